@@ -22,11 +22,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/utils"
 
 	"k8s.io/klog/v2"
 )
@@ -41,6 +43,8 @@ var (
 	rack                  = "default"
 	zone                  = "default"
 	region                = "default"
+	rackLabel             = ""
+	topologyKeys          = []string{}
 	endpoint              = "unix://csi/csi.sock"
 	kubeconfig            = ""
 	controller            = false
@@ -50,6 +54,12 @@ var (
 	showVersion           = false
 	conversionHealthzURL  = ""
 	dynamicDriveDiscovery = false
+	autoAccessTier        = false
+	healthCheckInterval   time.Duration
+	maxMediaErrors        uint64 = 100
+	maxReallocatedSectors uint64 = 100
+	maxPercentageUsed     int64  = 100
+	maxTemperature        int64  = 70
 )
 
 var driverCmd = &cobra.Command{
@@ -73,6 +83,10 @@ For more information, use '%s --help'
 			return fmt.Errorf("one among [--controller, --driver] should be set")
 		}
 
+		if err := utils.ValidateTopologyKeys(topologyKeys); err != nil {
+			return err
+		}
+
 		client.Init()
 		return run(c.Context(), args)
 	},
@@ -103,12 +117,20 @@ func init() {
 	driverCmd.Flags().StringVarP(&rack, "rack", "", rack, "identity of the rack in which this direct-csi is running")
 	driverCmd.Flags().StringVarP(&zone, "zone", "", zone, "identity of the zone in which this direct-csi is running")
 	driverCmd.Flags().StringVarP(&region, "region", "", region, "identity of the region in which this direct-csi is running")
+	driverCmd.Flags().StringVarP(&rackLabel, "rack-label", "", rackLabel, "node label key to read rack from; zone and region are read from 'topology.kubernetes.io/zone' and 'topology.kubernetes.io/region' node labels")
+	driverCmd.Flags().StringSliceVarP(&topologyKeys, "topology-keys", "", topologyKeys, "node label keys to read custom topology from e.g. 'enclosure,example.com/power-domain'")
 	driverCmd.Flags().StringVarP(&procfs, "procfs", "", procfs, "path to host /proc for accessing mount information")
 	driverCmd.Flags().BoolVarP(&controller, "controller", "", controller, "running in controller mode")
 	driverCmd.Flags().BoolVarP(&driver, "driver", "", driver, "run in driver mode")
 	driverCmd.Flags().BoolVarP(&loopbackOnly, "loopback-only", "", loopbackOnly, "Create and use loopback devices (FOR TESTING ONLY)")
 	driverCmd.Flags().StringVarP(&conversionHealthzURL, "conversion-healthz-url", "", conversionHealthzURL, "The URL of the conversion webhook healthz endpoint")
 	driverCmd.Flags().BoolVarP(&dynamicDriveDiscovery, "dynamic-drive-discovery", "", dynamicDriveDiscovery, "Enable dynamic drive discovery (disabled by default)")
+	driverCmd.Flags().BoolVarP(&autoAccessTier, "auto-access-tier", "", autoAccessTier, "Set access-tier of new drives by media type i.e. NVMe=hot, SSD=warm, HDD=cold (disabled by default)")
+	driverCmd.Flags().DurationVarP(&healthCheckInterval, "health-check-interval", "", healthCheckInterval, "Interval to check S.M.A.R.T. health of drives; zero disables the check (disabled by default)")
+	driverCmd.Flags().Uint64VarP(&maxMediaErrors, "max-media-errors", "", maxMediaErrors, "Mark drive unhealthy if its media errors exceed this value; zero disables the check")
+	driverCmd.Flags().Uint64VarP(&maxReallocatedSectors, "max-reallocated-sectors", "", maxReallocatedSectors, "Mark drive unhealthy if its reallocated sectors exceed this value; zero disables the check")
+	driverCmd.Flags().Int64VarP(&maxPercentageUsed, "max-percentage-used", "", maxPercentageUsed, "Mark drive unhealthy if its percentage used exceeds this value; zero disables the check")
+	driverCmd.Flags().Int64VarP(&maxTemperature, "max-temperature", "", maxTemperature, "Mark drive unhealthy if its temperature in Celsius exceeds this value; zero disables the check")
 
 	driverCmd.PersistentFlags().MarkHidden("alsologtostderr")
 	driverCmd.PersistentFlags().MarkHidden("log_backtrace_at")
@@ -169,7 +169,8 @@ func run(ctx context.Context, args []string) error {
 		}
 
 		if !dynamicDriveDiscovery {
-			discovery, err := discovery.NewDiscovery(ctx, identity, nodeID, rack, zone, region)
+			topology := node.GetTopology(ctx, identity, nodeID, rack, zone, region, rackLabel, topologyKeys)
+			discovery, err := discovery.NewDiscovery(ctx, nodeID, topology, autoAccessTier)
 			if err != nil {
 				return err
 			}
@@ -182,12 +183,21 @@ func run(ctx context.Context, args []string) error {
 			klog.V(3).Infof("This flag will be made default in the next major release version")
 		}
 
-		nodeSrv, err = node.NewNodeServer(ctx, identity, nodeID, rack, zone, region, dynamicDriveDiscovery, reflinkSupport, loopbackOnly)
+		nodeSrv, err = node.NewNodeServer(ctx, identity, nodeID, rack, zone, region, rackLabel, topologyKeys, dynamicDriveDiscovery, reflinkSupport, loopbackOnly, autoAccessTier)
 		if err != nil {
 			return err
 		}
 		klog.V(3).Infof("node server started")
 
+		if healthCheckInterval > 0 {
+			go node.StartHealthMonitor(ctx, nodeID, healthCheckInterval, node.HealthThresholds{
+				MediaErrors:        maxMediaErrors,
+				ReallocatedSectors: maxReallocatedSectors,
+				PercentageUsed:     maxPercentageUsed,
+				Temperature:        maxTemperature,
+			})
+		}
+
 		// Check if the volume objects are migrated and CRDs versions are in-sync
 		volume.SyncVolumes(ctx, nodeID)
 		klog.V(3).Infof("Volumes sync completed")
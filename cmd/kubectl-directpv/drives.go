@@ -62,4 +62,8 @@ func init() {
 	drivesCmd.AddCommand(drivesAccessTierCmd)
 	drivesCmd.AddCommand(releaseDrivesCmd)
 	drivesCmd.AddCommand(unreleaseDrivesCmd)
+	drivesCmd.AddCommand(drainDrivesCmd)
+	drivesCmd.AddCommand(taintDrivesCmd)
+	drivesCmd.AddCommand(untaintDrivesCmd)
+	drivesCmd.AddCommand(drivesLabelCmd)
 }
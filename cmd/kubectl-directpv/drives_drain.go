@@ -0,0 +1,168 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/utils"
+	"github.com/minio/directpv/pkg/volume"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+var moveVolumes = false
+
+var drainDrivesCmd = &cobra.Command{
+	Use:   "drain <drive_id> [<drive_id>...]",
+	Short: binaryNameTransform("cordon and drain drives in the {{ . }} cluster"),
+	Long:  "",
+	Example: binaryNameTransform(`
+# Cordon a drive by it's drive-id and list the volumes on it
+$ kubectl {{ . }} drives drain <drive_id>
+
+# Cordon a drive and move the volumes on it to other drives on the same node
+$ kubectl {{ . }} drives drain <drive_id> --move
+`),
+	RunE: func(c *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return fmt.Errorf("atleast one drive id must be specified. please use '%s' for examples to drain drives", utils.Bold("--help"))
+		}
+		return drainDrives(c.Context(), args)
+	},
+	Aliases: []string{},
+}
+
+func init() {
+	drainDrivesCmd.PersistentFlags().BoolVarP(&moveVolumes, "move", "", moveVolumes, "move the volumes to other drives on the same node")
+}
+
+func getNodeDrives(ctx context.Context, nodeName string) ([]directcsi.DirectCSIDrive, error) {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	resultCh, err := client.ListDrives(ctx,
+		[]utils.LabelValue{utils.NewLabelValue(nodeName)},
+		nil,
+		nil,
+		client.MaxThreadCount)
+	if err != nil {
+		return nil, err
+	}
+
+	drives := []directcsi.DirectCSIDrive{}
+	for result := range resultCh {
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		drives = append(drives, result.Drive)
+	}
+	return drives, nil
+}
+
+func drainVolume(ctx context.Context, volumeName string, source *directcsi.DirectCSIDrive, drives []directcsi.DirectCSIDrive) error {
+	volumeInterface := client.GetLatestDirectCSIVolumeInterface()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vol, err := volumeInterface.Get(ctx, volumeName, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			return err
+		}
+
+		if vol.Status.TargetDrive != "" {
+			return nil
+		}
+
+		target := volume.SelectTargetDrive(vol, source, drives)
+		if target == nil {
+			return fmt.Errorf("no drive found on node %s to move volume %s", vol.Status.NodeName, vol.Name)
+		}
+
+		vol.Status.TargetDrive = target.Name
+		if _, err = volumeInterface.Update(ctx, vol, metav1.UpdateOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()}); err != nil {
+			return err
+		}
+
+		// Account the capacity locally so that subsequent volumes are spread across drives.
+		target.Status.FreeCapacity -= vol.Status.TotalCapacity
+		fmt.Printf("moving volume %s to drive %s\n", utils.Bold(vol.Name), utils.Bold(target.Name))
+		return nil
+	})
+}
+
+func drainDrives(ctx context.Context, IDArgs []string) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	return processFilteredDrives(
+		ctx,
+		IDArgs,
+		func(drive *directcsi.DirectCSIDrive) bool {
+			switch drive.Status.DriveStatus {
+			case directcsi.DriveStatusReady, directcsi.DriveStatusInUse:
+				return true
+			default:
+				klog.Errorf("%s in '%s' state. only 'ready' or 'inuse' drives can be drained", utils.Bold(drive.Name), string(drive.Status.DriveStatus))
+				return false
+			}
+		},
+		func(drive *directcsi.DirectCSIDrive) error {
+			if drive.Spec.DriveTaint == nil {
+				drive.Spec.DriveTaint = map[string]string{}
+			}
+			drive.Spec.DriveTaint[directcsi.DriveTaintCordoned] = ""
+			volume.UpdateDrainedCondition(drive)
+			return nil
+		},
+		func(ctx context.Context, drive *directcsi.DirectCSIDrive) error {
+			if err := defaultDriveUpdateFunc()(ctx, drive); err != nil {
+				return err
+			}
+
+			volumeNames := volume.GetVolumeNames(drive)
+			fmt.Printf("drive %s cordoned; %d volume(s) remaining\n", utils.Bold(drive.Name), len(volumeNames))
+			if !moveVolumes {
+				for _, volumeName := range volumeNames {
+					fmt.Printf("  %s\n", volumeName)
+				}
+				return nil
+			}
+
+			if len(volumeNames) == 0 {
+				return nil
+			}
+
+			drives, err := getNodeDrives(ctx, drive.Status.NodeName)
+			if err != nil {
+				return err
+			}
+
+			for _, volumeName := range volumeNames {
+				if err := drainVolume(ctx, volumeName, drive, drives); err != nil {
+					klog.Errorf("unable to move volume %s; %v", utils.Bold(volumeName), err)
+				}
+			}
+			return nil
+		},
+		DriveDrain,
+	)
+}
@@ -30,10 +30,14 @@ import (
 	"k8s.io/klog/v2"
 )
 
-const xfs = "xfs"
+const (
+	xfs  = "xfs"
+	ext4 = "ext4"
+)
 
 var (
-	force = false
+	force  = false
+	fsType = xfs
 )
 
 var formatDrivesCmd = &cobra.Command{
@@ -68,6 +72,9 @@ $ kubectl {{ . }} drives format --nodes=direct-1,othernode-2 --status=available
 # Combine multiple parameters using ellipses notations
 $ kubectl {{ . }} drives format --nodes "direct-{3...4}" --drives "/dev/xvd{b...f}"
 
+# Format all available drives with ext4 filesystem
+$ kubectl {{ . }} drives format --all --fs-type=ext4
+
 # Format a drive by it's drive-id
 $ kubectl {{ . }} drives format <drive_id>
 
@@ -86,6 +93,11 @@ $ kubectl {{ . }} drives format <drive_id_1> <drive_id_2>
 		if err := validateDriveSelectors(); err != nil {
 			return err
 		}
+		switch fsType {
+		case xfs, ext4:
+		default:
+			return fmt.Errorf("unsupported filesystem type %v; the possible values are %v|%v", fsType, xfs, ext4)
+		}
 		if len(driveGlobs) > 0 || len(nodeGlobs) > 0 {
 			klog.Warning("Glob matches will be deprecated soon. Please use ellipses instead")
 		}
@@ -99,6 +111,7 @@ func init() {
 	formatDrivesCmd.PersistentFlags().StringSliceVarP(&nodes, "nodes", "n", nodes, "filter by node name(s) (also accepts ellipses range notations)")
 	formatDrivesCmd.PersistentFlags().BoolVarP(&all, "all", "a", all, "format all available drives")
 	formatDrivesCmd.PersistentFlags().BoolVarP(&force, "force", "f", force, "force format a drive even if a FS is already present")
+	formatDrivesCmd.PersistentFlags().StringVarP(&fsType, "fs-type", "", fsType, "filesystem to format the drives with. The possible values are xfs|ext4")
 	formatDrivesCmd.PersistentFlags().StringSliceVarP(&accessTiers, "access-tier", "", accessTiers,
 		"format based on access-tier set. The possible values are hot|cold|warm")
 }
@@ -141,7 +154,7 @@ func formatDrives(ctx context.Context, IDArgs []string) error {
 		func(drive *directcsi.DirectCSIDrive) error {
 			drive.Spec.DirectCSIOwned = true
 			drive.Spec.RequestedFormat = &directcsi.RequestedFormat{
-				Filesystem: xfs,
+				Filesystem: fsType,
 				Force:      force,
 			}
 			return nil
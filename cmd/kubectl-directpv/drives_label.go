@@ -0,0 +1,85 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+var drivesLabelCmd = &cobra.Command{
+	Use:   "label",
+	Short: binaryNameTransform("set/unset user-defined labels on {{ . }} drives"),
+	Long:  "",
+	Aliases: []string{
+		"labels",
+	},
+}
+
+func init() {
+	drivesLabelCmd.AddCommand(labelSetCmd)
+	drivesLabelCmd.AddCommand(labelUnsetCmd)
+}
+
+// toUserLabelKey converts the key to user-defined drive label key.
+func toUserLabelKey(key string) (string, error) {
+	labelKey := utils.DriveUserLabelPrefix + key
+	if key == "" || strings.Contains(key, "/") {
+		return "", fmt.Errorf("invalid label key %v", key)
+	}
+	if errs := validation.IsQualifiedName(labelKey); len(errs) != 0 {
+		return "", fmt.Errorf("invalid label key %v; %v", key, strings.Join(errs, "; "))
+	}
+	return labelKey, nil
+}
+
+// parseUserLabels parses labels of the form "<key>=<value>" into user-defined drive labels.
+func parseUserLabels(args []string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, arg := range args {
+		tokens := strings.SplitN(arg, "=", 2)
+		if len(tokens) != 2 {
+			return nil, fmt.Errorf("invalid label %v; label must be of the form <key>=<value>", arg)
+		}
+		key, err := toUserLabelKey(tokens[0])
+		if err != nil {
+			return nil, err
+		}
+		if errs := validation.IsValidLabelValue(tokens[1]); len(errs) != 0 {
+			return nil, fmt.Errorf("invalid label value %v; %v", tokens[1], strings.Join(errs, "; "))
+		}
+		labels[key] = tokens[1]
+	}
+	return labels, nil
+}
+
+func setDriveLabels(drive *directcsi.DirectCSIDrive, labels map[string]string) {
+	driveLabels := drive.GetLabels()
+	if driveLabels == nil {
+		driveLabels = map[string]string{}
+	}
+	for key, value := range labels {
+		driveLabels[key] = value
+	}
+	drive.SetLabels(driveLabels)
+}
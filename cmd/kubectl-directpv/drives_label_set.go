@@ -0,0 +1,102 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+)
+
+var labelSetCmd = &cobra.Command{
+	Use:   "set <key>=<value>...",
+	Short: binaryNameTransform("set user-defined labels on {{ . }} drive(s)"),
+	Long:  "",
+	Example: binaryNameTransform(`
+# Sets the 'vendor=samsung' label on 'nvme0n1' drives in all nodes
+$ kubectl {{ . }} drives label set vendor=samsung --drives '/dev/nvme0n1'
+
+# Sets multiple labels on selective drives using ellipses notation for drive paths
+$ kubectl {{ . }} drives label set media=ssd enclosure=e1 --drives '/dev/sd{a...z}'
+
+# Sets the 'enclosure=e2' label on drives from selective nodes using ellipses notation for node names
+$ kubectl {{ . }} drives label set enclosure=e2 --nodes 'direct-{1...3}'
+
+# Combine multiple parameters using csv
+$ kubectl {{ . }} drives label set media=nvme --nodes=direct-1,othernode-2 --status=ready
+`),
+	RunE: func(c *cobra.Command, args []string) error {
+		if !all {
+			if len(drives) == 0 && len(nodes) == 0 && len(status) == 0 && len(accessTiers) == 0 {
+				return fmt.Errorf("atleast one of '%s', '%s', '%s', '%s', or '%s' must be specified",
+					utils.Bold("--all"),
+					utils.Bold("--drives"),
+					utils.Bold("--nodes"),
+					utils.Bold("--status"),
+					utils.Bold("--access-tier"))
+			}
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("atleast one label must be specified. please use '%s' for examples to set labels", utils.Bold("--help"))
+		}
+		labels, err := parseUserLabels(args)
+		if err != nil {
+			return err
+		}
+		if err := validateDriveSelectors(); err != nil {
+			return err
+		}
+		if len(driveGlobs) > 0 || len(nodeGlobs) > 0 || len(statusGlobs) > 0 {
+			klog.Warning("Glob matches will be deprecated soon. Please use ellipses instead")
+		}
+		return setLabels(c.Context(), labels)
+	},
+	Aliases: []string{},
+}
+
+func init() {
+	labelSetCmd.PersistentFlags().StringSliceVarP(&drives, "drives", "d", drives, "filter by drive path(s) (also accepts ellipses range notations)")
+	labelSetCmd.PersistentFlags().StringSliceVarP(&nodes, "nodes", "n", nodes, "filter by node name(s) (also accepts ellipses range notations)")
+	labelSetCmd.PersistentFlags().BoolVarP(&all, "all", "a", all, "label all available drives")
+	labelSetCmd.PersistentFlags().StringSliceVarP(&status, "status", "s", status, fmt.Sprintf("match based on drive status [%s]", strings.Join(directcsi.SupportedStatusSelectorValues(), ", ")))
+	labelSetCmd.PersistentFlags().StringSliceVarP(&accessTiers, "access-tier", "", accessTiers, "match based on access-tier set. The possible values are [hot,cold,warm] ")
+}
+
+func setLabels(ctx context.Context, labels map[string]string) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	return processFilteredDrives(
+		ctx,
+		nil,
+		func(drive *directcsi.DirectCSIDrive) bool {
+			return drive.Status.DriveStatus != directcsi.DriveStatusUnavailable
+		},
+		func(drive *directcsi.DirectCSIDrive) error {
+			setDriveLabels(drive, labels)
+			return nil
+		},
+		defaultDriveUpdateFunc(),
+		SetLabel,
+	)
+}
@@ -0,0 +1,110 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+)
+
+var labelUnsetCmd = &cobra.Command{
+	Use:   "unset <key>...",
+	Short: binaryNameTransform("remove user-defined labels from {{ . }} drive(s)"),
+	Long:  "",
+	Example: binaryNameTransform(`
+# Unsets the 'vendor' label on all {{ . }} drives
+$ kubectl {{ . }} drives label unset vendor --all
+
+# Unsets the 'media' and 'enclosure' labels on drives from a particular node
+$ kubectl {{ . }} drives label unset media enclosure --nodes=direct-1
+`),
+	RunE: func(c *cobra.Command, args []string) error {
+		if !all {
+			if len(drives) == 0 && len(nodes) == 0 && len(status) == 0 && len(accessTiers) == 0 {
+				return fmt.Errorf("atleast one of '%s', '%s', '%s', '%s', or '%s' must be specified",
+					utils.Bold("--all"),
+					utils.Bold("--drives"),
+					utils.Bold("--nodes"),
+					utils.Bold("--status"),
+					utils.Bold("--access-tier"))
+			}
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("atleast one label key must be specified. please use '%s' for examples to unset labels", utils.Bold("--help"))
+		}
+		keys := []string{}
+		for _, arg := range args {
+			key, err := toUserLabelKey(arg)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, key)
+		}
+		if err := validateDriveSelectors(); err != nil {
+			return err
+		}
+		if len(driveGlobs) > 0 || len(nodeGlobs) > 0 || len(statusGlobs) > 0 {
+			klog.Warning("Glob matches will be deprecated soon. Please use ellipses instead")
+		}
+		return unsetLabels(c.Context(), keys)
+	},
+	Aliases: []string{},
+}
+
+func init() {
+	labelUnsetCmd.PersistentFlags().StringSliceVarP(&drives, "drives", "d", drives, "filter by drive path(s) (also accepts ellipses range notations)")
+	labelUnsetCmd.PersistentFlags().StringSliceVarP(&nodes, "nodes", "n", nodes, "filter by node name(s) (also accepts ellipses range notations)")
+	labelUnsetCmd.PersistentFlags().BoolVarP(&all, "all", "a", all, "unlabel all available drives")
+	labelUnsetCmd.PersistentFlags().StringSliceVarP(&status, "status", "s", status, fmt.Sprintf("match based on drive status [%s]", strings.Join(directcsi.SupportedStatusSelectorValues(), ", ")))
+	labelUnsetCmd.PersistentFlags().StringSliceVarP(&accessTiers, "access-tier", "", accessTiers, "match based on access-tier set. The possible values are [hot,cold,warm] ")
+}
+
+func unsetLabels(ctx context.Context, keys []string) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	return processFilteredDrives(
+		ctx,
+		nil,
+		func(drive *directcsi.DirectCSIDrive) bool {
+			labels := drive.GetLabels()
+			for _, key := range keys {
+				if _, found := labels[key]; found {
+					return true
+				}
+			}
+			return false
+		},
+		func(drive *directcsi.DirectCSIDrive) error {
+			labels := drive.GetLabels()
+			for _, key := range keys {
+				delete(labels, key)
+			}
+			drive.SetLabels(labels)
+			return nil
+		},
+		defaultDriveUpdateFunc(),
+		UnSetLabel,
+	)
+}
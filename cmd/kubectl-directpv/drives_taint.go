@@ -0,0 +1,192 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+)
+
+var taintDrivesCmd = &cobra.Command{
+	Use:   "taint <key>[=<value>][:<effect>]...",
+	Short: binaryNameTransform("taint {{ . }} drive(s) to place volumes tolerating the taints only"),
+	Long:  "",
+	Example: binaryNameTransform(`
+# Reserve the 'nvme0n1' drives in all nodes for volumes tolerating 'tenant=acme'
+$ kubectl {{ . }} drives taint tenant=acme:NoSchedule --drives '/dev/nvme0n1'
+
+# Avoid selective drives using ellipses notation for drive paths unless 'slow' is tolerated
+$ kubectl {{ . }} drives taint slow:PreferNoSchedule --drives '/dev/sd{a...z}'
+
+# Taint all drives from selective nodes using ellipses notation for node names
+$ kubectl {{ . }} drives taint tenant=acme --nodes 'direct-{1...3}'
+
+# Combine multiple parameters using csv
+$ kubectl {{ . }} drives taint tenant=acme:NoSchedule --nodes=direct-1,othernode-2 --drives=/dev/nvme0n1
+`),
+	RunE: func(c *cobra.Command, args []string) error {
+		if !all {
+			if len(drives) == 0 && len(nodes) == 0 && len(accessTiers) == 0 {
+				return fmt.Errorf("atleast one of '%s', '%s', '%s' or '%s' must be specified",
+					utils.Bold("--all"),
+					utils.Bold("--drives"),
+					utils.Bold("--nodes"),
+					utils.Bold("--access-tier"))
+			}
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("atleast one taint must be specified. please use '%s' for examples to taint drives", utils.Bold("--help"))
+		}
+		taints, err := parseTaints(args)
+		if err != nil {
+			return err
+		}
+		if err := validateDriveSelectors(); err != nil {
+			return err
+		}
+		if len(driveGlobs) > 0 || len(nodeGlobs) > 0 {
+			klog.Warning("Glob matches will be deprecated soon. Please use ellipses instead")
+		}
+		return taintDrives(c.Context(), taints)
+	},
+	Aliases: []string{},
+}
+
+var untaintDrivesCmd = &cobra.Command{
+	Use:   "untaint <key>...",
+	Short: binaryNameTransform("remove taints from {{ . }} drive(s)"),
+	Long:  "",
+	Example: binaryNameTransform(`
+# Remove 'tenant' taint from the 'nvme0n1' drives in all nodes
+$ kubectl {{ . }} drives untaint tenant --drives '/dev/nvme0n1'
+
+# Remove 'tenant' and 'slow' taints from all drives
+$ kubectl {{ . }} drives untaint tenant slow --all
+`),
+	RunE: func(c *cobra.Command, args []string) error {
+		if !all {
+			if len(drives) == 0 && len(nodes) == 0 && len(accessTiers) == 0 {
+				return fmt.Errorf("atleast one of '%s', '%s', '%s' or '%s' must be specified",
+					utils.Bold("--all"),
+					utils.Bold("--drives"),
+					utils.Bold("--nodes"),
+					utils.Bold("--access-tier"))
+			}
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("atleast one taint key must be specified. please use '%s' for examples to untaint drives", utils.Bold("--help"))
+		}
+		if err := validateDriveSelectors(); err != nil {
+			return err
+		}
+		if len(driveGlobs) > 0 || len(nodeGlobs) > 0 {
+			klog.Warning("Glob matches will be deprecated soon. Please use ellipses instead")
+		}
+		return untaintDrives(c.Context(), args)
+	},
+	Aliases: []string{},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{taintDrivesCmd, untaintDrivesCmd} {
+		cmd.PersistentFlags().StringSliceVarP(&drives, "drives", "d", drives, "filter by drive path(s) (also accepts ellipses range notations)")
+		cmd.PersistentFlags().StringSliceVarP(&nodes, "nodes", "n", nodes, "filter by node name(s) (also accepts ellipses range notations)")
+		cmd.PersistentFlags().BoolVarP(&all, "all", "a", all, "select all drives")
+		cmd.PersistentFlags().StringSliceVarP(&accessTiers, "access-tier", "", accessTiers, "filter based on access-tier set. The possible values are hot|cold|warm")
+	}
+}
+
+// parseTaints parses taints of the form "<key>[=<value>][:<effect>]" into drive taint map.
+func parseTaints(args []string) (map[string]string, error) {
+	taints := map[string]string{}
+	for _, arg := range args {
+		key, value := arg, ""
+		effect := directcsi.DriveTaintEffectNoSchedule
+		if index := strings.LastIndex(key, ":"); index >= 0 {
+			var err error
+			if effect, err = directcsi.ToDriveTaintEffect(key[index+1:]); err != nil {
+				return nil, fmt.Errorf("invalid taint %v; %w", arg, err)
+			}
+			key = key[:index]
+		}
+		if index := strings.Index(key, "="); index >= 0 {
+			key, value = key[:index], key[index+1:]
+		}
+		if key == "" {
+			return nil, fmt.Errorf("invalid taint %v; empty key", arg)
+		}
+		taints[key] = value + ":" + string(effect)
+	}
+	return taints, nil
+}
+
+func taintDrives(ctx context.Context, taints map[string]string) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	return processFilteredDrives(
+		ctx,
+		nil,
+		func(drive *directcsi.DirectCSIDrive) bool {
+			return drive.Status.DriveStatus != directcsi.DriveStatusUnavailable
+		},
+		func(drive *directcsi.DirectCSIDrive) error {
+			if drive.Spec.DriveTaint == nil {
+				drive.Spec.DriveTaint = map[string]string{}
+			}
+			for key, value := range taints {
+				drive.Spec.DriveTaint[key] = value
+			}
+			return nil
+		},
+		defaultDriveUpdateFunc(),
+		DriveTaint,
+	)
+}
+
+func untaintDrives(ctx context.Context, keys []string) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	return processFilteredDrives(
+		ctx,
+		nil,
+		func(drive *directcsi.DirectCSIDrive) bool {
+			for _, key := range keys {
+				if _, found := drive.Spec.DriveTaint[key]; found {
+					return true
+				}
+			}
+			return false
+		},
+		func(drive *directcsi.DirectCSIDrive) error {
+			for _, key := range keys {
+				delete(drive.Spec.DriveTaint, key)
+			}
+			return nil
+		},
+		defaultDriveUpdateFunc(),
+		DriveUntaint,
+	)
+}
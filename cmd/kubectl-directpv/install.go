@@ -19,6 +19,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -50,6 +51,10 @@ var (
 	seccompProfile         = ""
 	apparmorProfile        = ""
 	dynamicDriveDiscovery  = false
+	autoAccessTier         = false
+	healthCheckInterval    time.Duration
+	rackLabel              = ""
+	topologyKeys           = []string{}
 	auditInstall           = "install"
 )
 
@@ -68,6 +73,10 @@ func init() {
 	installCmd.PersistentFlags().BoolVarP(&loopbackOnly, "loopback-only", "", loopbackOnly, "Uses 4 free loopback devices per node and treat them as DirectCSIDrive resources. This is recommended only for testing/development purposes")
 	installCmd.PersistentFlags().MarkHidden("loopback-only")
 	installCmd.PersistentFlags().BoolVarP(&dynamicDriveDiscovery, "enable-dynamic-discovery", "", dynamicDriveDiscovery, "Enable dynamic drive discovery")
+	installCmd.PersistentFlags().BoolVarP(&autoAccessTier, "auto-access-tier", "", autoAccessTier, "Set access-tier of new drives by media type i.e. NVMe=hot, SSD=warm, HDD=cold")
+	installCmd.PersistentFlags().DurationVarP(&healthCheckInterval, "health-check-interval", "", healthCheckInterval, "Check S.M.A.R.T. health of drives at this interval and mark unhealthy drives unschedulable")
+	installCmd.PersistentFlags().StringVarP(&rackLabel, "rack-label", "", rackLabel, "Node label key to read rack of the node from")
+	installCmd.PersistentFlags().StringSliceVarP(&topologyKeys, "topology-keys", "", topologyKeys, "Node label keys to read custom topology from e.g. 'enclosure,example.com/power-domain'")
 }
 
 func install(ctx context.Context, args []string) (err error) {
@@ -88,6 +97,9 @@ func install(ctx context.Context, args []string) (err error) {
 	if err != nil {
 		return fmt.Errorf("invalid tolerations. format of '--tolerations' must be <key>[=value]:<NoSchedule|PreferNoSchedule|NoExecute>")
 	}
+	if err := utils.ValidateTopologyKeys(topologyKeys); err != nil {
+		return fmt.Errorf("invalid topology keys. %v", err)
+	}
 
 	if !dynamicDriveDiscovery {
 		klog.Infof("Enable dynamic drive change management using " + utils.Bold("--enable-dynamic-discovery") + " flag")
@@ -119,6 +131,10 @@ func install(ctx context.Context, args []string) (err error) {
 		SeccompProfile:             seccompProfile,
 		ApparmorProfile:            apparmorProfile,
 		DynamicDriveDiscovery:      dynamicDriveDiscovery,
+		AutoAccessTier:             autoAccessTier,
+		HealthCheckInterval:        healthCheckInterval,
+		RackLabel:                  rackLabel,
+		TopologyKeys:               topologyKeys,
 		DryRun:                     dryRun,
 		AuditFile:                  file,
 	}
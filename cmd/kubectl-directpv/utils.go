@@ -62,6 +62,11 @@ const (
 	UnSetAcessTier Command = "unSetAccessTier"
 	Format         Command = "format"
 	DriveRelease   Command = "driveRelease"
+	DriveDrain     Command = "driveDrain"
+	DriveTaint     Command = "driveTaint"
+	DriveUntaint   Command = "driveUntaint"
+	SetLabel       Command = "setLabel"
+	UnSetLabel     Command = "unSetLabel"
 )
 
 func printableString(s string) string {
@@ -64,4 +64,5 @@ func validateVolumeSelectors() (err error) {
 
 func init() {
 	volumesCmd.AddCommand(listVolumesCmd)
+	volumesCmd.AddCommand(moveVolumeCmd)
 }
@@ -0,0 +1,81 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/utils"
+	"github.com/minio/directpv/pkg/volume"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+var moveVolumeCmd = &cobra.Command{
+	Use:   "move <volume_name> <drive_id>",
+	Short: binaryNameTransform("move an unpublished volume to another drive on the same node in the {{ . }} cluster"),
+	Long:  "",
+	Example: binaryNameTransform(`
+# Move a volume to a drive by it's drive-id
+$ kubectl {{ . }} volumes move <volume_name> <drive_id>
+`),
+	RunE: func(c *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return fmt.Errorf("volume name and drive id must be specified. please use '%s' for examples to move a volume", utils.Bold("--help"))
+		}
+		return moveVolume(c.Context(), args[0], args[1])
+	},
+	Aliases: []string{},
+}
+
+func moveVolume(ctx context.Context, volumeName, driveName string) error {
+	volumeInterface := client.GetLatestDirectCSIVolumeInterface()
+	driveInterface := client.GetLatestDirectCSIDriveInterface()
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vol, err := volumeInterface.Get(ctx, volumeName, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			return err
+		}
+
+		if vol.Status.TargetDrive != "" {
+			return fmt.Errorf("volume %s is already being moved to drive %s", utils.Bold(vol.Name), utils.Bold(vol.Status.TargetDrive))
+		}
+
+		source, err := driveInterface.Get(ctx, vol.Status.Drive, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			return err
+		}
+
+		target, err := driveInterface.Get(ctx, driveName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			return err
+		}
+
+		if err = volume.CheckMove(vol, source, target); err != nil {
+			return err
+		}
+
+		vol.Status.TargetDrive = target.Name
+		_, err = volumeInterface.Update(ctx, vol, metav1.UpdateOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		return err
+	})
+}
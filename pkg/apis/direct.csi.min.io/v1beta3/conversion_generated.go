@@ -254,6 +254,11 @@ func autoConvert_v1beta3_DirectCSIDriveStatus_To_v1beta2_DirectCSIDriveStatus(in
 	// INFO: in.Partitioned opted out of conversion generation
 	// INFO: in.SwapOn opted out of conversion generation
 	// INFO: in.Master opted out of conversion generation
+	// INFO: in.BlockVolume opted out of conversion generation
+	// INFO: in.Rotational opted out of conversion generation
+	// INFO: in.Transport opted out of conversion generation
+	// INFO: in.Scheduler opted out of conversion generation
+	// INFO: in.NrRequests opted out of conversion generation
 	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
@@ -322,7 +327,17 @@ func Convert_v1beta2_DirectCSIVolume_To_v1beta3_DirectCSIVolume(in *v1beta2.Dire
 
 func autoConvert_v1beta3_DirectCSIVolumeList_To_v1beta2_DirectCSIVolumeList(in *DirectCSIVolumeList, out *v1beta2.DirectCSIVolumeList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]v1beta2.DirectCSIVolume)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta2.DirectCSIVolume, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta3_DirectCSIVolume_To_v1beta2_DirectCSIVolume(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -333,7 +348,17 @@ func Convert_v1beta3_DirectCSIVolumeList_To_v1beta2_DirectCSIVolumeList(in *Dire
 
 func autoConvert_v1beta2_DirectCSIVolumeList_To_v1beta3_DirectCSIVolumeList(in *v1beta2.DirectCSIVolumeList, out *DirectCSIVolumeList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]DirectCSIVolume)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DirectCSIVolume, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta2_DirectCSIVolume_To_v1beta3_DirectCSIVolume(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -348,9 +373,13 @@ func autoConvert_v1beta3_DirectCSIVolumeStatus_To_v1beta2_DirectCSIVolumeStatus(
 	out.HostPath = in.HostPath
 	out.StagingPath = in.StagingPath
 	out.ContainerPath = in.ContainerPath
+	// INFO: in.TargetPaths opted out of conversion generation
+	// INFO: in.ThrottledPods opted out of conversion generation
 	out.TotalCapacity = in.TotalCapacity
 	out.AvailableCapacity = in.AvailableCapacity
 	out.UsedCapacity = in.UsedCapacity
+	// INFO: in.ContentSource opted out of conversion generation
+	// INFO: in.TargetDrive opted out of conversion generation
 	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
@@ -150,6 +150,82 @@ func (in *DirectCSIDriveStatus) DeepCopy() *DirectCSIDriveStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectCSISnapshot) DeepCopyInto(out *DirectCSISnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectCSISnapshot.
+func (in *DirectCSISnapshot) DeepCopy() *DirectCSISnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectCSISnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DirectCSISnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectCSISnapshotList) DeepCopyInto(out *DirectCSISnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DirectCSISnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectCSISnapshotList.
+func (in *DirectCSISnapshotList) DeepCopy() *DirectCSISnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectCSISnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DirectCSISnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectCSISnapshotStatus) DeepCopyInto(out *DirectCSISnapshotStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectCSISnapshotStatus.
+func (in *DirectCSISnapshotStatus) DeepCopy() *DirectCSISnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectCSISnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DirectCSIVolume) DeepCopyInto(out *DirectCSIVolume) {
 	*out = *in
@@ -213,6 +289,23 @@ func (in *DirectCSIVolumeList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DirectCSIVolumeStatus) DeepCopyInto(out *DirectCSIVolumeStatus) {
 	*out = *in
+	if in.TargetPaths != nil {
+		in, out := &in.TargetPaths, &out.TargetPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ThrottledPods != nil {
+		in, out := &in.ThrottledPods, &out.ThrottledPods
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ContentSource != nil {
+		in, out := &in.ContentSource, &out.ContentSource
+		*out = new(VolumeContentSource)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -253,3 +346,19 @@ func (in *RequestedFormat) DeepCopy() *RequestedFormat {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeContentSource) DeepCopyInto(out *VolumeContentSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeContentSource.
+func (in *VolumeContentSource) DeepCopy() *VolumeContentSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeContentSource)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -21,6 +21,8 @@ import (
 	"strings"
 
 	"github.com/minio/directpv/pkg/matcher"
+
+	"k8s.io/apimachinery/pkg/api/meta"
 )
 
 func SupportedStatusSelectorValues() []string {
@@ -96,3 +98,35 @@ func (drive *DirectCSIDrive) MatchGlob(nodes, drives, status []string) bool {
 func (drive *DirectCSIDrive) MatchDriveStatus(driveStatusList []DriveStatus) bool {
 	return matcher.StringIn(DriveStatusListToStrings(driveStatusList), string(drive.Status.DriveStatus))
 }
+
+// IsCordoned returns whether the drive is cordoned for new volumes.
+func (drive *DirectCSIDrive) IsCordoned() bool {
+	_, found := drive.Spec.DriveTaint[DriveTaintCordoned]
+	return found
+}
+
+// IsUnhealthy returns whether the drive failed S.M.A.R.T. health check.
+func (drive *DirectCSIDrive) IsUnhealthy() bool {
+	return meta.IsStatusConditionFalse(drive.Status.Conditions, string(DirectCSIDriveConditionHealthy))
+}
+
+// ToDriveTaintEffect converts string value to DriveTaintEffect.
+func ToDriveTaintEffect(value string) (DriveTaintEffect, error) {
+	switch effect := DriveTaintEffect(value); effect {
+	case DriveTaintEffectNoSchedule, DriveTaintEffectPreferNoSchedule:
+		return effect, nil
+	default:
+		return "", fmt.Errorf("unknown drive taint effect %v; the possible values are %v|%v", value, DriveTaintEffectNoSchedule, DriveTaintEffectPreferNoSchedule)
+	}
+}
+
+// ParseDriveTaintValue parses drive taint value of the form "<value>:<effect>" into value
+// and effect; the effect defaults to NoSchedule if not specified.
+func ParseDriveTaintValue(taintValue string) (value string, effect DriveTaintEffect, err error) {
+	index := strings.LastIndex(taintValue, ":")
+	if index < 0 {
+		return taintValue, DriveTaintEffectNoSchedule, nil
+	}
+	effect, err = ToDriveTaintEffect(taintValue[index+1:])
+	return taintValue[:index], effect, err
+}
@@ -30,14 +30,18 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIDrive":        schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDrive(ref),
-		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIDriveList":    schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveList(ref),
-		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIDriveSpec":    schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveSpec(ref),
-		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIDriveStatus":  schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveStatus(ref),
-		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIVolume":       schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolume(ref),
-		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIVolumeList":   schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolumeList(ref),
-		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIVolumeStatus": schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolumeStatus(ref),
-		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.RequestedFormat":       schema_pkg_apis_directcsiminio_v1beta3_RequestedFormat(ref),
+		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIDrive":          schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDrive(ref),
+		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIDriveList":      schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveList(ref),
+		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIDriveSpec":      schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveSpec(ref),
+		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIDriveStatus":    schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveStatus(ref),
+		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSISnapshot":       schema_pkg_apis_directcsiminio_v1beta3_DirectCSISnapshot(ref),
+		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSISnapshotList":   schema_pkg_apis_directcsiminio_v1beta3_DirectCSISnapshotList(ref),
+		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSISnapshotStatus": schema_pkg_apis_directcsiminio_v1beta3_DirectCSISnapshotStatus(ref),
+		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIVolume":         schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolume(ref),
+		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIVolumeList":     schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolumeList(ref),
+		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIVolumeStatus":   schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolumeStatus(ref),
+		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.RequestedFormat":         schema_pkg_apis_directcsiminio_v1beta3_RequestedFormat(ref),
+		"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.VolumeContentSource":     schema_pkg_apis_directcsiminio_v1beta3_VolumeContentSource(ref),
 	}
 }
 
@@ -424,6 +428,41 @@ func schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveStatus(ref common.Refe
 							Format: "",
 						},
 					},
+					"blockVolume": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BlockVolume is the name of the raw block volume this drive is dedicated to.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rotational": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rotational denotes whether the drive is a rotational device such as HDD.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"transport": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Transport is the transport of the drive such as nvme, sata, sas, usb or virtio.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"scheduler": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Scheduler is the active I/O scheduler of the drive.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"nrRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NrRequests is the maximum number of queued requests of the drive.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 					"conditions": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -456,6 +495,151 @@ func schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveStatus(ref common.Refe
 	}
 }
 
+func schema_pkg_apis_directcsiminio_v1beta3_DirectCSISnapshot(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DirectCSISnapshot denotes snapshot CRD object.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSISnapshotStatus"),
+						},
+					},
+				},
+				Required: []string{"metadata"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSISnapshotStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_directcsiminio_v1beta3_DirectCSISnapshotList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DirectCSISnapshotList denotes list of snapshots.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "metdata is the standard list metadata.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSISnapshot"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.DirectCSISnapshot", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_directcsiminio_v1beta3_DirectCSISnapshotStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DirectCSISnapshotStatus denotes snapshot information.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"sourceVolume": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"drive": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"nodeName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"hostPath": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"size": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+					"readyToUse": {
+						SchemaProps: spec.SchemaProps{
+							Default: false,
+							Type:    []string{"boolean"},
+							Format:  "",
+						},
+					},
+				},
+				Required: []string{"sourceVolume"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolume(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -585,6 +769,41 @@ func schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolumeStatus(ref common.Ref
 							Format: "",
 						},
 					},
+					"targetPaths": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"throttledPods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThrottledPods maps publish target paths to the UIDs of the pods having I/O limits set.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"totalCapacity": {
 						SchemaProps: spec.SchemaProps{
 							Default: 0,
@@ -606,6 +825,18 @@ func schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolumeStatus(ref common.Ref
 							Format:  "int64",
 						},
 					},
+					"contentSource": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.VolumeContentSource"),
+						},
+					},
+					"targetDrive": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TargetDrive is the drive on the same node the volume is requested to be moved to.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"conditions": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -633,7 +864,7 @@ func schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolumeStatus(ref common.Ref
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Condition"},
+			"github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3.VolumeContentSource", "k8s.io/apimachinery/pkg/apis/meta/v1.Condition"},
 	}
 }
 
@@ -692,3 +923,31 @@ func schema_pkg_apis_directcsiminio_v1beta3_RequestedFormat(ref common.Reference
 		},
 	}
 }
+
+func schema_pkg_apis_directcsiminio_v1beta3_VolumeContentSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VolumeContentSource denotes the source the volume is populated from.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+				},
+				Required: []string{"kind", "name"},
+			},
+		},
+	}
+}
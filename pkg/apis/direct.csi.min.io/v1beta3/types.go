@@ -32,6 +32,12 @@ const (
 
 	// DirectCSIDriveFinalizerPrefix denotes prefix finalizer.
 	DirectCSIDriveFinalizerPrefix = Group + ".volume/"
+
+	// DirectCSIDriveFinalizerSnapshotPrefix denotes snapshot prefix finalizer.
+	DirectCSIDriveFinalizerSnapshotPrefix = Group + ".snapshot/"
+
+	// DirectCSISnapshotFinalizerPurgeProtection denotes snapshot purge protection finalizer.
+	DirectCSISnapshotFinalizerPurgeProtection = Group + "/snapshot-protection"
 )
 
 // +genclient
@@ -163,6 +169,26 @@ type DirectCSIDriveStatus struct {
 	// +optional
 	// +k8s:conversion-gen=false
 	Master string `json:"master,omitempty"`
+	// BlockVolume is the name of the raw block volume this drive is dedicated to.
+	// +optional
+	// +k8s:conversion-gen=false
+	BlockVolume string `json:"blockVolume,omitempty"`
+	// Rotational denotes whether the drive is a rotational device such as HDD.
+	// +optional
+	// +k8s:conversion-gen=false
+	Rotational bool `json:"rotational,omitempty"`
+	// Transport is the transport of the drive such as nvme, sata, sas, usb or virtio.
+	// +optional
+	// +k8s:conversion-gen=false
+	Transport string `json:"transport,omitempty"`
+	// Scheduler is the active I/O scheduler of the drive.
+	// +optional
+	// +k8s:conversion-gen=false
+	Scheduler string `json:"scheduler,omitempty"`
+	// NrRequests is the maximum number of queued requests of the drive.
+	// +optional
+	// +k8s:conversion-gen=false
+	NrRequests int64 `json:"nrRequests,omitempty"`
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	// +listType=map
@@ -185,6 +211,15 @@ const (
 
 	// DirectCSIDriveConditionInitialized denotes "Initialized" drive condition.
 	DirectCSIDriveConditionInitialized DirectCSIDriveCondition = "Initialized"
+
+	// DirectCSIDriveConditionDrained denotes "Drained" drive condition.
+	DirectCSIDriveConditionDrained DirectCSIDriveCondition = "Drained"
+
+	// DirectCSIDriveConditionHealthy denotes "Healthy" drive condition.
+	DirectCSIDriveConditionHealthy DirectCSIDriveCondition = "Healthy"
+
+	// DirectCSIDriveConditionHighWatermark denotes "HighWatermark" drive condition.
+	DirectCSIDriveConditionHighWatermark DirectCSIDriveCondition = "HighWatermark"
 )
 
 // DirectCSIDriveReason denotes drive reason.
@@ -199,6 +234,38 @@ const (
 
 	// DirectCSIDriveReasonInitialized denotes "Initialized" drive reason.
 	DirectCSIDriveReasonInitialized DirectCSIDriveReason = "Initialized"
+
+	// DirectCSIDriveReasonDraining denotes "Draining" drive reason.
+	DirectCSIDriveReasonDraining DirectCSIDriveReason = "Draining"
+
+	// DirectCSIDriveReasonDrained denotes "Drained" drive reason.
+	DirectCSIDriveReasonDrained DirectCSIDriveReason = "Drained"
+
+	// DirectCSIDriveReasonHealthy denotes "Healthy" drive reason.
+	DirectCSIDriveReasonHealthy DirectCSIDriveReason = "Healthy"
+
+	// DirectCSIDriveReasonUnhealthy denotes "Unhealthy" drive reason.
+	DirectCSIDriveReasonUnhealthy DirectCSIDriveReason = "Unhealthy"
+
+	// DirectCSIDriveReasonHighWatermarkReached denotes "HighWatermarkReached" drive reason.
+	DirectCSIDriveReasonHighWatermarkReached DirectCSIDriveReason = "HighWatermarkReached"
+
+	// DirectCSIDriveReasonBelowHighWatermark denotes "BelowHighWatermark" drive reason.
+	DirectCSIDriveReasonBelowHighWatermark DirectCSIDriveReason = "BelowHighWatermark"
+)
+
+// DriveTaintCordoned is the drive taint key which stops new volumes being placed on the drive.
+const DriveTaintCordoned = Group + "/cordoned"
+
+// DriveTaintEffect denotes the effect of a drive taint on volumes not tolerating it.
+type DriveTaintEffect string
+
+const (
+	// DriveTaintEffectNoSchedule denotes no new volume is placed on the drive unless the taint is tolerated.
+	DriveTaintEffectNoSchedule DriveTaintEffect = "NoSchedule"
+
+	// DriveTaintEffectPreferNoSchedule denotes the drive is avoided for new volumes unless the taint is tolerated.
+	DriveTaintEffectPreferNoSchedule DriveTaintEffect = "PreferNoSchedule"
 )
 
 // DirectCSIDriveMessage denotes drive message.
@@ -336,6 +403,14 @@ type DirectCSIVolumeStatus struct {
 	StagingPath string `json:"stagingPath,omitempty"`
 	// +optional
 	ContainerPath string `json:"containerPath,omitempty"`
+	// +listType=atomic
+	// +optional
+	// +k8s:conversion-gen=false
+	TargetPaths []string `json:"targetPaths,omitempty"`
+	// ThrottledPods maps publish target paths to the UIDs of the pods having I/O limits set.
+	// +optional
+	// +k8s:conversion-gen=false
+	ThrottledPods map[string]string `json:"throttledPods,omitempty"`
 	// +optional
 	TotalCapacity int64 `json:"totalCapacity"`
 	// +optional
@@ -343,9 +418,77 @@ type DirectCSIVolumeStatus struct {
 	// +optional
 	UsedCapacity int64 `json:"usedCapacity"`
 	// +optional
+	// +k8s:conversion-gen=false
+	ContentSource *VolumeContentSource `json:"contentSource,omitempty"`
+	// TargetDrive is the drive on the same node the volume is requested to be moved to.
+	// +optional
+	// +k8s:conversion-gen=false
+	TargetDrive string `json:"targetDrive,omitempty"`
+	// +optional
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
 }
+
+// VolumeContentSourceKind denotes kind of volume content source.
+type VolumeContentSourceKind string
+
+const (
+	// VolumeContentSourceKindSnapshot denotes "Snapshot" volume content source kind.
+	VolumeContentSourceKindSnapshot VolumeContentSourceKind = "Snapshot"
+
+	// VolumeContentSourceKindVolume denotes "Volume" volume content source kind.
+	VolumeContentSourceKindVolume VolumeContentSourceKind = "Volume"
+)
+
+// VolumeContentSource denotes the source the volume is populated from.
+type VolumeContentSource struct {
+	// required
+	Kind VolumeContentSourceKind `json:"kind"`
+	// required
+	Name string `json:"name"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DirectCSISnapshotList denotes list of snapshots.
+type DirectCSISnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	// metdata is the standard list metadata.
+	// +optional
+	metav1.ListMeta `json:"metadata"`
+	Items           []DirectCSISnapshot `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DirectCSISnapshot denotes snapshot CRD object.
+type DirectCSISnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Status DirectCSISnapshotStatus `json:"status,omitempty"`
+}
+
+// DirectCSISnapshotStatus denotes snapshot information.
+type DirectCSISnapshotStatus struct {
+	// required
+	SourceVolume string `json:"sourceVolume"`
+	// +optional
+	Drive string `json:"drive,omitempty"`
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+	// +optional
+	HostPath string `json:"hostPath,omitempty"`
+	// +optional
+	Size int64 `json:"size"`
+	// +optional
+	ReadyToUse bool `json:"readyToUse"`
+}
@@ -96,6 +96,10 @@ func NewDirectCSIDriveStatus(device *sys.Device, nodeID string, topology map[str
 		Partitioned:       device.Partitioned,
 		SwapOn:            device.SwapOn,
 		Master:            device.Master,
+		Rotational:        device.Rotational,
+		Transport:         device.Transport,
+		Scheduler:         device.Scheduler,
+		NrRequests:        int64(device.NrRequests),
 		Conditions: []metav1.Condition{
 			{
 				Type:               string(directcsi.DirectCSIDriveConditionOwned),
@@ -128,6 +132,21 @@ func NewDirectCSIDriveStatus(device *sys.Device, nodeID string, topology map[str
 	}
 }
 
+// GetMediaAccessTier returns the access tier by drive media i.e. Hot for NVMe,
+// Warm for SSD and Cold for HDD; virtual drives have unknown access tier.
+func GetMediaAccessTier(status directcsi.DirectCSIDriveStatus) directcsi.AccessTier {
+	switch {
+	case status.Virtual:
+		return directcsi.AccessTierUnknown
+	case status.Transport == sys.TransportNVMe:
+		return directcsi.AccessTierHot
+	case status.Rotational:
+		return directcsi.AccessTierCold
+	default:
+		return directcsi.AccessTierWarm
+	}
+}
+
 // NewDirectCSIDrive creates new direct-csi drive.
 func NewDirectCSIDrive(name string, status directcsi.DirectCSIDriveStatus) *directcsi.DirectCSIDrive {
 	drive := &directcsi.DirectCSIDrive{
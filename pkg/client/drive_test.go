@@ -0,0 +1,43 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"testing"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/sys"
+)
+
+func TestGetMediaAccessTier(t *testing.T) {
+	testCases := []struct {
+		status         directcsi.DirectCSIDriveStatus
+		expectedResult directcsi.AccessTier
+	}{
+		{directcsi.DirectCSIDriveStatus{Transport: sys.TransportNVMe}, directcsi.AccessTierHot},
+		{directcsi.DirectCSIDriveStatus{Transport: sys.TransportSATA}, directcsi.AccessTierWarm},
+		{directcsi.DirectCSIDriveStatus{Transport: sys.TransportSAS, Rotational: true}, directcsi.AccessTierCold},
+		{directcsi.DirectCSIDriveStatus{Transport: sys.TransportUSB, Rotational: true}, directcsi.AccessTierCold},
+		{directcsi.DirectCSIDriveStatus{Virtual: true, Rotational: true}, directcsi.AccessTierUnknown},
+	}
+
+	for i, testCase := range testCases {
+		if result := GetMediaAccessTier(testCase.status); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
@@ -62,6 +62,7 @@ func FakeInit() {
 
 	latestDirectCSIDriveInterface = directClientset.DirectV1beta3().DirectCSIDrives()
 	latestDirectCSIVolumeInterface = directClientset.DirectV1beta3().DirectCSIVolumes()
+	latestDirectCSISnapshotInterface = directClientset.DirectV1beta3().DirectCSISnapshots()
 
 	initEvent(kubeClient)
 }
@@ -74,6 +75,10 @@ func SetLatestDirectCSIVolumeInterface(volumeInterface directcsiclientset.Direct
 	latestDirectCSIVolumeInterface = volumeInterface
 }
 
+func SetLatestDirectCSISnapshotInterface(snapshotInterface directcsiclientset.DirectCSISnapshotInterface) {
+	latestDirectCSISnapshotInterface = snapshotInterface
+}
+
 func SetFakeDiscoveryClient(groupsAndMethodsFn fakeServerGroupsAndResourcesMethod, serverVersionInfo *version.Info) {
 	discoveryClient = &FakeDiscovery{
 		FakeDiscovery:                      discoveryfake.FakeDiscovery{Fake: &kubeClient.(*kubernetesfake.Clientset).Fake},
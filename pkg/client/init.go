@@ -47,6 +47,8 @@ var (
 	metadataClient                 metadata.Interface
 	latestDirectCSIDriveInterface  directcsi.DirectCSIDriveInterface
 	latestDirectCSIVolumeInterface directcsi.DirectCSIVolumeInterface
+
+	latestDirectCSISnapshotInterface directcsi.DirectCSISnapshotInterface
 )
 
 // GetLatestDirectCSIDriveInterface gets latest versioned direct-csi drive interface.
@@ -59,6 +61,11 @@ func GetLatestDirectCSIVolumeInterface() directcsi.DirectCSIVolumeInterface {
 	return latestDirectCSIVolumeInterface
 }
 
+// GetLatestDirectCSISnapshotInterface gets latest versioned direct-csi snapshot interface.
+func GetLatestDirectCSISnapshotInterface() directcsi.DirectCSISnapshotInterface {
+	return latestDirectCSISnapshotInterface
+}
+
 // GetKubeClient gets kube client.
 func GetKubeClient() kubernetes.Interface {
 	return kubeClient
@@ -123,6 +130,7 @@ func Init() {
 	if err != nil {
 		klog.Fatalf("could not initialize direct-csi client: %v", err)
 	}
+	latestDirectCSISnapshotInterface = directCSIClient.DirectCSISnapshots()
 
 	crdClientset, err := apiextensions.NewForConfig(config)
 	if err != nil {
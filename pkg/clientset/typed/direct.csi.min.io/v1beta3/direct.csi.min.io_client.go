@@ -27,6 +27,7 @@ import (
 type DirectV1beta3Interface interface {
 	RESTClient() rest.Interface
 	DirectCSIDrivesGetter
+	DirectCSISnapshotsGetter
 	DirectCSIVolumesGetter
 }
 
@@ -39,6 +40,10 @@ func (c *DirectV1beta3Client) DirectCSIDrives() DirectCSIDriveInterface {
 	return newDirectCSIDrives(c)
 }
 
+func (c *DirectV1beta3Client) DirectCSISnapshots() DirectCSISnapshotInterface {
+	return newDirectCSISnapshots(c)
+}
+
 func (c *DirectV1beta3Client) DirectCSIVolumes() DirectCSIVolumeInterface {
 	return newDirectCSIVolumes(c)
 }
@@ -32,6 +32,10 @@ func (c *FakeDirectV1beta3) DirectCSIDrives() v1beta3.DirectCSIDriveInterface {
 	return &FakeDirectCSIDrives{c}
 }
 
+func (c *FakeDirectV1beta3) DirectCSISnapshots() v1beta3.DirectCSISnapshotInterface {
+	return &FakeDirectCSISnapshots{c}
+}
+
 func (c *FakeDirectV1beta3) DirectCSIVolumes() v1beta3.DirectCSIVolumeInterface {
 	return &FakeDirectCSIVolumes{c}
 }
@@ -20,4 +20,6 @@ package v1beta3
 
 type DirectCSIDriveExpansion interface{}
 
+type DirectCSISnapshotExpansion interface{}
+
 type DirectCSIVolumeExpansion interface{}
@@ -0,0 +1,161 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"sync"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/utils"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	volumeAntiAffinityParameter = "direct-csi-min-io/volume-anti-affinity"
+
+	// podVolumeAntiAffinity denotes volumes of the same StatefulSet pod must not share a drive.
+	podVolumeAntiAffinity = "pod"
+)
+
+// podLocks serializes volume creation requesting anti-affinity in the same namespace
+// so that concurrent requests see the drives of each other's volumes. Namespaces are
+// hashed into a fixed set of locks. The locks are local to this process which is
+// sufficient as csi-provisioner calls CreateVolume only on the leader controller.
+var podLocks [64]sync.Mutex
+
+// podOrdinalRegexp matches the ordinal of StatefulSet pod.
+var podOrdinalRegexp = regexp.MustCompile(`^[0-9]+$`)
+
+func validateVolumeAntiAffinity(value string) error {
+	if value != podVolumeAntiAffinity {
+		return fmt.Errorf("unknown volume anti-affinity %v; the possible value is %v", value, podVolumeAntiAffinity)
+	}
+	return nil
+}
+
+// listStatefulSets returns the StatefulSets in the namespace.
+func listStatefulSets(ctx context.Context, namespace string) ([]appsv1.StatefulSet, error) {
+	if namespace == "" {
+		return nil, nil
+	}
+
+	statefulSetList, err := client.GetKubeClient().AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return statefulSetList.Items, nil
+}
+
+// getStatefulSetPod returns the StatefulSet and its pod of the PVC among given StatefulSets.
+// StatefulSet names its PVCs as <claim-template>-<statefulset>-<ordinal> and its pods as
+// <statefulset>-<ordinal>; they are matched by the names of the StatefulSets and their
+// claim templates as both may contain '-'.
+func getStatefulSetPod(statefulSets []appsv1.StatefulSet, pvcName string) (statefulSet, pod string) {
+	for _, item := range statefulSets {
+		for _, claimTemplate := range item.Spec.VolumeClaimTemplates {
+			prefix := claimTemplate.Name + "-" + item.Name + "-"
+			if strings.HasPrefix(pvcName, prefix) && podOrdinalRegexp.MatchString(strings.TrimPrefix(pvcName, prefix)) {
+				return item.Name, strings.TrimPrefix(pvcName, claimTemplate.Name+"-")
+			}
+		}
+	}
+	return "", ""
+}
+
+// getAntiAffinityDrives returns the drives having volumes of the same StatefulSet pod
+// of the PVC by existing volumes' drive labels.
+func getAntiAffinityDrives(volumes []directcsi.DirectCSIVolume, statefulSets []appsv1.StatefulSet, volumeName, pvcName, pvcNamespace string) map[string]struct{} {
+	drives := map[string]struct{}{}
+
+	_, pod := getStatefulSetPod(statefulSets, string(utils.NewLabelValue(pvcName)))
+	if pod == "" {
+		return drives
+	}
+
+	for _, volume := range volumes {
+		if volume.Name == volumeName {
+			continue
+		}
+
+		labels := volume.GetLabels()
+		if labels[string(utils.PVCNamespaceLabelKey)] != string(utils.NewLabelValue(pvcNamespace)) {
+			continue
+		}
+		if _, volumePod := getStatefulSetPod(statefulSets, labels[string(utils.PVCNameLabelKey)]); volumePod != pod {
+			continue
+		}
+
+		if drive, found := labels[string(utils.DriveLabelKey)]; found {
+			drives[drive] = struct{}{}
+		}
+	}
+
+	return drives
+}
+
+// filterAntiAffinityDrives removes the drives violating volume anti-affinity requested in parameters.
+func filterAntiAffinityDrives(ctx context.Context, req *csi.CreateVolumeRequest, drives []directcsi.DirectCSIDrive) ([]directcsi.DirectCSIDrive, error) {
+	if req.GetParameters()[volumeAntiAffinityParameter] != podVolumeAntiAffinity {
+		return drives, nil
+	}
+
+	statefulSets, err := listStatefulSets(ctx, req.GetParameters()[pvcNamespaceParameter])
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, err := client.GetVolumeList(ctx, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	excludedDrives := getAntiAffinityDrives(
+		volumes, statefulSets, req.GetName(), req.GetParameters()[pvcNameParameter], req.GetParameters()[pvcNamespaceParameter],
+	)
+
+	var result []directcsi.DirectCSIDrive
+	for _, drive := range drives {
+		if _, found := excludedDrives[string(utils.NewLabelValue(drive.Name))]; !found {
+			result = append(result, drive)
+		}
+	}
+
+	return result, nil
+}
+
+// lockPodVolumes locks volume creation in the namespace of the PVC if volume anti-affinity
+// is requested and returns the unlock function.
+func lockPodVolumes(req *csi.CreateVolumeRequest) func() {
+	if req.GetParameters()[volumeAntiAffinityParameter] != podVolumeAntiAffinity {
+		return func() {}
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(req.GetParameters()[pvcNamespaceParameter]))
+	lock := &podLocks[hash.Sum32()%uint32(len(podLocks))]
+	lock.Lock()
+	return lock.Unlock
+}
@@ -0,0 +1,226 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/client"
+	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+func newTestStatefulSet(name, namespace string, claimTemplates ...string) appsv1.StatefulSet {
+	statefulSet := appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+	}
+	for _, claimTemplate := range claimTemplates {
+		statefulSet.Spec.VolumeClaimTemplates = append(
+			statefulSet.Spec.VolumeClaimTemplates,
+			corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: claimTemplate}},
+		)
+	}
+	return statefulSet
+}
+
+func createTestStatefulSets(t *testing.T, statefulSets ...appsv1.StatefulSet) {
+	client.FakeInit()
+	for _, statefulSet := range statefulSets {
+		statefulSet := statefulSet
+		if _, err := client.GetKubeClient().AppsV1().StatefulSets(statefulSet.Namespace).Create(context.TODO(), &statefulSet, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("unable to create statefulset %v; %v", statefulSet.Name, err)
+		}
+	}
+}
+
+func TestGetStatefulSetPod(t *testing.T) {
+	statefulSets := []appsv1.StatefulSet{
+		newTestStatefulSet("minio", "default", "data0", "data1"),
+		newTestStatefulSet("my-minio", "default", "export-a", "export-b"),
+	}
+
+	testCases := []struct {
+		pvcName             string
+		expectedStatefulSet string
+		expectedPod         string
+	}{
+		{"data0-minio-0", "minio", "minio-0"},
+		{"data1-minio-0", "minio", "minio-0"},
+		{"export-a-my-minio-12", "my-minio", "my-minio-12"},
+		{"export-b-my-minio-12", "my-minio", "my-minio-12"},
+		{"data2-minio-0", "", ""},
+		{"data0-minio-x", "", ""},
+		{"minio-0", "", ""},
+		{"data", "", ""},
+	}
+
+	for i, testCase := range testCases {
+		statefulSet, pod := getStatefulSetPod(statefulSets, testCase.pvcName)
+		if statefulSet != testCase.expectedStatefulSet || pod != testCase.expectedPod {
+			t.Fatalf("case %v: expected: %v/%v, got: %v/%v", i+1, testCase.expectedStatefulSet, testCase.expectedPod, statefulSet, pod)
+		}
+	}
+}
+
+func TestFilterAntiAffinityDrives(t *testing.T) {
+	newVolume := func(name, drive, pvcName, pvcNamespace string) *directcsi.DirectCSIVolume {
+		volume := &directcsi.DirectCSIVolume{
+			TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     directcsi.DirectCSIVolumeStatus{Drive: drive},
+		}
+		utils.SetLabels(volume, map[utils.LabelKey]utils.LabelValue{
+			utils.DriveLabelKey:        utils.NewLabelValue(drive),
+			utils.PVCNameLabelKey:      utils.NewLabelValue(pvcName),
+			utils.PVCNamespaceLabelKey: utils.NewLabelValue(pvcNamespace),
+		})
+		return volume
+	}
+
+	objects := []runtime.Object{
+		newVolume("volume-1", "drive-1", "data-a-minio-0", "default"),
+		newVolume("volume-2", "drive-2", "data-b-minio-0", "default"),
+		newVolume("volume-3", "drive-3", "data-a-minio-1", "default"),
+		newVolume("volume-4", "drive-3", "data-a-minio-0", "tenant"),
+	}
+
+	createTestStatefulSets(
+		t,
+		newTestStatefulSet("minio", "default", "data-a", "data-b", "data-c"),
+		newTestStatefulSet("minio", "tenant", "data-a", "data-b", "data-c"),
+	)
+
+	drives := []directcsi.DirectCSIDrive{
+		newStrategyTestDrive("drive-1", GiB),
+		newStrategyTestDrive("drive-2", GiB),
+		newStrategyTestDrive("drive-3", GiB),
+		newStrategyTestDrive("drive-4", GiB),
+	}
+
+	newRequest := func(name, pvcName, pvcNamespace, antiAffinity string) *csi.CreateVolumeRequest {
+		parameters := map[string]string{
+			pvcNameParameter:      pvcName,
+			pvcNamespaceParameter: pvcNamespace,
+		}
+		if antiAffinity != "" {
+			parameters[volumeAntiAffinityParameter] = antiAffinity
+		}
+		return &csi.CreateVolumeRequest{Name: name, Parameters: parameters}
+	}
+
+	testCases := []struct {
+		request        *csi.CreateVolumeRequest
+		expectedDrives []string
+	}{
+		{newRequest("volume-5", "data-c-minio-0", "default", ""), []string{"drive-1", "drive-2", "drive-3", "drive-4"}},
+		{newRequest("volume-5", "data-c-minio-0", "default", podVolumeAntiAffinity), []string{"drive-3", "drive-4"}},
+		{newRequest("volume-5", "data-b-minio-1", "default", podVolumeAntiAffinity), []string{"drive-1", "drive-2", "drive-4"}},
+		{newRequest("volume-5", "data-b-minio-0", "tenant", podVolumeAntiAffinity), []string{"drive-1", "drive-2", "drive-4"}},
+		{newRequest("volume-2", "data-b-minio-0", "default", podVolumeAntiAffinity), []string{"drive-2", "drive-3", "drive-4"}},
+		{newRequest("volume-5", "data", "default", podVolumeAntiAffinity), []string{"drive-1", "drive-2", "drive-3", "drive-4"}},
+		{newRequest("volume-5", "data-c-other-0", "default", podVolumeAntiAffinity), []string{"drive-1", "drive-2", "drive-3", "drive-4"}},
+	}
+
+	client.SetLatestDirectCSIVolumeInterface(clientsetfake.NewSimpleClientset(objects...).DirectV1beta3().DirectCSIVolumes())
+	for i, testCase := range testCases {
+		result, err := filterAntiAffinityDrives(context.TODO(), testCase.request, drives)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if names := getDriveNames(result); !reflect.DeepEqual(names, testCase.expectedDrives) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedDrives, names)
+		}
+	}
+}
+
+func TestCreateVolumeAntiAffinityParallel(t *testing.T) {
+	newDrive := func(name string) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Finalizers: []string{directcsi.DirectCSIDriveFinalizerDataProtection},
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:      "node-1",
+				Filesystem:    "xfs",
+				DriveStatus:   directcsi.DriveStatusReady,
+				FreeCapacity:  mb100,
+				TotalCapacity: mb100,
+			},
+		}
+	}
+
+	createTestStatefulSets(t, newTestStatefulSet("minio", "default", "data-0", "data-1"))
+
+	ctx := context.TODO()
+	for i := 0; i < 10; i++ {
+		controller := createFakeController()
+		clientset := clientsetfake.NewSimpleClientset(newDrive("drive-1"), newDrive("drive-2"))
+		controller.directcsiClient = clientset
+		client.SetLatestDirectCSIDriveInterface(clientset.DirectV1beta3().DirectCSIDrives())
+		client.SetLatestDirectCSIVolumeInterface(clientset.DirectV1beta3().DirectCSIVolumes())
+
+		errs := make([]error, 2)
+		var wg sync.WaitGroup
+		for j := range errs {
+			wg.Add(1)
+			go func(j int) {
+				defer wg.Done()
+				_, errs[j] = controller.CreateVolume(ctx, &csi.CreateVolumeRequest{
+					Name:          fmt.Sprintf("volume-%v", j),
+					CapacityRange: &csi.CapacityRange{RequiredBytes: mb20},
+					Parameters: map[string]string{
+						volumeAntiAffinityParameter: podVolumeAntiAffinity,
+						pvcNameParameter:            fmt.Sprintf("data-%v-minio-0", j),
+						pvcNamespaceParameter:       "default",
+					},
+					VolumeCapabilities: []*csi.VolumeCapability{
+						{
+							AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+						},
+					},
+				})
+			}(j)
+		}
+		wg.Wait()
+
+		for j, err := range errs {
+			if err != nil {
+				t.Fatalf("case %v: request %v: unexpected error %v", i+1, j+1, err)
+			}
+		}
+
+		volumes, err := clientset.DirectV1beta3().DirectCSIVolumes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if len(volumes.Items) != 2 || volumes.Items[0].Status.Drive == volumes.Items[1].Status.Drive {
+			t.Fatalf("case %v: volumes of the same pod must be on different drives; %v", i+1, volumes.Items)
+		}
+	}
+}
@@ -19,12 +19,16 @@ package controller
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 
 	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
 	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/clientset"
+	"github.com/minio/directpv/pkg/iothrottle"
 	"github.com/minio/directpv/pkg/matcher"
 	"github.com/minio/directpv/pkg/utils"
+	"github.com/minio/directpv/pkg/volume"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -105,6 +109,51 @@ func (c *ControllerServer) ControllerGetCapabilities(ctx context.Context, req *c
 					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME},
 				},
 			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_EXPAND_VOLUME},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_GET_CAPACITY},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_LIST_SNAPSHOTS},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_CLONE_VOLUME},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_LIST_VOLUMES},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_GET_VOLUME},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_VOLUME_CONDITION},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_SINGLE_NODE_MULTI_WRITER},
+				},
+			},
 		},
 	}, nil
 }
@@ -112,7 +161,7 @@ func (c *ControllerServer) ControllerGetCapabilities(ctx context.Context, req *c
 func (c *ControllerServer) ValidateVolumeCapabilities(ctx context.Context, req *csi.ValidateVolumeCapabilitiesRequest) (*csi.ValidateVolumeCapabilitiesResponse, error) {
 	var message string
 	for _, vcap := range req.GetVolumeCapabilities() {
-		if vcap.GetAccessMode() != nil && vcap.GetAccessMode().GetMode() != csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER {
+		if vcap.GetAccessMode() != nil && !utils.IsSupportedAccessMode(vcap.GetAccessMode().GetMode()) {
 			message = fmt.Sprintf("unsupported access mode %s", vcap.GetAccessMode().GetMode())
 			break
 		}
@@ -139,36 +188,111 @@ func (c *ControllerServer) CreateVolume(ctx context.Context, req *csi.CreateVolu
 	}
 
 	for _, vcap := range req.GetVolumeCapabilities() {
-		if vcap.GetAccessMode() != nil && vcap.GetAccessMode().GetMode() != csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER {
+		if vcap.GetAccessMode() != nil && !utils.IsSupportedAccessMode(vcap.GetAccessMode().GetMode()) {
 			return nil, status.Errorf(codes.InvalidArgument, "unsupported access mode: %s", vcap.GetAccessMode().GetMode())
 		}
 	}
 
-	if len(req.GetVolumeCapabilities()) > 0 && req.GetVolumeCapabilities()[0].GetMount().GetFsType() != "xfs" {
-		return nil, status.Errorf(codes.InvalidArgument, "unsupported filesystem type %v", req.GetVolumeCapabilities()[0].GetMount().GetFsType())
+	block := isBlockVolumeRequest(req)
+	if block {
+		for _, vcap := range req.GetVolumeCapabilities() {
+			if vcap.GetMount() != nil {
+				return nil, status.Error(codes.InvalidArgument, "block and mount access types must not be requested together")
+			}
+		}
+		if req.GetVolumeContentSource() != nil {
+			return nil, status.Error(codes.InvalidArgument, "volume content source is not supported for raw block volume")
+		}
+	} else if len(req.GetVolumeCapabilities()) > 0 {
+		switch fsType := req.GetVolumeCapabilities()[0].GetMount().GetFsType(); fsType {
+		case xfsFileSystem, ext4FileSystem:
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported filesystem type %v", fsType)
+		}
 	}
 
 	for key, value := range req.GetParameters() {
-		if key == "direct-csi-min-io/access-tier" {
+		switch key {
+		case accessTierParameter:
 			if _, err := directcsi.ToAccessTier(value); err != nil {
 				return nil, status.Errorf(codes.InvalidArgument, "unknown access-tier %v; %v", value, err)
 			}
+		case driveSelectionParameter:
+			if _, err := toDriveSelectionStrategy(value); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		case volumeAntiAffinityParameter:
+			if err := validateVolumeAntiAffinity(value); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		case overcommitRatioParameter:
+			if _, err := parseOvercommitRatio(value); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		case tolerationsParameter:
+			if _, err := parseTolerations(value); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		case driveLabelSelectorParameter:
+			if _, err := parseDriveLabelSelector(value); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		case volume.DataReclaimPolicyParameter:
+			if _, err := volume.ToDataReclaimPolicy(value); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
 		}
 	}
 
-	drive, err := selectDrive(ctx, req)
+	volumeContext, err := getVolumeContext(ctx, req)
 	if err != nil {
 		return nil, err
 	}
+	if _, err := iothrottle.ParseLimits(volumeContext); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	contentSource, sourceDrive, err := c.getContentSource(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Volumes of the same pod are created one after another for volume anti-affinity
+	// to see the drives of each other.
+	unlock := lockPodVolumes(req)
+	defer unlock()
+
+	// Drive is reserved before creating the volume; if the selected drive no longer satisfies
+	// the request due to concurrent reservations, another drive is selected.
+	var drive *directcsi.DirectCSIDrive
+	var size int64
+	for i := 0; ; i++ {
+		selectedDrive, err := selectDrive(ctx, req, sourceDrive)
+		if err != nil {
+			return nil, err
+		}
+
+		klog.V(4).InfoS("Selected DirectCSI drive",
+			"drive-name", selectedDrive.Name,
+			"node", selectedDrive.Status.NodeName,
+			"volume", name)
+
+		if drive, size, err = c.reserveDrive(ctx, req, selectedDrive.Name, block); err == nil {
+			break
+		}
+
+		if err != errDriveMismatch {
+			return nil, status.Errorf(codes.Internal, "could not reserve drive[%s] %v", selectedDrive.Name, err)
+		}
 
-	klog.V(4).InfoS("Selected DirectCSI drive",
-		"drive-name", drive.Name,
-		"node", drive.Status.NodeName,
-		"volume", name)
+		if i == maxDriveReservationRetries {
+			return nil, status.Errorf(codes.ResourceExhausted, "could not reserve drive[%s] %v", selectedDrive.Name, err)
+		}
 
-	size := drive.Status.FreeCapacity
-	if req.GetCapacityRange() != nil {
-		size = req.GetCapacityRange().GetRequiredBytes()
+		klog.V(3).InfoS("Retrying drive selection",
+			"drive-name", selectedDrive.Name,
+			"volume", name,
+			"reason", err)
 	}
 
 	newVolume := &directcsi.DirectCSIVolume{
@@ -185,6 +309,7 @@ func (c *ControllerServer) CreateVolume(ctx context.Context, req *csi.CreateVolu
 			TotalCapacity:     size,
 			AvailableCapacity: size,
 			UsedCapacity:      0,
+			ContentSource:     contentSource,
 			Conditions: []metav1.Condition{
 				{
 					Type:               string(directcsi.DirectCSIVolumeConditionStaged),
@@ -218,11 +343,29 @@ func (c *ControllerServer) CreateVolume(ctx context.Context, req *csi.CreateVolu
 		utils.VersionLabelKey:   directcsi.Version,
 		utils.CreatedByLabelKey: utils.DirectCSIControllerName,
 	}
+	if pvcName, found := req.GetParameters()[pvcNameParameter]; found {
+		labels[utils.PVCNameLabelKey] = utils.NewLabelValue(pvcName)
+	}
+	if pvcNamespace, found := req.GetParameters()[pvcNamespaceParameter]; found {
+		labels[utils.PVCNamespaceLabelKey] = utils.NewLabelValue(pvcNamespace)
+	}
+	if value, found := req.GetParameters()[volume.DataReclaimPolicyParameter]; found {
+		policy, _ := volume.ToDataReclaimPolicy(value)
+		labels[utils.DataReclaimPolicyLabelKey] = utils.LabelValue(policy)
+	}
 	utils.UpdateLabels(newVolume, labels)
 
 	volumeInterface := c.directcsiClient.DirectV1beta3().DirectCSIVolumes()
 	if _, err := volumeInterface.Create(ctx, newVolume, metav1.CreateOptions{}); err != nil {
 		if !errors.IsAlreadyExists(err) {
+			// Release the reservation unless the volume got created in spite of the error.
+			if _, getErr := volumeInterface.Get(
+				ctx, name, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()},
+			); errors.IsNotFound(getErr) {
+				if releaseErr := c.releaseDrive(ctx, drive.Name, name, size, block); releaseErr != nil {
+					klog.ErrorS(releaseErr, "unable to release drive reservation", "drive", drive.Name, "volume", name)
+				}
+			}
 			return nil, status.Errorf(codes.Internal, "could not create volume %s; %v", name, err)
 		}
 
@@ -248,33 +391,11 @@ func (c *ControllerServer) CreateVolume(ctx context.Context, req *csi.CreateVolu
 		client.Eventf(newVolume, corev1.EventTypeNormal, "VolumeProvisioningSucceeded", "volume %v is created", newVolume.Name)
 	}
 
-	finalizer := directcsi.DirectCSIDriveFinalizerPrefix + req.GetName()
-	if !matcher.StringIn(drive.Finalizers, finalizer) {
-		drive.Status.FreeCapacity = drive.Status.FreeCapacity - size
-		drive.Status.AllocatedCapacity = drive.Status.AllocatedCapacity + size
-		drive.Status.DriveStatus = directcsi.DriveStatusInUse
-		drive.SetFinalizers(append(drive.GetFinalizers(), finalizer))
-
-		klog.V(4).InfoS("Reserving DirectCSI drive",
-			"drive-name", drive.Name,
-			"node", drive.Status.NodeName,
-			"volume", name)
-
-		_, err = c.directcsiClient.DirectV1beta3().DirectCSIDrives().Update(
-			ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
-		)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "could not reserve drive[%s] %v", drive.Name, err)
-		} else {
-			client.Eventf(drive, corev1.EventTypeNormal, "DriveReservationSucceded", "reserved drive %v on node %v and volume %v", drive.Name, drive.Status.NodeName, name)
-		}
-	}
-
 	return &csi.CreateVolumeResponse{
 		Volume: &csi.Volume{
 			VolumeId:      name,
 			CapacityBytes: size,
-			VolumeContext: req.GetParameters(),
+			VolumeContext: volumeContext,
 			ContentSource: req.GetVolumeContentSource(),
 			AccessibleTopology: []*csi.Topology{
 				{
@@ -340,8 +461,77 @@ func (c *ControllerServer) DeleteVolume(ctx context.Context, req *csi.DeleteVolu
 	return &csi.DeleteVolumeResponse{}, nil
 }
 
+// ListVolumes - Lists volumes with their conditions.
 func (c *ControllerServer) ListVolumes(ctx context.Context, req *csi.ListVolumesRequest) (*csi.ListVolumesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "unimplemented")
+	klog.V(5).InfoS("ListVolumesRequest", "max-entries", req.GetMaxEntries(), "starting-token", req.GetStartingToken())
+
+	if req.GetMaxEntries() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid max entries %v", req.GetMaxEntries())
+	}
+
+	start := 0
+	if req.GetStartingToken() != "" {
+		var err error
+		if start, err = strconv.Atoi(req.GetStartingToken()); err != nil || start < 0 {
+			return nil, status.Errorf(codes.Aborted, "invalid starting token %v", req.GetStartingToken())
+		}
+	}
+
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	driveResultCh, err := client.ListDrives(ctx, nil, nil, nil, client.MaxThreadCount)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not list drives; %v", err)
+	}
+	drives := map[string]*directcsi.DirectCSIDrive{}
+	for result := range driveResultCh {
+		if result.Err != nil {
+			return nil, status.Errorf(codes.Internal, "could not list drives; %v", result.Err)
+		}
+		drive := result.Drive
+		drives[drive.Name] = &drive
+	}
+
+	volumeResultCh, err := client.ListVolumes(ctx, nil, nil, nil, nil, client.MaxThreadCount)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not list volumes; %v", err)
+	}
+	var volumes []directcsi.DirectCSIVolume
+	for result := range volumeResultCh {
+		if result.Err != nil {
+			return nil, status.Errorf(codes.Internal, "could not list volumes; %v", result.Err)
+		}
+		volumes = append(volumes, result.Volume)
+	}
+	sort.Slice(volumes, func(i, j int) bool { return volumes[i].Name < volumes[j].Name })
+
+	if start > len(volumes) {
+		return nil, status.Errorf(codes.Aborted, "invalid starting token %v", req.GetStartingToken())
+	}
+	volumes = volumes[start:]
+
+	nextToken := ""
+	if req.GetMaxEntries() > 0 && int(req.GetMaxEntries()) < len(volumes) {
+		volumes = volumes[:req.GetMaxEntries()]
+		nextToken = strconv.Itoa(start + len(volumes))
+	}
+
+	entries := make([]*csi.ListVolumesResponse_Entry, 0, len(volumes))
+	for i := range volumes {
+		drive := drives[volumes[i].Status.Drive]
+		entries = append(entries, &csi.ListVolumesResponse_Entry{
+			Volume: newCSIVolume(&volumes[i], drive),
+			Status: &csi.ListVolumesResponse_VolumeStatus{
+				VolumeCondition: getVolumeCondition(&volumes[i], drive),
+			},
+		})
+	}
+
+	return &csi.ListVolumesResponse{
+		Entries:   entries,
+		NextToken: nextToken,
+	}, nil
 }
 
 func (c *ControllerServer) ControllerPublishVolume(ctx context.Context, req *csi.ControllerPublishVolumeRequest) (*csi.ControllerPublishVolumeResponse, error) {
@@ -352,26 +542,328 @@ func (c *ControllerServer) ControllerUnpublishVolume(ctx context.Context, req *c
 	return nil, status.Error(codes.Unimplemented, "unimplemented")
 }
 
+// ControllerExpandVolume - Expands a DirectCSI Volume by reserving additional capacity on its drive.
 func (c *ControllerServer) ControllerExpandVolume(ctx context.Context, req *csi.ControllerExpandVolumeRequest) (*csi.ControllerExpandVolumeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "unimplemented")
+	klog.V(3).InfoS("ControllerExpandVolumeRequest", "name", req.GetVolumeId(), "capacity", req.GetCapacityRange())
+	vID := req.GetVolumeId()
+	if vID == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume ID missing in request")
+	}
+
+	size := req.GetCapacityRange().GetRequiredBytes()
+	if size <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "required bytes missing in request")
+	}
+
+	if limit := req.GetCapacityRange().GetLimitBytes(); limit > 0 && size > limit {
+		return nil, status.Errorf(codes.OutOfRange, "required bytes %v exceeds limit bytes %v", size, limit)
+	}
+
+	vclient := c.directcsiClient.DirectV1beta3().DirectCSIVolumes()
+
+	volume, err := vclient.Get(ctx, vID, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "volume [%s] not found", vID)
+		}
+		return nil, status.Errorf(codes.Internal, "could not retreive volume [%s]: %v", vID, err)
+	}
+
+	if size <= volume.Status.TotalCapacity {
+		return &csi.ControllerExpandVolumeResponse{
+			CapacityBytes:         volume.Status.TotalCapacity,
+			NodeExpansionRequired: true,
+		}, nil
+	}
+
+	delta := size - volume.Status.TotalCapacity
+	if err = c.reserveExpansion(ctx, volume.Status.Drive, vID, delta); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "could not reserve capacity on drive [%s]: %v", volume.Status.Drive, err)
+	}
+
+	volume.Status.TotalCapacity = size
+	volume.Status.AvailableCapacity = size - volume.Status.UsedCapacity
+	if _, err = vclient.Update(ctx, volume, metav1.UpdateOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()}); err != nil {
+		// Release the capacity reserved above so that a retry does not account it twice.
+		if rerr := c.releaseExpansion(ctx, volume.Status.Drive, delta); rerr != nil {
+			klog.ErrorS(rerr, "unable to release reserved capacity", "drive", volume.Status.Drive, "volume", vID)
+		}
+		return nil, status.Errorf(codes.Internal, "could not update volume [%s]: %v", vID, err)
+	}
+
+	client.Eventf(volume, corev1.EventTypeNormal, "VolumeExpansionSucceeded", "volume %v expanded to %v bytes", volume.Name, size)
+
+	return &csi.ControllerExpandVolumeResponse{
+		CapacityBytes:         size,
+		NodeExpansionRequired: true,
+	}, nil
 }
 
+// ControllerGetVolume - Gets the volume with its condition.
 func (c *ControllerServer) ControllerGetVolume(ctx context.Context, req *csi.ControllerGetVolumeRequest) (*csi.ControllerGetVolumeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "unimplemented")
+	klog.V(5).InfoS("ControllerGetVolumeRequest", "volume", req.GetVolumeId())
+	vID := req.GetVolumeId()
+	if vID == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume ID missing in request")
+	}
+
+	directCSIClient := c.directcsiClient.DirectV1beta3()
+	volume, err := directCSIClient.DirectCSIVolumes().Get(ctx, vID, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "volume %v not found", vID)
+		}
+		return nil, status.Errorf(codes.Internal, "could not retrieve volume %v; %v", vID, err)
+	}
+
+	drive, err := directCSIClient.DirectCSIDrives().Get(ctx, volume.Status.Drive, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, status.Errorf(codes.Internal, "could not retrieve drive %v; %v", volume.Status.Drive, err)
+		}
+		drive = nil
+	}
+
+	return &csi.ControllerGetVolumeResponse{
+		Volume: newCSIVolume(volume, drive),
+		Status: &csi.ControllerGetVolumeResponse_VolumeStatus{
+			VolumeCondition: getVolumeCondition(volume, drive),
+		},
+	}, nil
 }
 
+// ListSnapshots - Lists snapshots filtered by snapshot ID or source volume ID.
 func (c *ControllerServer) ListSnapshots(ctx context.Context, req *csi.ListSnapshotsRequest) (*csi.ListSnapshotsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "unimplemented")
+	klog.V(5).InfoS("ListSnapshotsRequest", "snapshot", req.GetSnapshotId(), "source-volume", req.GetSourceVolumeId())
+
+	if req.GetMaxEntries() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid max entries %v", req.GetMaxEntries())
+	}
+
+	start := 0
+	if req.GetStartingToken() != "" {
+		var err error
+		if start, err = strconv.Atoi(req.GetStartingToken()); err != nil || start < 0 {
+			return nil, status.Errorf(codes.Aborted, "invalid starting token %v", req.GetStartingToken())
+		}
+	}
+
+	snapshotInterface := c.directcsiClient.DirectV1beta3().DirectCSISnapshots()
+
+	var snapshots []directcsi.DirectCSISnapshot
+	if req.GetSnapshotId() != "" {
+		snapshot, err := snapshotInterface.Get(
+			ctx, req.GetSnapshotId(), metav1.GetOptions{TypeMeta: utils.DirectCSISnapshotTypeMeta()},
+		)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return &csi.ListSnapshotsResponse{}, nil
+			}
+			return nil, status.Errorf(codes.Internal, "could not retrieve snapshot %v; %v", req.GetSnapshotId(), err)
+		}
+		snapshots = append(snapshots, *snapshot)
+	} else {
+		snapshotList, err := snapshotInterface.List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "could not list snapshots; %v", err)
+		}
+		snapshots = snapshotList.Items
+		sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Name < snapshots[j].Name })
+	}
+
+	var entries []*csi.ListSnapshotsResponse_Entry
+	for i := range snapshots {
+		if req.GetSourceVolumeId() != "" && snapshots[i].Status.SourceVolume != req.GetSourceVolumeId() {
+			continue
+		}
+		entries = append(entries, &csi.ListSnapshotsResponse_Entry{Snapshot: newCSISnapshot(&snapshots[i])})
+	}
+
+	if start > len(entries) {
+		return nil, status.Errorf(codes.Aborted, "invalid starting token %v", req.GetStartingToken())
+	}
+	entries = entries[start:]
+
+	nextToken := ""
+	if req.GetMaxEntries() > 0 && int(req.GetMaxEntries()) < len(entries) {
+		entries = entries[:req.GetMaxEntries()]
+		nextToken = strconv.Itoa(start + len(entries))
+	}
+
+	return &csi.ListSnapshotsResponse{
+		Entries:   entries,
+		NextToken: nextToken,
+	}, nil
 }
 
+// CreateSnapshot - Creates a DirectCSI snapshot of a volume on the volume's drive.
 func (c *ControllerServer) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshotRequest) (*csi.CreateSnapshotResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "unimplemented")
+	klog.V(3).InfoS("CreateSnapshotRequest", "name", req.GetName(), "source-volume", req.GetSourceVolumeId())
+	name := req.GetName()
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "snapshot name cannot be empty")
+	}
+
+	sourceVolumeID := req.GetSourceVolumeId()
+	if sourceVolumeID == "" {
+		return nil, status.Error(codes.InvalidArgument, "source volume ID missing in request")
+	}
+
+	directCSIClient := c.directcsiClient.DirectV1beta3()
+	snapshotInterface := directCSIClient.DirectCSISnapshots()
+
+	snapshot, err := snapshotInterface.Get(ctx, name, metav1.GetOptions{TypeMeta: utils.DirectCSISnapshotTypeMeta()})
+	switch {
+	case err == nil:
+		if snapshot.Status.SourceVolume != sourceVolumeID {
+			return nil, status.Errorf(codes.AlreadyExists, "snapshot %v already exists for volume %v", name, snapshot.Status.SourceVolume)
+		}
+	case errors.IsNotFound(err):
+		volume, err := directCSIClient.DirectCSIVolumes().Get(
+			ctx, sourceVolumeID, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()},
+		)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil, status.Errorf(codes.NotFound, "volume %v not found", sourceVolumeID)
+			}
+			return nil, status.Errorf(codes.Internal, "could not retrieve volume %v; %v", sourceVolumeID, err)
+		}
+
+		drive, err := directCSIClient.DirectCSIDrives().Get(
+			ctx, volume.Status.Drive, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+		)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "could not retrieve drive %v; %v", volume.Status.Drive, err)
+		}
+		if drive.Status.BlockVolume == volume.Name {
+			return nil, status.Errorf(codes.InvalidArgument, "snapshot of raw block volume %v is not supported", volume.Name)
+		}
+
+		snapshot = &directcsi.DirectCSISnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Finalizers: []string{
+					directcsi.DirectCSISnapshotFinalizerPurgeProtection,
+				},
+			},
+			Status: directcsi.DirectCSISnapshotStatus{
+				SourceVolume: volume.Name,
+				Drive:        volume.Status.Drive,
+				NodeName:     volume.Status.NodeName,
+				Size:         volume.Status.TotalCapacity,
+			},
+		}
+		utils.UpdateLabels(snapshot, map[utils.LabelKey]utils.LabelValue{
+			utils.NodeLabelKey:      utils.NewLabelValue(volume.Status.NodeName),
+			utils.DriveLabelKey:     utils.NewLabelValue(volume.Status.Drive),
+			utils.VolumeLabelKey:    utils.NewLabelValue(volume.Name),
+			utils.VersionLabelKey:   directcsi.Version,
+			utils.CreatedByLabelKey: utils.DirectCSIControllerName,
+		})
+
+		if snapshot, err = snapshotInterface.Create(ctx, snapshot, metav1.CreateOptions{}); err != nil {
+			return nil, status.Errorf(codes.Internal, "could not create snapshot %v; %v", name, err)
+		}
+		client.Eventf(snapshot, corev1.EventTypeNormal, "SnapshotProvisioningSucceeded", "snapshot %v of volume %v is created", name, sourceVolumeID)
+	default:
+		return nil, status.Errorf(codes.Internal, "could not retrieve snapshot %v; %v", name, err)
+	}
+
+	driveInterface := directCSIClient.DirectCSIDrives()
+	drive, err := driveInterface.Get(ctx, snapshot.Status.Drive, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not retrieve drive %v; %v", snapshot.Status.Drive, err)
+	}
+
+	finalizer := directcsi.DirectCSIDriveFinalizerSnapshotPrefix + name
+	if !matcher.StringIn(drive.Finalizers, finalizer) {
+		if drive.Status.FreeCapacity < snapshot.Status.Size {
+			return nil, status.Errorf(codes.ResourceExhausted, "drive %v does not have enough free capacity %v for snapshot %v", drive.Name, snapshot.Status.Size, name)
+		}
+
+		drive.Status.FreeCapacity -= snapshot.Status.Size
+		drive.Status.AllocatedCapacity += snapshot.Status.Size
+		drive.SetFinalizers(append(drive.GetFinalizers(), finalizer))
+
+		if _, err = driveInterface.Update(ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()}); err != nil {
+			return nil, status.Errorf(codes.Internal, "could not reserve drive %v for snapshot %v; %v", drive.Name, name, err)
+		}
+	}
+
+	return &csi.CreateSnapshotResponse{
+		Snapshot: newCSISnapshot(snapshot),
+	}, nil
 }
 
+// DeleteSnapshot - Deletes a DirectCSI snapshot; the snapshot data is removed by the node.
 func (c *ControllerServer) DeleteSnapshot(ctx context.Context, req *csi.DeleteSnapshotRequest) (*csi.DeleteSnapshotResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "unimplemented")
+	klog.V(3).InfoS("DeleteSnapshotRequest", "name", req.GetSnapshotId())
+	snapshotID := req.GetSnapshotId()
+	if snapshotID == "" {
+		return nil, status.Error(codes.InvalidArgument, "snapshot ID missing in request")
+	}
+
+	err := c.directcsiClient.DirectV1beta3().DirectCSISnapshots().Delete(ctx, snapshotID, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, status.Errorf(codes.Internal, "could not delete snapshot %v; %v", snapshotID, err)
+	}
+
+	return &csi.DeleteSnapshotResponse{}, nil
 }
 
+// GetCapacity - Returns sum of free capacity of drives matching requested topology and parameters.
 func (c *ControllerServer) GetCapacity(ctx context.Context, req *csi.GetCapacityRequest) (*csi.GetCapacityResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "unimplemented")
+	klog.V(5).InfoS("GetCapacityRequest", "topology", req.GetAccessibleTopology(), "parameters", req.GetParameters())
+
+	if value, found := req.GetParameters()[accessTierParameter]; found {
+		if _, err := directcsi.ToAccessTier(value); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown access-tier %v; %v", value, err)
+		}
+	}
+
+	if value, found := req.GetParameters()[overcommitRatioParameter]; found {
+		if _, err := parseOvercommitRatio(value); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if value, found := req.GetParameters()[tolerationsParameter]; found {
+		if _, err := parseTolerations(value); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if value, found := req.GetParameters()[driveLabelSelectorParameter]; found {
+		if _, err := parseDriveLabelSelector(value); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	resultCh, err := client.ListDrives(ctx, nil, nil, nil, client.MaxThreadCount)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var availableCapacity int64
+	for result := range resultCh {
+		if result.Err != nil {
+			return nil, status.Error(codes.Internal, result.Err.Error())
+		}
+
+		if matchCapacityDrive(result.Drive, req) {
+			if capacity := getAllocatableCapacity(result.Drive, getOvercommitRatio(result.Drive, req.GetParameters())); capacity > 0 {
+				availableCapacity += capacity
+			}
+		}
+	}
+
+	return &csi.GetCapacityResponse{
+		AvailableCapacity: availableCapacity,
+	}, nil
 }
@@ -18,7 +18,10 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
@@ -27,8 +30,10 @@ import (
 
 	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
 	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func init() {
@@ -337,6 +342,436 @@ func TestAbnormalDeleteVolume(t1 *testing.T) {
 	}
 }
 
+func TestCreateVolumeWithContentSource(t *testing.T) {
+	newDrive := func(name string, freeCapacity int64) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Finalizers: []string{directcsi.DirectCSIDriveFinalizerDataProtection},
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:          "node-1",
+				Filesystem:        "xfs",
+				DriveStatus:       directcsi.DriveStatusReady,
+				FreeCapacity:      freeCapacity,
+				AllocatedCapacity: mb100 - freeCapacity,
+				TotalCapacity:     mb100,
+			},
+		}
+	}
+	newSnapshot := func(name string, readyToUse bool) *directcsi.DirectCSISnapshot {
+		return &directcsi.DirectCSISnapshot{
+			TypeMeta:   utils.DirectCSISnapshotTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: directcsi.DirectCSISnapshotStatus{
+				SourceVolume: "source-volume",
+				Drive:        "drive-2",
+				NodeName:     "node-1",
+				Size:         mb20,
+				ReadyToUse:   readyToUse,
+			},
+		}
+	}
+	newRequest := func(size int64, source *csi.VolumeContentSource) *csi.CreateVolumeRequest {
+		return &csi.CreateVolumeRequest{
+			Name:          "volume-1",
+			CapacityRange: &csi.CapacityRange{RequiredBytes: size},
+			VolumeCapabilities: []*csi.VolumeCapability{
+				{
+					AccessType: &csi.VolumeCapability_Mount{
+						Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"},
+					},
+				},
+			},
+			VolumeContentSource: source,
+		}
+	}
+	volumeSource := func(volumeID string) *csi.VolumeContentSource {
+		return &csi.VolumeContentSource{
+			Type: &csi.VolumeContentSource_Volume{
+				Volume: &csi.VolumeContentSource_VolumeSource{VolumeId: volumeID},
+			},
+		}
+	}
+	snapshotSource := func(snapshotID string) *csi.VolumeContentSource {
+		return &csi.VolumeContentSource{
+			Type: &csi.VolumeContentSource_Snapshot{
+				Snapshot: &csi.VolumeContentSource_SnapshotSource{SnapshotId: snapshotID},
+			},
+		}
+	}
+
+	testObjects := []runtime.Object{
+		newDrive("drive-1", mb100),
+		newDrive("drive-2", mb100-mb20),
+		&directcsi.DirectCSIVolume{
+			TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: "source-volume"},
+			Status: directcsi.DirectCSIVolumeStatus{
+				Drive:         "drive-2",
+				NodeName:      "node-1",
+				TotalCapacity: mb20,
+			},
+		},
+		newSnapshot("snapshot-1", true),
+		newSnapshot("snapshot-2", false),
+	}
+
+	testCases := []struct {
+		request               *csi.CreateVolumeRequest
+		expectErr             bool
+		expectedDrive         string
+		expectedContentSource *directcsi.VolumeContentSource
+	}{
+		{newRequest(mb20, nil), false, "drive-1", nil},
+		{newRequest(mb20, volumeSource("source-volume")), false, "drive-2", &directcsi.VolumeContentSource{Kind: directcsi.VolumeContentSourceKindVolume, Name: "source-volume"}},
+		{newRequest(mb20, snapshotSource("snapshot-1")), false, "drive-2", &directcsi.VolumeContentSource{Kind: directcsi.VolumeContentSourceKindSnapshot, Name: "snapshot-1"}},
+		{newRequest(mb20, volumeSource("unknown-volume")), true, "", nil},
+		{newRequest(mb20, snapshotSource("snapshot-2")), true, "", nil},
+		{newRequest(mb20/2, volumeSource("source-volume")), true, "", nil},
+		{newRequest(mb100, volumeSource("source-volume")), true, "", nil},
+		{newRequest(mb20, &csi.VolumeContentSource{}), true, "", nil},
+	}
+
+	for i, testCase := range testCases {
+		ctx := context.TODO()
+		controller := createFakeController()
+		controller.directcsiClient = clientsetfake.NewSimpleClientset(testObjects...)
+		client.SetLatestDirectCSIDriveInterface(controller.directcsiClient.DirectV1beta3().DirectCSIDrives())
+
+		_, err := controller.CreateVolume(ctx, testCase.request)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+
+		volume, err := controller.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(ctx, "volume-1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if volume.Status.Drive != testCase.expectedDrive {
+			t.Fatalf("case %v: expected drive: %v, got: %v", i+1, testCase.expectedDrive, volume.Status.Drive)
+		}
+		if !reflect.DeepEqual(volume.Status.ContentSource, testCase.expectedContentSource) {
+			t.Fatalf("case %v: expected content source: %+v, got: %+v", i+1, testCase.expectedContentSource, volume.Status.ContentSource)
+		}
+	}
+}
+
+func TestCreateBlockVolume(t *testing.T) {
+	newDrive := func(name string, finalizers ...string) *directcsi.DirectCSIDrive {
+		freeCapacity := int64(mb100 - mb20)
+		driveStatus := directcsi.DriveStatusReady
+		if len(finalizers) != 0 {
+			freeCapacity -= mb20
+			driveStatus = directcsi.DriveStatusInUse
+		}
+		return &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Finalizers: append([]string{directcsi.DirectCSIDriveFinalizerDataProtection}, finalizers...),
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:          "node-1",
+				Filesystem:        "xfs",
+				DriveStatus:       driveStatus,
+				FreeCapacity:      freeCapacity,
+				AllocatedCapacity: mb100 - freeCapacity,
+				TotalCapacity:     mb100,
+			},
+		}
+	}
+	newRequest := func(name string, capability *csi.VolumeCapability) *csi.CreateVolumeRequest {
+		return &csi.CreateVolumeRequest{
+			Name:               name,
+			CapacityRange:      &csi.CapacityRange{RequiredBytes: mb20},
+			VolumeCapabilities: []*csi.VolumeCapability{capability},
+		}
+	}
+	blockCapability := &csi.VolumeCapability{
+		AccessType: &csi.VolumeCapability_Block{Block: &csi.VolumeCapability_BlockVolume{}},
+	}
+	mountCapability := &csi.VolumeCapability{
+		AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+	}
+
+	ctx := context.TODO()
+	controller := createFakeController()
+	controller.directcsiClient = clientsetfake.NewSimpleClientset(
+		newDrive("drive-1", directcsi.DirectCSIDriveFinalizerPrefix+"volume-0"),
+		newDrive("drive-2", directcsi.DirectCSIDriveFinalizerSnapshotPrefix+"snapshot-0"),
+		newDrive("drive-3"),
+	)
+	client.SetLatestDirectCSIDriveInterface(controller.directcsiClient.DirectV1beta3().DirectCSIDrives())
+
+	// Block volume must be created on the drive without volumes and snapshots.
+	result, err := controller.CreateVolume(ctx, newRequest("volume-1", blockCapability))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if result.Volume.CapacityBytes != mb100 {
+		t.Fatalf("expected capacity: %v, got: %v", mb100, result.Volume.CapacityBytes)
+	}
+	drive, err := controller.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(ctx, "drive-3", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if drive.Status.BlockVolume != "volume-1" {
+		t.Fatalf("expected block volume: volume-1, got: %v", drive.Status.BlockVolume)
+	}
+	if drive.Status.FreeCapacity != 0 || drive.Status.AllocatedCapacity != mb100 {
+		t.Fatalf("unexpected drive capacity; free: %v, allocated: %v", drive.Status.FreeCapacity, drive.Status.AllocatedCapacity)
+	}
+
+	// Repeated request must return the same drive.
+	if _, err = controller.CreateVolume(ctx, newRequest("volume-1", blockCapability)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	volume, err := controller.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(ctx, "volume-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if volume.Status.Drive != "drive-3" || volume.Status.TotalCapacity != mb100 {
+		t.Fatalf("unexpected volume status %+v", volume.Status)
+	}
+
+	// No more empty drive is left for block volume.
+	if _, err = controller.CreateVolume(ctx, newRequest("volume-2", blockCapability)); err == nil {
+		t.Fatalf("expected error, but succeeded")
+	}
+
+	// Filesystem volume must not be created on the drive dedicated to block volume.
+	if _, err = controller.CreateVolume(ctx, newRequest("volume-3", mountCapability)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	volume, err = controller.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(ctx, "volume-3", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if volume.Status.Drive == "drive-3" {
+		t.Fatalf("filesystem volume created on drive dedicated to block volume")
+	}
+
+	// Mixed access types are not allowed.
+	request := newRequest("volume-4", blockCapability)
+	request.VolumeCapabilities = append(request.VolumeCapabilities, mountCapability)
+	if _, err = controller.CreateVolume(ctx, request); err == nil {
+		t.Fatalf("expected error, but succeeded")
+	}
+}
+
+func TestCreateVolumeParallel(t *testing.T) {
+	newDrive := func(name string) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				ResourceVersion: "1",
+				Finalizers:      []string{directcsi.DirectCSIDriveFinalizerDataProtection},
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:      "node-1",
+				Filesystem:    "xfs",
+				DriveStatus:   directcsi.DriveStatusReady,
+				FreeCapacity:  mb100,
+				TotalCapacity: mb100,
+			},
+		}
+	}
+
+	ctx := context.TODO()
+	controller := createFakeController()
+	clientset := clientsetfake.NewSimpleClientset(newDrive("drive-1"), newDrive("drive-2"))
+	controller.directcsiClient = clientset
+	client.SetLatestDirectCSIDriveInterface(clientset.DirectV1beta3().DirectCSIDrives())
+	client.SetLatestDirectCSIVolumeInterface(clientset.DirectV1beta3().DirectCSIVolumes())
+
+	// Fake tracker does not honor resource version; reject stale updates like API server does.
+	var mutex sync.Mutex
+	resourceVersion := 1
+	driveResource := directcsi.SchemeGroupVersion.WithResource("directcsidrives")
+	clientset.PrependReactor("update", "directcsidrives", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		drive := action.(k8stesting.UpdateAction).GetObject().(*directcsi.DirectCSIDrive).DeepCopy()
+		obj, err := clientset.Tracker().Get(driveResource, "", drive.Name)
+		if err != nil {
+			return true, nil, err
+		}
+		if obj.(*directcsi.DirectCSIDrive).ResourceVersion != drive.ResourceVersion {
+			return true, nil, errors.NewConflict(driveResource.GroupResource(), drive.Name, fmt.Errorf("stale resource version"))
+		}
+
+		resourceVersion++
+		drive.ResourceVersion = strconv.Itoa(resourceVersion)
+		return true, drive, clientset.Tracker().Update(driveResource, drive, "")
+	})
+
+	// Two drives of 100MiB can hold only ten volumes of 20MiB.
+	requests := 16
+	errs := make([]error, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = controller.CreateVolume(ctx, &csi.CreateVolumeRequest{
+				Name:          fmt.Sprintf("volume-%v", i),
+				CapacityRange: &csi.CapacityRange{RequiredBytes: mb20},
+				VolumeCapabilities: []*csi.VolumeCapability{
+					{
+						AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+					},
+				},
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		}
+	}
+
+	volumes, err := clientset.DirectV1beta3().DirectCSIVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(volumes.Items) != succeeded {
+		t.Fatalf("expected volumes: %v, got: %v", succeeded, len(volumes.Items))
+	}
+	if succeeded == 0 || succeeded > 10 {
+		t.Fatalf("expected succeeded requests: 1 to 10, got: %v; errors: %v", succeeded, errs)
+	}
+
+	allocated := map[string]int64{}
+	for _, volume := range volumes.Items {
+		allocated[volume.Status.Drive] += volume.Status.TotalCapacity
+	}
+
+	drives, err := clientset.DirectV1beta3().DirectCSIDrives().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	for _, drive := range drives.Items {
+		if drive.Status.FreeCapacity < 0 {
+			t.Fatalf("drive %v: negative free capacity %v", drive.Name, drive.Status.FreeCapacity)
+		}
+		if drive.Status.AllocatedCapacity != allocated[drive.Name] {
+			t.Fatalf("drive %v: expected allocated capacity: %v, got: %v", drive.Name, allocated[drive.Name], drive.Status.AllocatedCapacity)
+		}
+		if drive.Status.FreeCapacity+drive.Status.AllocatedCapacity != drive.Status.TotalCapacity {
+			t.Fatalf("drive %v: free capacity %v and allocated capacity %v do not add up to total capacity %v", drive.Name, drive.Status.FreeCapacity, drive.Status.AllocatedCapacity, drive.Status.TotalCapacity)
+		}
+		if len(drive.Finalizers) != int(drive.Status.AllocatedCapacity/mb20)+1 {
+			t.Fatalf("drive %v: unexpected finalizers %v", drive.Name, drive.Finalizers)
+		}
+	}
+}
+
+func TestCreateVolumeReservation(t *testing.T) {
+	newDrive := func(name string, finalizers []string, freeCapacity int64) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Finalizers: append([]string{directcsi.DirectCSIDriveFinalizerDataProtection}, finalizers...),
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:          "node-1",
+				Filesystem:        "xfs",
+				DriveStatus:       directcsi.DriveStatusReady,
+				FreeCapacity:      freeCapacity,
+				AllocatedCapacity: mb100 - freeCapacity,
+				TotalCapacity:     mb100,
+			},
+		}
+	}
+
+	newRequest := func(name string) *csi.CreateVolumeRequest {
+		return &csi.CreateVolumeRequest{
+			Name:          name,
+			CapacityRange: &csi.CapacityRange{RequiredBytes: mb20},
+			VolumeCapabilities: []*csi.VolumeCapability{
+				{
+					AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+				},
+			},
+		}
+	}
+
+	getDrive := func(clientset *clientsetfake.Clientset, name string) *directcsi.DirectCSIDrive {
+		drive, err := clientset.DirectV1beta3().DirectCSIDrives().Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		return drive
+	}
+
+	ctx := context.TODO()
+
+	// Reservation is released if the volume could not be created.
+	controller := createFakeController()
+	clientset := clientsetfake.NewSimpleClientset(newDrive("drive-1", nil, mb100))
+	controller.directcsiClient = clientset
+	client.SetLatestDirectCSIDriveInterface(clientset.DirectV1beta3().DirectCSIDrives())
+	client.SetLatestDirectCSIVolumeInterface(clientset.DirectV1beta3().DirectCSIVolumes())
+	clientset.PrependReactor("create", "directcsivolumes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("create failed")
+	})
+
+	if _, err := controller.CreateVolume(ctx, newRequest("volume-1")); err == nil {
+		t.Fatalf("expected error, but succeeded")
+	}
+	drive := getDrive(clientset, "drive-1")
+	if drive.Status.FreeCapacity != mb100 || drive.Status.AllocatedCapacity != 0 {
+		t.Fatalf("reservation not released; free capacity: %v, allocated capacity: %v", drive.Status.FreeCapacity, drive.Status.AllocatedCapacity)
+	}
+	if !reflect.DeepEqual(drive.Finalizers, []string{directcsi.DirectCSIDriveFinalizerDataProtection}) {
+		t.Fatalf("unexpected finalizers %v", drive.Finalizers)
+	}
+	if drive.Status.DriveStatus != directcsi.DriveStatusReady {
+		t.Fatalf("expected drive status: %v, got: %v", directcsi.DriveStatusReady, drive.Status.DriveStatus)
+	}
+
+	// Drive already reserved for the volume by a previous request is reused.
+	controller = createFakeController()
+	clientset = clientsetfake.NewSimpleClientset(
+		newDrive("drive-1", []string{directcsi.DirectCSIDriveFinalizerPrefix + "volume-1"}, mb100-mb20),
+		newDrive("drive-2", nil, mb100),
+	)
+	controller.directcsiClient = clientset
+	client.SetLatestDirectCSIDriveInterface(clientset.DirectV1beta3().DirectCSIDrives())
+	client.SetLatestDirectCSIVolumeInterface(clientset.DirectV1beta3().DirectCSIVolumes())
+
+	if _, err := controller.CreateVolume(ctx, newRequest("volume-1")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	volume, err := clientset.DirectV1beta3().DirectCSIVolumes().Get(ctx, "volume-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if volume.Status.Drive != "drive-1" {
+		t.Fatalf("expected drive: drive-1, got: %v", volume.Status.Drive)
+	}
+	if drive := getDrive(clientset, "drive-1"); drive.Status.FreeCapacity != mb100-mb20 {
+		t.Fatalf("drive reserved again; free capacity: %v", drive.Status.FreeCapacity)
+	}
+	if drive := getDrive(clientset, "drive-2"); drive.Status.FreeCapacity != mb100 {
+		t.Fatalf("unexpected reservation on drive-2; free capacity: %v", drive.Status.FreeCapacity)
+	}
+}
+
 func TestControllerGetCapabilities(t *testing.T) {
 	result, err := createFakeController().ControllerGetCapabilities(context.TODO(), nil)
 	if err != nil {
@@ -350,6 +785,51 @@ func TestControllerGetCapabilities(t *testing.T) {
 					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME},
 				},
 			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_EXPAND_VOLUME},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_GET_CAPACITY},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_LIST_SNAPSHOTS},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_CLONE_VOLUME},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_LIST_VOLUMES},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_GET_VOLUME},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_VOLUME_CONDITION},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_SINGLE_NODE_MULTI_WRITER},
+				},
+			},
 		},
 	}
 	if !reflect.DeepEqual(result, expectedResult) {
@@ -380,6 +860,24 @@ func TestValidateVolumeCapabilities(t *testing.T) {
 				},
 			},
 		},
+		{
+			&csi.ValidateVolumeCapabilitiesRequest{
+				VolumeCapabilities: []*csi.VolumeCapability{
+					{AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY}},
+					{AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_SINGLE_WRITER}},
+					{AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_MULTI_WRITER}},
+				},
+			},
+			&csi.ValidateVolumeCapabilitiesResponse{
+				Confirmed: &csi.ValidateVolumeCapabilitiesResponse_Confirmed{
+					VolumeCapabilities: []*csi.VolumeCapability{
+						{AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY}},
+						{AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_SINGLE_WRITER}},
+						{AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_MULTI_WRITER}},
+					},
+				},
+			},
+		},
 		{
 			&csi.ValidateVolumeCapabilitiesRequest{
 				VolumeCapabilities: []*csi.VolumeCapability{
@@ -405,9 +903,111 @@ func TestValidateVolumeCapabilities(t *testing.T) {
 	}
 }
 
+func newHealthTestObjects() []runtime.Object {
+	newDrive := func(name string, driveStatus directcsi.DriveStatus, mountpoint string) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta:   utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: directcsi.DirectCSIDriveStatus{
+				DriveStatus: driveStatus,
+				Mountpoint:  mountpoint,
+				Topology:    map[string]string{"node": "N1"},
+			},
+		}
+	}
+	newVolume := func(name, drive string, ready bool, message string) *directcsi.DirectCSIVolume {
+		return &directcsi.DirectCSIVolume{
+			TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: directcsi.DirectCSIVolumeStatus{
+				Drive:         drive,
+				TotalCapacity: mb20,
+				Conditions: []metav1.Condition{
+					{
+						Type:    string(directcsi.DirectCSIVolumeConditionStaged),
+						Status:  utils.BoolToCondition(ready),
+						Reason:  string(directcsi.DirectCSIVolumeReasonInUse),
+						Message: "",
+					},
+					{
+						Type:    string(directcsi.DirectCSIVolumeConditionReady),
+						Status:  utils.BoolToCondition(ready),
+						Reason:  string(directcsi.DirectCSIVolumeReasonReady),
+						Message: message,
+					},
+				},
+			},
+		}
+	}
+
+	return []runtime.Object{
+		newDrive("drive-1", directcsi.DriveStatusInUse, "/var/lib/direct-csi/mnt/drive-1"),
+		newDrive("drive-2", directcsi.DriveStatusUnavailable, "/var/lib/direct-csi/mnt/drive-2"),
+		newDrive("drive-3", directcsi.DriveStatusInUse, ""),
+		newVolume("volume-1", "drive-1", true, ""),
+		newVolume("volume-2", "drive-2", true, ""),
+		newVolume("volume-3", "drive-3", true, ""),
+		newVolume("volume-4", "drive-1", false, "[DRIVE LOST]"),
+		newVolume("volume-5", "drive-4", true, ""),
+		newVolume("volume-6", "drive-1", false, ""),
+	}
+}
+
 func TestListVolumes(t *testing.T) {
-	if _, err := createFakeController().ListVolumes(context.TODO(), nil); err == nil {
-		t.Fatal("error expected")
+	objects := newHealthTestObjects()
+
+	testCases := []struct {
+		request           *csi.ListVolumesRequest
+		expectErr         bool
+		expectedVolumes   []string
+		expectedAbnormal  []bool
+		expectedNextToken string
+	}{
+		{
+			&csi.ListVolumesRequest{},
+			false,
+			[]string{"volume-1", "volume-2", "volume-3", "volume-4", "volume-5", "volume-6"},
+			[]bool{false, true, true, true, true, false},
+			"",
+		},
+		{&csi.ListVolumesRequest{MaxEntries: 4}, false, []string{"volume-1", "volume-2", "volume-3", "volume-4"}, []bool{false, true, true, true}, "4"},
+		{&csi.ListVolumesRequest{MaxEntries: 4, StartingToken: "4"}, false, []string{"volume-5", "volume-6"}, []bool{true, false}, ""},
+		{&csi.ListVolumesRequest{StartingToken: "7"}, true, nil, nil, ""},
+		{&csi.ListVolumesRequest{StartingToken: "invalid"}, true, nil, nil, ""},
+		{&csi.ListVolumesRequest{MaxEntries: -1}, true, nil, nil, ""},
+	}
+
+	for i, testCase := range testCases {
+		directCSIClient := clientsetfake.NewSimpleClientset(objects...)
+		client.SetLatestDirectCSIDriveInterface(directCSIClient.DirectV1beta3().DirectCSIDrives())
+		client.SetLatestDirectCSIVolumeInterface(directCSIClient.DirectV1beta3().DirectCSIVolumes())
+
+		result, err := createFakeController().ListVolumes(context.TODO(), testCase.request)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+
+		var volumes []string
+		var abnormal []bool
+		for _, entry := range result.GetEntries() {
+			volumes = append(volumes, entry.GetVolume().GetVolumeId())
+			abnormal = append(abnormal, entry.GetStatus().GetVolumeCondition().GetAbnormal())
+		}
+		if !reflect.DeepEqual(volumes, testCase.expectedVolumes) {
+			t.Fatalf("case %v: expected volumes: %v, got: %v", i+1, testCase.expectedVolumes, volumes)
+		}
+		if !reflect.DeepEqual(abnormal, testCase.expectedAbnormal) {
+			t.Fatalf("case %v: expected abnormal: %v, got: %v", i+1, testCase.expectedAbnormal, abnormal)
+		}
+		if result.GetNextToken() != testCase.expectedNextToken {
+			t.Fatalf("case %v: expected next token: %v, got: %v", i+1, testCase.expectedNextToken, result.GetNextToken())
+		}
 	}
 }
 
@@ -427,17 +1027,283 @@ func TestControllerExpandVolume(t *testing.T) {
 	if _, err := createFakeController().ControllerExpandVolume(context.TODO(), nil); err == nil {
 		t.Fatal("error expected")
 	}
+
+	drive := &directcsi.DirectCSIDrive{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "drive-1",
+			Finalizers: []string{
+				directcsi.DirectCSIDriveFinalizerDataProtection,
+				directcsi.DirectCSIDriveFinalizerPrefix + "volume-1",
+			},
+		},
+		Status: directcsi.DirectCSIDriveStatus{
+			DriveStatus:       directcsi.DriveStatusInUse,
+			FreeCapacity:      mb100 - mb20,
+			AllocatedCapacity: mb20,
+			TotalCapacity:     mb100,
+		},
+	}
+	volume := &directcsi.DirectCSIVolume{
+		TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "volume-1"},
+		Status: directcsi.DirectCSIVolumeStatus{
+			Drive:             "drive-1",
+			TotalCapacity:     mb20,
+			AvailableCapacity: mb20,
+		},
+	}
+
+	testCases := []struct {
+		requiredBytes         int64
+		expectErr             bool
+		expectedCapacity      int64
+		expectedFreeCapacity  int64
+		expectedAllocCapacity int64
+	}{
+		{0, true, mb20, mb100 - mb20, mb20},
+		{mb100 + mb20, true, mb20, mb100 - mb20, mb20},
+		{mb20, false, mb20, mb100 - mb20, mb20},
+		{2 * mb20, false, 2 * mb20, mb100 - 2*mb20, 2 * mb20},
+	}
+
+	for i, testCase := range testCases {
+		ctx := context.TODO()
+		controller := createFakeController()
+		controller.directcsiClient = clientsetfake.NewSimpleClientset(drive.DeepCopy(), volume.DeepCopy())
+		req := &csi.ControllerExpandVolumeRequest{
+			VolumeId:      "volume-1",
+			CapacityRange: &csi.CapacityRange{RequiredBytes: testCase.requiredBytes},
+		}
+
+		result, err := controller.ControllerExpandVolume(ctx, req)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("case %v: unexpected error %v", i+1, err)
+			}
+			if result.CapacityBytes != testCase.expectedCapacity || !result.NodeExpansionRequired {
+				t.Fatalf("case %v: unexpected result %+v", i+1, result)
+			}
+		}
+
+		directCSIClient := controller.directcsiClient.DirectV1beta3()
+		updatedVolume, err := directCSIClient.DirectCSIVolumes().Get(ctx, "volume-1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if updatedVolume.Status.TotalCapacity != testCase.expectedCapacity {
+			t.Fatalf("case %v: expected volume capacity: %v, got: %v", i+1, testCase.expectedCapacity, updatedVolume.Status.TotalCapacity)
+		}
+
+		updatedDrive, err := directCSIClient.DirectCSIDrives().Get(ctx, "drive-1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if updatedDrive.Status.FreeCapacity != testCase.expectedFreeCapacity {
+			t.Fatalf("case %v: expected free capacity: %v, got: %v", i+1, testCase.expectedFreeCapacity, updatedDrive.Status.FreeCapacity)
+		}
+		if updatedDrive.Status.AllocatedCapacity != testCase.expectedAllocCapacity {
+			t.Fatalf("case %v: expected allocated capacity: %v, got: %v", i+1, testCase.expectedAllocCapacity, updatedDrive.Status.AllocatedCapacity)
+		}
+	}
+}
+
+func TestControllerExpandVolumeConflict(t *testing.T) {
+	drive := &directcsi.DirectCSIDrive{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "drive-1",
+			Finalizers: []string{
+				directcsi.DirectCSIDriveFinalizerDataProtection,
+				directcsi.DirectCSIDriveFinalizerPrefix + "volume-1",
+			},
+		},
+		Status: directcsi.DirectCSIDriveStatus{
+			DriveStatus:       directcsi.DriveStatusInUse,
+			FreeCapacity:      mb100 - mb20,
+			AllocatedCapacity: mb20,
+			TotalCapacity:     mb100,
+		},
+	}
+	volume := &directcsi.DirectCSIVolume{
+		TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "volume-1"},
+		Status: directcsi.DirectCSIVolumeStatus{
+			Drive:             "drive-1",
+			TotalCapacity:     mb20,
+			AvailableCapacity: mb20,
+		},
+	}
+
+	testCases := []struct {
+		volumeUpdateErr       error
+		expectErr             bool
+		expectedCapacity      int64
+		expectedFreeCapacity  int64
+		expectedAllocCapacity int64
+	}{
+		{nil, false, 2 * mb20, mb100 - 2*mb20, 2 * mb20},
+		{fmt.Errorf("volume update failed"), true, mb20, mb100 - mb20, mb20},
+	}
+
+	driveResource := directcsi.SchemeGroupVersion.WithResource("directcsidrives")
+	for i, testCase := range testCases {
+		ctx := context.TODO()
+		controller := createFakeController()
+		clientset := clientsetfake.NewSimpleClientset(drive.DeepCopy(), volume.DeepCopy())
+		controller.directcsiClient = clientset
+
+		// Every first update of the drive conflicts with a concurrent update.
+		conflicted := false
+		clientset.PrependReactor("update", "directcsidrives", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			conflicted = !conflicted
+			if conflicted {
+				return true, nil, errors.NewConflict(driveResource.GroupResource(), "drive-1", fmt.Errorf("stale resource version"))
+			}
+			return false, nil, nil
+		})
+		clientset.PrependReactor("update", "directcsivolumes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if testCase.volumeUpdateErr != nil {
+				return true, nil, testCase.volumeUpdateErr
+			}
+			return false, nil, nil
+		})
+
+		_, err := controller.ControllerExpandVolume(ctx, &csi.ControllerExpandVolumeRequest{
+			VolumeId:      "volume-1",
+			CapacityRange: &csi.CapacityRange{RequiredBytes: 2 * mb20},
+		})
+		if testCase.expectErr && err == nil {
+			t.Fatalf("case %v: expected error, but succeeded", i+1)
+		}
+		if !testCase.expectErr && err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+
+		directCSIClient := clientset.DirectV1beta3()
+		updatedVolume, err := directCSIClient.DirectCSIVolumes().Get(ctx, "volume-1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if updatedVolume.Status.TotalCapacity != testCase.expectedCapacity {
+			t.Fatalf("case %v: expected volume capacity: %v, got: %v", i+1, testCase.expectedCapacity, updatedVolume.Status.TotalCapacity)
+		}
+
+		updatedDrive, err := directCSIClient.DirectCSIDrives().Get(ctx, "drive-1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if updatedDrive.Status.FreeCapacity != testCase.expectedFreeCapacity || updatedDrive.Status.AllocatedCapacity != testCase.expectedAllocCapacity {
+			t.Fatalf("case %v: expected free/allocated capacity: %v/%v, got: %v/%v", i+1, testCase.expectedFreeCapacity, testCase.expectedAllocCapacity, updatedDrive.Status.FreeCapacity, updatedDrive.Status.AllocatedCapacity)
+		}
+	}
 }
 
 func TestControllerGetVolume(t *testing.T) {
-	if _, err := createFakeController().ControllerGetVolume(context.TODO(), nil); err == nil {
-		t.Fatal("error expected")
+	testCases := []struct {
+		volumeID         string
+		expectErr        bool
+		expectedAbnormal bool
+	}{
+		{"volume-1", false, false},
+		{"volume-2", false, true},
+		{"volume-3", false, true},
+		{"volume-4", false, true},
+		{"volume-5", false, true},
+		{"volume-6", false, false},
+		{"volume-7", true, false},
+		{"", true, false},
+	}
+
+	controller := createFakeController()
+	controller.directcsiClient = clientsetfake.NewSimpleClientset(newHealthTestObjects()...)
+	for i, testCase := range testCases {
+		result, err := controller.ControllerGetVolume(context.TODO(), &csi.ControllerGetVolumeRequest{VolumeId: testCase.volumeID})
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+
+		if result.GetVolume().GetVolumeId() != testCase.volumeID {
+			t.Fatalf("case %v: expected volume: %v, got: %v", i+1, testCase.volumeID, result.GetVolume().GetVolumeId())
+		}
+		if result.GetStatus().GetVolumeCondition().GetAbnormal() != testCase.expectedAbnormal {
+			t.Fatalf("case %v: expected abnormal: %v, got: %v", i+1, testCase.expectedAbnormal, result.GetStatus().GetVolumeCondition())
+		}
 	}
 }
 
 func TestListSnapshots(t *testing.T) {
-	if _, err := createFakeController().ListSnapshots(context.TODO(), nil); err == nil {
-		t.Fatal("error expected")
+	newSnapshot := func(name, volume string) *directcsi.DirectCSISnapshot {
+		return &directcsi.DirectCSISnapshot{
+			TypeMeta:   utils.DirectCSISnapshotTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: directcsi.DirectCSISnapshotStatus{
+				SourceVolume: volume,
+				Size:         mb20,
+				ReadyToUse:   true,
+			},
+		}
+	}
+
+	objects := []runtime.Object{
+		newSnapshot("snapshot-3", "volume-2"),
+		newSnapshot("snapshot-1", "volume-1"),
+		newSnapshot("snapshot-2", "volume-1"),
+	}
+
+	testCases := []struct {
+		request           *csi.ListSnapshotsRequest
+		expectErr         bool
+		expectedSnapshots []string
+		expectedNextToken string
+	}{
+		{&csi.ListSnapshotsRequest{}, false, []string{"snapshot-1", "snapshot-2", "snapshot-3"}, ""},
+		{&csi.ListSnapshotsRequest{SnapshotId: "snapshot-2"}, false, []string{"snapshot-2"}, ""},
+		{&csi.ListSnapshotsRequest{SnapshotId: "snapshot-4"}, false, nil, ""},
+		{&csi.ListSnapshotsRequest{SnapshotId: "snapshot-3", SourceVolumeId: "volume-1"}, false, nil, ""},
+		{&csi.ListSnapshotsRequest{SourceVolumeId: "volume-1"}, false, []string{"snapshot-1", "snapshot-2"}, ""},
+		{&csi.ListSnapshotsRequest{MaxEntries: 2}, false, []string{"snapshot-1", "snapshot-2"}, "2"},
+		{&csi.ListSnapshotsRequest{MaxEntries: 2, StartingToken: "2"}, false, []string{"snapshot-3"}, ""},
+		{&csi.ListSnapshotsRequest{StartingToken: "4"}, true, nil, ""},
+		{&csi.ListSnapshotsRequest{StartingToken: "invalid"}, true, nil, ""},
+		{&csi.ListSnapshotsRequest{MaxEntries: -1}, true, nil, ""},
+	}
+
+	for i, testCase := range testCases {
+		controller := createFakeController()
+		controller.directcsiClient = clientsetfake.NewSimpleClientset(objects...)
+
+		result, err := controller.ListSnapshots(context.TODO(), testCase.request)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+
+		var snapshots []string
+		for _, entry := range result.GetEntries() {
+			snapshots = append(snapshots, entry.GetSnapshot().GetSnapshotId())
+		}
+		if !reflect.DeepEqual(snapshots, testCase.expectedSnapshots) {
+			t.Fatalf("case %v: expected snapshots: %v, got: %v", i+1, testCase.expectedSnapshots, snapshots)
+		}
+		if result.GetNextToken() != testCase.expectedNextToken {
+			t.Fatalf("case %v: expected next token: %v, got: %v", i+1, testCase.expectedNextToken, result.GetNextToken())
+		}
 	}
 }
 
@@ -445,16 +1311,184 @@ func TestCreateSnapshot(t *testing.T) {
 	if _, err := createFakeController().CreateSnapshot(context.TODO(), nil); err == nil {
 		t.Fatal("error expected")
 	}
+
+	drive := &directcsi.DirectCSIDrive{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "drive-1",
+			Finalizers: []string{
+				directcsi.DirectCSIDriveFinalizerDataProtection,
+				directcsi.DirectCSIDriveFinalizerPrefix + "volume-1",
+			},
+		},
+		Status: directcsi.DirectCSIDriveStatus{
+			NodeName:          "node-1",
+			DriveStatus:       directcsi.DriveStatusInUse,
+			FreeCapacity:      mb20,
+			AllocatedCapacity: mb100 - mb20,
+			TotalCapacity:     mb100,
+		},
+	}
+	newVolume := func(name string, capacity int64) *directcsi.DirectCSIVolume {
+		return &directcsi.DirectCSIVolume{
+			TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: directcsi.DirectCSIVolumeStatus{
+				Drive:         "drive-1",
+				NodeName:      "node-1",
+				TotalCapacity: capacity,
+			},
+		}
+	}
+	snapshot := &directcsi.DirectCSISnapshot{
+		TypeMeta:   utils.DirectCSISnapshotTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "snapshot-2"},
+		Status: directcsi.DirectCSISnapshotStatus{
+			SourceVolume: "volume-2",
+			Drive:        "drive-1",
+			Size:         mb20,
+		},
+	}
+
+	testCases := []struct {
+		request               *csi.CreateSnapshotRequest
+		expectErr             bool
+		expectedFreeCapacity  int64
+		expectedAllocCapacity int64
+	}{
+		{&csi.CreateSnapshotRequest{SourceVolumeId: "volume-1"}, true, mb20, mb100 - mb20},
+		{&csi.CreateSnapshotRequest{Name: "snapshot-1"}, true, mb20, mb100 - mb20},
+		{&csi.CreateSnapshotRequest{Name: "snapshot-1", SourceVolumeId: "volume-3"}, true, mb20, mb100 - mb20},
+		{&csi.CreateSnapshotRequest{Name: "snapshot-1", SourceVolumeId: "volume-4"}, true, mb20, mb100 - mb20},
+		{&csi.CreateSnapshotRequest{Name: "snapshot-2", SourceVolumeId: "volume-1"}, true, mb20, mb100 - mb20},
+		{&csi.CreateSnapshotRequest{Name: "snapshot-1", SourceVolumeId: "volume-1"}, false, 0, mb100},
+		{&csi.CreateSnapshotRequest{Name: "snapshot-2", SourceVolumeId: "volume-2"}, false, 0, mb100},
+	}
+
+	for i, testCase := range testCases {
+		ctx := context.TODO()
+		controller := createFakeController()
+		controller.directcsiClient = clientsetfake.NewSimpleClientset(
+			drive.DeepCopy(), newVolume("volume-1", mb20), newVolume("volume-2", mb20), newVolume("volume-4", mb100), snapshot.DeepCopy(),
+		)
+
+		result, err := controller.CreateSnapshot(ctx, testCase.request)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("case %v: unexpected error %v", i+1, err)
+			}
+			if result.Snapshot.SnapshotId != testCase.request.Name || result.Snapshot.SourceVolumeId != testCase.request.SourceVolumeId || result.Snapshot.SizeBytes != mb20 {
+				t.Fatalf("case %v: unexpected result %+v", i+1, result)
+			}
+
+			// Repeated request must not reserve the drive again.
+			if _, err = controller.CreateSnapshot(ctx, testCase.request); err != nil {
+				t.Fatalf("case %v: unexpected error %v", i+1, err)
+			}
+		}
+
+		updatedDrive, err := controller.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(ctx, "drive-1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if updatedDrive.Status.FreeCapacity != testCase.expectedFreeCapacity {
+			t.Fatalf("case %v: expected free capacity: %v, got: %v", i+1, testCase.expectedFreeCapacity, updatedDrive.Status.FreeCapacity)
+		}
+		if updatedDrive.Status.AllocatedCapacity != testCase.expectedAllocCapacity {
+			t.Fatalf("case %v: expected allocated capacity: %v, got: %v", i+1, testCase.expectedAllocCapacity, updatedDrive.Status.AllocatedCapacity)
+		}
+	}
 }
 
 func TestDeleteSnapshot(t *testing.T) {
 	if _, err := createFakeController().DeleteSnapshot(context.TODO(), nil); err == nil {
 		t.Fatal("error expected")
 	}
+
+	controller := createFakeController()
+	controller.directcsiClient = clientsetfake.NewSimpleClientset(&directcsi.DirectCSISnapshot{
+		TypeMeta:   utils.DirectCSISnapshotTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "snapshot-1"},
+	})
+
+	for i, snapshotID := range []string{"snapshot-1", "snapshot-1"} {
+		if _, err := controller.DeleteSnapshot(context.TODO(), &csi.DeleteSnapshotRequest{SnapshotId: snapshotID}); err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+	}
 }
 
 func TestGetCapacity(t *testing.T) {
-	if _, err := createFakeController().GetCapacity(context.TODO(), nil); err == nil {
-		t.Fatal("error expected")
+	newDrive := func(name, node string, driveStatus directcsi.DriveStatus, accessTier directcsi.AccessTier, freeCapacity int64) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta:   utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:     node,
+				Filesystem:   "xfs",
+				DriveStatus:  driveStatus,
+				AccessTier:   accessTier,
+				FreeCapacity: freeCapacity,
+				Topology:     map[string]string{"node": node},
+			},
+		}
+	}
+
+	objects := []runtime.Object{
+		newDrive("drive-1", "N1", directcsi.DriveStatusReady, directcsi.AccessTierHot, mb100),
+		newDrive("drive-2", "N1", directcsi.DriveStatusInUse, directcsi.AccessTierWarm, mb20),
+		newDrive("drive-3", "N1", directcsi.DriveStatusAvailable, directcsi.AccessTierHot, mb100),
+		newDrive("drive-4", "N2", directcsi.DriveStatusReady, directcsi.AccessTierHot, mb20),
+	}
+
+	testCases := []struct {
+		req              *csi.GetCapacityRequest
+		expectedCapacity int64
+		expectErr        bool
+	}{
+		{nil, mb100 + 2*mb20, false},
+		{&csi.GetCapacityRequest{AccessibleTopology: &csi.Topology{Segments: map[string]string{"node": "N1"}}}, mb100 + mb20, false},
+		{&csi.GetCapacityRequest{AccessibleTopology: &csi.Topology{Segments: map[string]string{"node": "N3"}}}, 0, false},
+		{&csi.GetCapacityRequest{Parameters: map[string]string{accessTierParameter: "Hot"}}, mb100 + mb20, false},
+		{
+			&csi.GetCapacityRequest{
+				AccessibleTopology: &csi.Topology{Segments: map[string]string{"node": "N1"}},
+				Parameters:         map[string]string{accessTierParameter: "Warm"},
+			},
+			mb20,
+			false,
+		},
+		{&csi.GetCapacityRequest{Parameters: map[string]string{accessTierParameter: "Frozen"}}, 0, true},
+		{
+			&csi.GetCapacityRequest{
+				VolumeCapabilities: []*csi.VolumeCapability{
+					{AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "ext4"}}},
+				},
+			},
+			0,
+			false,
+		},
+	}
+
+	client.SetLatestDirectCSIDriveInterface(clientsetfake.NewSimpleClientset(objects...).DirectV1beta3().DirectCSIDrives())
+	for i, testCase := range testCases {
+		result, err := createFakeController().GetCapacity(context.TODO(), testCase.req)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if result.AvailableCapacity != testCase.expectedCapacity {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedCapacity, result.AvailableCapacity)
+		}
 	}
 }
@@ -0,0 +1,74 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/utils"
+
+	"k8s.io/klog/v2"
+)
+
+const overcommitRatioParameter = "direct-csi-min-io/overcommit-ratio"
+
+// parseOvercommitRatio parses overcommit ratio value; ratio must not be less than 1.
+func parseOvercommitRatio(value string) (float64, error) {
+	ratio, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid overcommit ratio %v; %w", value, err)
+	}
+	if math.IsNaN(ratio) || math.IsInf(ratio, 0) {
+		return 0, fmt.Errorf("invalid overcommit ratio %v; ratio must be a finite number", value)
+	}
+	if ratio < 1 {
+		return 0, fmt.Errorf("invalid overcommit ratio %v; ratio must be greater than or equal to 1", value)
+	}
+	return ratio, nil
+}
+
+// getOvercommitRatio returns overcommit ratio of the drive. Ratio from storage class
+// parameters takes precedence over the ratio set in the drive label; ratio 1 is
+// returned if none of them are set i.e. overcommit is disabled.
+func getOvercommitRatio(drive directcsi.DirectCSIDrive, parameters map[string]string) float64 {
+	value, found := parameters[overcommitRatioParameter]
+	if !found {
+		if value, found = drive.GetLabels()[string(utils.OvercommitRatioLabelKey)]; !found {
+			return 1
+		}
+	}
+
+	ratio, err := parseOvercommitRatio(value)
+	if err != nil {
+		klog.ErrorS(err, "ignoring overcommit ratio", "drive", drive.Name)
+		return 1
+	}
+
+	return ratio
+}
+
+// getAllocatableCapacity returns the capacity which can still be allocated on the drive
+// for the overcommit ratio; for ratio 1, it is the free capacity of the drive.
+func getAllocatableCapacity(drive directcsi.DirectCSIDrive, ratio float64) int64 {
+	if ratio <= 1 {
+		return drive.Status.FreeCapacity
+	}
+	return int64(float64(drive.Status.TotalCapacity)*ratio) - drive.Status.AllocatedCapacity
+}
@@ -0,0 +1,116 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseOvercommitRatio(t *testing.T) {
+	testCases := []struct {
+		value         string
+		expectedRatio float64
+		expectErr     bool
+	}{
+		{"1", 1, false},
+		{"1.5", 1.5, false},
+		{"4", 4, false},
+		{"0.5", 0, true},
+		{"-2", 0, true},
+		{"", 0, true},
+		{"two", 0, true},
+		{"NaN", 0, true},
+		{"Inf", 0, true},
+		{"+Inf", 0, true},
+		{"-Inf", 0, true},
+	}
+
+	for i, testCase := range testCases {
+		ratio, err := parseOvercommitRatio(testCase.value)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if ratio != testCase.expectedRatio {
+			t.Fatalf("case %v: expected ratio: %v, got: %v", i+1, testCase.expectedRatio, ratio)
+		}
+	}
+}
+
+func TestMatchDriveOvercommit(t *testing.T) {
+	newDrive := func(allocated int64, ratio string) directcsi.DirectCSIDrive {
+		drive := directcsi.DirectCSIDrive{
+			TypeMeta:   utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: "drive-1"},
+			Status: directcsi.DirectCSIDriveStatus{
+				DriveStatus:       directcsi.DriveStatusInUse,
+				Filesystem:        "xfs",
+				FreeCapacity:      mb100 - allocated,
+				AllocatedCapacity: allocated,
+				TotalCapacity:     mb100,
+			},
+		}
+		if ratio != "" {
+			drive.Labels = map[string]string{string(utils.OvercommitRatioLabelKey): ratio}
+		}
+		return drive
+	}
+	newRequest := func(size int64, ratio string) *csi.CreateVolumeRequest {
+		req := &csi.CreateVolumeRequest{
+			Name:          "volume-1",
+			CapacityRange: &csi.CapacityRange{RequiredBytes: size},
+		}
+		if ratio != "" {
+			req.Parameters = map[string]string{overcommitRatioParameter: ratio}
+		}
+		return req
+	}
+
+	testCases := []struct {
+		drive          directcsi.DirectCSIDrive
+		req            *csi.CreateVolumeRequest
+		expectedResult bool
+	}{
+		{newDrive(0, ""), newRequest(mb100, ""), true},
+		{newDrive(0, ""), newRequest(mb100+1, ""), false},
+		{newDrive(mb100, ""), newRequest(mb20, ""), false},
+		{newDrive(mb100, ""), newRequest(mb20, "2"), true},
+		{newDrive(mb100, "2"), newRequest(mb20, ""), true},
+		{newDrive(mb100, "2"), newRequest(mb100, ""), true},
+		{newDrive(mb100, "2"), newRequest(mb100+1, ""), false},
+		{newDrive(mb100, "2"), newRequest(mb20, "1"), false},
+		{newDrive(mb100, "1.5"), newRequest(mb100/2, ""), true},
+		{newDrive(mb100, "1.5"), newRequest(mb100/2+1, ""), false},
+		{newDrive(mb100, "invalid"), newRequest(mb20, ""), false},
+	}
+
+	for i, testCase := range testCases {
+		if result := matchDrive(testCase.drive, testCase.req); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
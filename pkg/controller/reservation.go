@@ -0,0 +1,214 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/matcher"
+	"github.com/minio/directpv/pkg/utils"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// maxDriveReservationRetries is the number of times a drive is re-selected
+// when the selected drive no longer satisfies the request.
+const maxDriveReservationRetries = 3
+
+var errDriveMismatch = errors.New("drive does not satisfy the request")
+
+// getVolumeSize returns the size of the volume to be created on the drive.
+func getVolumeSize(drive *directcsi.DirectCSIDrive, req *csi.CreateVolumeRequest, block bool) int64 {
+	switch {
+	case block:
+		return drive.Status.TotalCapacity
+	case req.GetCapacityRange() != nil:
+		return req.GetCapacityRange().GetRequiredBytes()
+	default:
+		return drive.Status.FreeCapacity
+	}
+}
+
+// reserveDrive reserves capacity of the drive for the requested volume. The drive
+// is re-fetched and re-matched on every attempt so that concurrent reservations
+// never over-allocate it; errDriveMismatch is returned if the latest state of the
+// drive does not satisfy the request.
+func (c *ControllerServer) reserveDrive(ctx context.Context, req *csi.CreateVolumeRequest, driveName string, block bool) (drive *directcsi.DirectCSIDrive, size int64, err error) {
+	name := req.GetName()
+	finalizer := directcsi.DirectCSIDriveFinalizerPrefix + name
+	driveInterface := c.directcsiClient.DirectV1beta3().DirectCSIDrives()
+
+	reserved := false
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		drive, err = driveInterface.Get(
+			ctx, driveName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+		)
+		if err != nil {
+			return err
+		}
+
+		size = getVolumeSize(drive, req, block)
+
+		// Drive is already reserved for this volume by a previous request.
+		if matcher.StringIn(drive.Finalizers, finalizer) {
+			return nil
+		}
+
+		if !matchDrive(*drive, req) {
+			return errDriveMismatch
+		}
+
+		if block {
+			drive.Status.BlockVolume = name
+			drive.Status.FreeCapacity = 0
+			drive.Status.AllocatedCapacity = drive.Status.TotalCapacity
+		} else {
+			drive.Status.FreeCapacity = drive.Status.FreeCapacity - size
+			drive.Status.AllocatedCapacity = drive.Status.AllocatedCapacity + size
+		}
+		drive.Status.DriveStatus = directcsi.DriveStatusInUse
+		drive.SetFinalizers(append(drive.GetFinalizers(), finalizer))
+
+		klog.V(4).InfoS("Reserving DirectCSI drive",
+			"drive-name", drive.Name,
+			"node", drive.Status.NodeName,
+			"volume", name)
+
+		drive, err = driveInterface.Update(
+			ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+		)
+		reserved = err == nil
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if reserved {
+		client.Eventf(drive, corev1.EventTypeNormal, "DriveReservationSucceded", "reserved drive %v on node %v and volume %v", drive.Name, drive.Status.NodeName, name)
+	}
+
+	return drive, size, nil
+}
+
+// releaseDrive releases capacity of the drive reserved for the volume which could
+// not be created.
+func (c *ControllerServer) releaseDrive(ctx context.Context, driveName, volumeName string, size int64, block bool) error {
+	finalizer := directcsi.DirectCSIDriveFinalizerPrefix + volumeName
+	driveInterface := c.directcsiClient.DirectV1beta3().DirectCSIDrives()
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		drive, err := driveInterface.Get(
+			ctx, driveName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+		)
+		if err != nil {
+			return err
+		}
+
+		var finalizers []string
+		for _, f := range drive.GetFinalizers() {
+			if f != finalizer {
+				finalizers = append(finalizers, f)
+			}
+		}
+		if len(finalizers) == len(drive.GetFinalizers()) {
+			return nil
+		}
+
+		if block {
+			drive.Status.BlockVolume = ""
+		}
+		drive.Status.FreeCapacity += size
+		drive.Status.AllocatedCapacity -= size
+		if len(finalizers) == 1 && finalizers[0] == directcsi.DirectCSIDriveFinalizerDataProtection {
+			drive.Status.DriveStatus = directcsi.DriveStatusReady
+		}
+		drive.SetFinalizers(finalizers)
+
+		klog.V(4).InfoS("Releasing DirectCSI drive",
+			"drive-name", drive.Name,
+			"node", drive.Status.NodeName,
+			"volume", volumeName)
+
+		_, err = driveInterface.Update(
+			ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+		)
+		return err
+	})
+}
+
+// reserveExpansion reserves additional capacity of the drive for the expanding volume.
+func (c *ControllerServer) reserveExpansion(ctx context.Context, driveName, volumeName string, delta int64) error {
+	finalizer := directcsi.DirectCSIDriveFinalizerPrefix + volumeName
+	driveInterface := c.directcsiClient.DirectV1beta3().DirectCSIDrives()
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		drive, err := driveInterface.Get(
+			ctx, driveName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+		)
+		if err != nil {
+			return err
+		}
+
+		if !matcher.StringIn(drive.Finalizers, finalizer) {
+			return status.Errorf(codes.FailedPrecondition, "drive [%s] is not reserved for volume [%s]", drive.Name, volumeName)
+		}
+
+		// Expansion honors overcommit ratio of the drive label only as the request has no storage class parameters.
+		if allocatable := getAllocatableCapacity(*drive, getOvercommitRatio(*drive, nil)); allocatable < delta {
+			return status.Errorf(codes.OutOfRange, "drive [%s] does not have enough free capacity; requested %v, available %v", drive.Name, delta, allocatable)
+		}
+
+		drive.Status.FreeCapacity -= delta
+		drive.Status.AllocatedCapacity += delta
+		_, err = driveInterface.Update(
+			ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+		)
+		return err
+	})
+}
+
+// releaseExpansion releases capacity of the drive reserved for the volume expansion
+// which could not be completed.
+func (c *ControllerServer) releaseExpansion(ctx context.Context, driveName string, delta int64) error {
+	driveInterface := c.directcsiClient.DirectV1beta3().DirectCSIDrives()
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		drive, err := driveInterface.Get(
+			ctx, driveName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+		)
+		if err != nil {
+			return err
+		}
+
+		drive.Status.FreeCapacity += delta
+		drive.Status.AllocatedCapacity -= delta
+		_, err = driveInterface.Update(
+			ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+		)
+		return err
+	})
+}
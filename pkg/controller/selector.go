@@ -0,0 +1,68 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/utils"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+const driveLabelSelectorParameter = "direct-csi-min-io/drive-selector"
+
+// parseDriveLabelSelector parses label selector on user-defined drive labels;
+// the label keys are specified without the user label prefix.
+func parseDriveLabelSelector(value string) (labels.Selector, error) {
+	selector, err := labels.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid drive selector %v; %w", value, err)
+	}
+	return selector, nil
+}
+
+// getUserLabels returns user-defined labels of the drive without the user label prefix.
+func getUserLabels(drive directcsi.DirectCSIDrive) labels.Set {
+	userLabels := labels.Set{}
+	for key, value := range drive.GetLabels() {
+		if strings.HasPrefix(key, utils.DriveUserLabelPrefix) {
+			userLabels[strings.TrimPrefix(key, utils.DriveUserLabelPrefix)] = value
+		}
+	}
+	return userLabels
+}
+
+// matchDriveLabelSelector returns whether user-defined labels of the drive match
+// the drive selector in parameters; invalid selector matches no drive.
+func matchDriveLabelSelector(drive directcsi.DirectCSIDrive, parameters map[string]string) bool {
+	value, found := parameters[driveLabelSelectorParameter]
+	if !found {
+		return true
+	}
+
+	selector, err := parseDriveLabelSelector(value)
+	if err != nil {
+		klog.ErrorS(err, "unable to parse drive selector")
+		return false
+	}
+
+	return selector.Matches(getUserLabels(drive))
+}
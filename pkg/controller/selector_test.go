@@ -0,0 +1,58 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"testing"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMatchDriveLabelSelector(t *testing.T) {
+	drive := directcsi.DirectCSIDrive{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "drive-1",
+			Labels: map[string]string{
+				utils.DriveUserLabelPrefix + "vendor": "samsung",
+				utils.DriveUserLabelPrefix + "media":  "nvme",
+				string(utils.AccessTierLabelKey):      "Hot",
+			},
+		},
+	}
+
+	testCases := []struct {
+		parameters     map[string]string
+		expectedResult bool
+	}{
+		{nil, true},
+		{map[string]string{driveLabelSelectorParameter: "vendor=samsung"}, true},
+		{map[string]string{driveLabelSelectorParameter: "vendor=samsung,media in (nvme,ssd)"}, true},
+		{map[string]string{driveLabelSelectorParameter: "vendor!=samsung"}, false},
+		{map[string]string{driveLabelSelectorParameter: "enclosure"}, false},
+		{map[string]string{driveLabelSelectorParameter: "!enclosure"}, true},
+		{map[string]string{driveLabelSelectorParameter: "access-tier=Hot"}, false},
+		{map[string]string{driveLabelSelectorParameter: "vendor in (samsung"}, false},
+	}
+
+	for i, testCase := range testCases {
+		if result := matchDriveLabelSelector(drive, testCase.parameters); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
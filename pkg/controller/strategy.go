@@ -0,0 +1,185 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/utils"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+const (
+	driveSelectionParameter = "direct-csi-min-io/drive-selection"
+
+	// Below parameters are passed by csi-provisioner with --extra-create-metadata.
+	pvcNameParameter      = "csi.storage.k8s.io/pvc/name"
+	pvcNamespaceParameter = "csi.storage.k8s.io/pvc/namespace"
+)
+
+// driveSelectionStrategy denotes the strategy to select a drive among matching drives.
+type driveSelectionStrategy string
+
+const (
+	// driveSelectionMaxFree selects the drive having maximum free capacity; this is the default strategy.
+	driveSelectionMaxFree driveSelectionStrategy = "max-free"
+
+	// driveSelectionBinPack selects the drive having least free capacity which still fits the volume.
+	driveSelectionBinPack driveSelectionStrategy = "bin-pack"
+
+	// driveSelectionSpread selects the drive having fewest volumes.
+	driveSelectionSpread driveSelectionStrategy = "spread"
+
+	// driveSelectionStatefulSetSpread selects the drive having fewest volumes of the same StatefulSet.
+	driveSelectionStatefulSetSpread driveSelectionStrategy = "statefulset-spread"
+)
+
+// toDriveSelectionStrategy converts string value to driveSelectionStrategy.
+func toDriveSelectionStrategy(value string) (driveSelectionStrategy, error) {
+	switch driveSelectionStrategy(value) {
+	case "", driveSelectionMaxFree:
+		return driveSelectionMaxFree, nil
+	case driveSelectionBinPack, driveSelectionSpread, driveSelectionStatefulSetSpread:
+		return driveSelectionStrategy(value), nil
+	default:
+		return "", fmt.Errorf("unknown drive selection strategy %v; the possible values are %v|%v|%v|%v",
+			value, driveSelectionMaxFree, driveSelectionBinPack, driveSelectionSpread, driveSelectionStatefulSetSpread)
+	}
+}
+
+// driveSelector returns the best drives for the volume among given drives.
+type driveSelector func(drives []directcsi.DirectCSIDrive) []directcsi.DirectCSIDrive
+
+// selectDrivesBy returns the drives having the least value by valueFunc.
+func selectDrivesBy(drives []directcsi.DirectCSIDrive, valueFunc func(drive directcsi.DirectCSIDrive) int64) []directcsi.DirectCSIDrive {
+	var result []directcsi.DirectCSIDrive
+	var minValue int64
+	for _, drive := range drives {
+		value := valueFunc(drive)
+		switch {
+		case len(result) == 0 || value < minValue:
+			minValue = value
+			result = []directcsi.DirectCSIDrive{drive}
+		case value == minValue:
+			result = append(result, drive)
+		}
+	}
+	return result
+}
+
+func selectMaxFreeDrives(drives []directcsi.DirectCSIDrive) []directcsi.DirectCSIDrive {
+	return selectDrivesBy(drives, func(drive directcsi.DirectCSIDrive) int64 {
+		return -drive.Status.FreeCapacity
+	})
+}
+
+func selectBinPackDrives(drives []directcsi.DirectCSIDrive) []directcsi.DirectCSIDrive {
+	return selectDrivesBy(drives, func(drive directcsi.DirectCSIDrive) int64 {
+		return drive.Status.FreeCapacity
+	})
+}
+
+func getVolumeCount(drive directcsi.DirectCSIDrive) int64 {
+	count := int64(0)
+	for _, finalizer := range drive.GetFinalizers() {
+		if strings.HasPrefix(finalizer, directcsi.DirectCSIDriveFinalizerPrefix) {
+			count++
+		}
+	}
+	return count
+}
+
+func selectSpreadDrives(drives []directcsi.DirectCSIDrive) []directcsi.DirectCSIDrive {
+	return selectMaxFreeDrives(selectDrivesBy(drives, getVolumeCount))
+}
+
+// newStatefulSetSpreadSelector returns selector to choose the drives having fewest volumes
+// of the same StatefulSet of the PVC among given volumes.
+func newStatefulSetSpreadSelector(volumes []directcsi.DirectCSIVolume, statefulSets []appsv1.StatefulSet, pvcName, pvcNamespace string) driveSelector {
+	statefulSet, _ := getStatefulSetPod(statefulSets, string(utils.NewLabelValue(pvcName)))
+	if statefulSet == "" {
+		return selectMaxFreeDrives
+	}
+
+	counts := map[string]int64{}
+	for _, volume := range volumes {
+		labels := volume.GetLabels()
+		if labels[string(utils.PVCNamespaceLabelKey)] != string(utils.NewLabelValue(pvcNamespace)) {
+			continue
+		}
+		if name, _ := getStatefulSetPod(statefulSets, labels[string(utils.PVCNameLabelKey)]); name == statefulSet {
+			counts[volume.Status.Drive]++
+		}
+	}
+
+	return func(drives []directcsi.DirectCSIDrive) []directcsi.DirectCSIDrive {
+		return selectMaxFreeDrives(selectDrivesBy(drives, func(drive directcsi.DirectCSIDrive) int64 {
+			return counts[drive.Name]
+		}))
+	}
+}
+
+// getDriveSelector returns drive selector for the strategy requested in parameters.
+func getDriveSelector(ctx context.Context, req *csi.CreateVolumeRequest) (driveSelector, error) {
+	strategy, err := toDriveSelectionStrategy(req.GetParameters()[driveSelectionParameter])
+	if err != nil {
+		return nil, err
+	}
+
+	switch strategy {
+	case driveSelectionBinPack:
+		return selectBinPackDrives, nil
+	case driveSelectionSpread:
+		return selectSpreadDrives, nil
+	case driveSelectionStatefulSetSpread:
+		statefulSets, err := listStatefulSets(ctx, req.GetParameters()[pvcNamespaceParameter])
+		if err != nil {
+			return nil, err
+		}
+		volumes, err := client.GetVolumeList(ctx, nil, nil, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		return newStatefulSetSpreadSelector(
+			volumes, statefulSets, req.GetParameters()[pvcNameParameter], req.GetParameters()[pvcNamespaceParameter],
+		), nil
+	default:
+		return selectMaxFreeDrives, nil
+	}
+}
+
+// pickDrive picks a drive randomly among given drives.
+func pickDrive(drives []directcsi.DirectCSIDrive) (*directcsi.DirectCSIDrive, error) {
+	if len(drives) == 1 {
+		return &drives[0], nil
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(drives))))
+	if err != nil {
+		return nil, fmt.Errorf("random number generation failed; %w", err)
+	}
+
+	return &drives[n.Int64()], nil
+}
@@ -0,0 +1,139 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newStrategyTestDrive(name string, freeCapacity int64, volumes ...string) directcsi.DirectCSIDrive {
+	finalizers := []string{directcsi.DirectCSIDriveFinalizerDataProtection}
+	for _, volume := range volumes {
+		finalizers = append(finalizers, directcsi.DirectCSIDriveFinalizerPrefix+volume)
+	}
+	return directcsi.DirectCSIDrive{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Finalizers: finalizers},
+		Status:     directcsi.DirectCSIDriveStatus{DriveStatus: directcsi.DriveStatusInUse, FreeCapacity: freeCapacity},
+	}
+}
+
+func newStrategyTestVolume(name, drive, pvcName, pvcNamespace string) directcsi.DirectCSIVolume {
+	volume := directcsi.DirectCSIVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     directcsi.DirectCSIVolumeStatus{Drive: drive},
+	}
+	utils.SetLabels(&volume, map[utils.LabelKey]utils.LabelValue{
+		utils.PVCNameLabelKey:      utils.NewLabelValue(pvcName),
+		utils.PVCNamespaceLabelKey: utils.NewLabelValue(pvcNamespace),
+	})
+	return volume
+}
+
+func getDriveNames(drives []directcsi.DirectCSIDrive) (names []string) {
+	for _, drive := range drives {
+		names = append(names, drive.Name)
+	}
+	return names
+}
+
+func TestToDriveSelectionStrategy(t *testing.T) {
+	testCases := []struct {
+		value            string
+		expectedStrategy driveSelectionStrategy
+		expectErr        bool
+	}{
+		{"", driveSelectionMaxFree, false},
+		{"max-free", driveSelectionMaxFree, false},
+		{"bin-pack", driveSelectionBinPack, false},
+		{"spread", driveSelectionSpread, false},
+		{"statefulset-spread", driveSelectionStatefulSetSpread, false},
+		{"random", "", true},
+	}
+
+	for i, testCase := range testCases {
+		strategy, err := toDriveSelectionStrategy(testCase.value)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if strategy != testCase.expectedStrategy {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedStrategy, strategy)
+		}
+	}
+}
+
+func TestDriveSelectors(t *testing.T) {
+	drives := []directcsi.DirectCSIDrive{
+		newStrategyTestDrive("drive-1", 4*GiB, "volume-1", "volume-2"),
+		newStrategyTestDrive("drive-2", 2*GiB),
+		newStrategyTestDrive("drive-3", 4*GiB, "volume-3"),
+		newStrategyTestDrive("drive-4", 2*GiB, "volume-4"),
+		newStrategyTestDrive("drive-5", 1*GiB),
+	}
+
+	volumes := []directcsi.DirectCSIVolume{
+		newStrategyTestVolume("volume-1", "drive-1", "data-minio-0", "default"),
+		newStrategyTestVolume("volume-2", "drive-1", "data-other-0", "default"),
+		newStrategyTestVolume("volume-3", "drive-3", "logs-minio-1", "default"),
+		newStrategyTestVolume("volume-4", "drive-4", "data-minio-2", "tenant"),
+	}
+
+	statefulSets := []appsv1.StatefulSet{
+		newTestStatefulSet("minio", "default", "data", "logs"),
+		newTestStatefulSet("other", "default", "data"),
+	}
+	tenantStatefulSets := []appsv1.StatefulSet{
+		newTestStatefulSet("minio", "tenant", "data", "logs"),
+	}
+
+	testCases := []struct {
+		selector       driveSelector
+		expectedDrives []string
+	}{
+		{selectMaxFreeDrives, []string{"drive-1", "drive-3"}},
+		{selectBinPackDrives, []string{"drive-5"}},
+		{selectSpreadDrives, []string{"drive-2"}},
+		{newStatefulSetSpreadSelector(volumes, statefulSets, "data-minio-3", "default"), []string{"drive-2", "drive-4"}},
+		{newStatefulSetSpreadSelector(volumes, statefulSets, "logs-minio-3", "default"), []string{"drive-2", "drive-4"}},
+		{newStatefulSetSpreadSelector(volumes, statefulSets, "data-other-1", "default"), []string{"drive-3"}},
+		{newStatefulSetSpreadSelector(volumes, tenantStatefulSets, "logs-minio-3", "tenant"), []string{"drive-1", "drive-3"}},
+		{newStatefulSetSpreadSelector(volumes, statefulSets, "data", "default"), []string{"drive-1", "drive-3"}},
+		{newStatefulSetSpreadSelector(volumes, nil, "data-minio-3", "default"), []string{"drive-1", "drive-3"}},
+	}
+
+	for i, testCase := range testCases {
+		result := getDriveNames(testCase.selector(drives))
+		if !reflect.DeepEqual(result, testCase.expectedDrives) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedDrives, result)
+		}
+	}
+
+	if result := selectMaxFreeDrives(nil); result != nil {
+		t.Fatalf("expected no drives, got: %v", getDriveNames(result))
+	}
+}
@@ -0,0 +1,135 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+
+	"k8s.io/klog/v2"
+)
+
+const tolerationsParameter = "direct-csi-min-io/tolerations"
+
+// toleration denotes a drive taint toleration of the form "<key>[=<value>][:<effect>]".
+// Toleration without value tolerates any value of the key; toleration without effect
+// tolerates any effect.
+type toleration struct {
+	key    string
+	value  *string
+	effect directcsi.DriveTaintEffect
+}
+
+// parseTolerations parses comma separated tolerations.
+func parseTolerations(value string) ([]toleration, error) {
+	var tolerations []toleration
+	for _, token := range strings.Split(value, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+
+		var t toleration
+		original := token
+		if index := strings.LastIndex(token, ":"); index >= 0 {
+			effect, err := directcsi.ToDriveTaintEffect(token[index+1:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid toleration %v; %w", original, err)
+			}
+			t.effect = effect
+			token = token[:index]
+		}
+
+		if index := strings.Index(token, "="); index >= 0 {
+			tolerationValue := token[index+1:]
+			t.value = &tolerationValue
+			token = token[:index]
+		}
+
+		if token == "" {
+			return nil, fmt.Errorf("invalid toleration %v; empty key", original)
+		}
+		t.key = token
+
+		tolerations = append(tolerations, t)
+	}
+	return tolerations, nil
+}
+
+// getTolerations returns tolerations from parameters; invalid tolerations are ignored.
+func getTolerations(parameters map[string]string) []toleration {
+	value, found := parameters[tolerationsParameter]
+	if !found {
+		return nil
+	}
+
+	tolerations, err := parseTolerations(value)
+	if err != nil {
+		klog.ErrorS(err, "unable to parse tolerations")
+	}
+	return tolerations
+}
+
+func (t toleration) tolerates(key, value string, effect directcsi.DriveTaintEffect) bool {
+	return t.key == key && (t.value == nil || *t.value == value) && (t.effect == "" || t.effect == effect)
+}
+
+// isTolerated returns whether all drive taints having the effect are tolerated.
+// Invalid drive taints are treated as NoSchedule taints.
+func isTolerated(drive directcsi.DirectCSIDrive, tolerations []toleration, effect directcsi.DriveTaintEffect) bool {
+	for key, taintValue := range drive.Spec.DriveTaint {
+		value, taintEffect, err := directcsi.ParseDriveTaintValue(taintValue)
+		if err != nil {
+			klog.ErrorS(err, "invalid drive taint", "drive", drive.Name, "key", key)
+			taintEffect = directcsi.DriveTaintEffectNoSchedule
+		}
+
+		if taintEffect != effect {
+			continue
+		}
+
+		tolerated := false
+		for _, t := range tolerations {
+			if t.tolerates(key, value, taintEffect) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// filterPreferNoScheduleDrives returns the drives without untolerated PreferNoSchedule
+// taints if any, else returns all drives.
+func filterPreferNoScheduleDrives(drives []directcsi.DirectCSIDrive, tolerations []toleration) []directcsi.DirectCSIDrive {
+	var result []directcsi.DirectCSIDrive
+	for _, drive := range drives {
+		if isTolerated(drive, tolerations, directcsi.DriveTaintEffectPreferNoSchedule) {
+			result = append(result, drive)
+		}
+	}
+
+	if len(result) == 0 {
+		return drives
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseTolerations(t *testing.T) {
+	acme := "acme"
+	empty := ""
+	testCases := []struct {
+		value               string
+		expectedTolerations []toleration
+		expectErr           bool
+	}{
+		{"", nil, false},
+		{"tenant", []toleration{{key: "tenant"}}, false},
+		{"tenant=acme", []toleration{{key: "tenant", value: &acme}}, false},
+		{"tenant=", []toleration{{key: "tenant", value: &empty}}, false},
+		{"tenant:NoSchedule", []toleration{{key: "tenant", effect: directcsi.DriveTaintEffectNoSchedule}}, false},
+		{
+			"tenant=acme:PreferNoSchedule, slow",
+			[]toleration{{key: "tenant", value: &acme, effect: directcsi.DriveTaintEffectPreferNoSchedule}, {key: "slow"}},
+			false,
+		},
+		{"tenant:NoExecute", nil, true},
+		{"=acme", nil, true},
+	}
+
+	for i, testCase := range testCases {
+		tolerations, err := parseTolerations(testCase.value)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(tolerations, testCase.expectedTolerations) {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, testCase.expectedTolerations, tolerations)
+		}
+	}
+}
+
+func TestIsTolerated(t *testing.T) {
+	newDrive := func(taints map[string]string) directcsi.DirectCSIDrive {
+		return directcsi.DirectCSIDrive{
+			ObjectMeta: metav1.ObjectMeta{Name: "drive-1"},
+			Spec:       directcsi.DirectCSIDriveSpec{DriveTaint: taints},
+		}
+	}
+	mustParse := func(value string) []toleration {
+		tolerations, err := parseTolerations(value)
+		if err != nil {
+			t.Fatalf("unable to parse tolerations %v; %v", value, err)
+		}
+		return tolerations
+	}
+
+	testCases := []struct {
+		drive          directcsi.DirectCSIDrive
+		tolerations    string
+		effect         directcsi.DriveTaintEffect
+		expectedResult bool
+	}{
+		{newDrive(nil), "", directcsi.DriveTaintEffectNoSchedule, true},
+		{newDrive(map[string]string{"tenant": "acme:NoSchedule"}), "", directcsi.DriveTaintEffectNoSchedule, false},
+		{newDrive(map[string]string{"tenant": "acme"}), "", directcsi.DriveTaintEffectNoSchedule, false},
+		{newDrive(map[string]string{"tenant": "acme:NoSchedule"}), "tenant=acme", directcsi.DriveTaintEffectNoSchedule, true},
+		{newDrive(map[string]string{"tenant": "acme:NoSchedule"}), "tenant", directcsi.DriveTaintEffectNoSchedule, true},
+		{newDrive(map[string]string{"tenant": "acme:NoSchedule"}), "tenant=other", directcsi.DriveTaintEffectNoSchedule, false},
+		{newDrive(map[string]string{"tenant": "acme:NoSchedule"}), "tenant:PreferNoSchedule", directcsi.DriveTaintEffectNoSchedule, false},
+		{newDrive(map[string]string{"tenant": "acme:PreferNoSchedule"}), "", directcsi.DriveTaintEffectNoSchedule, true},
+		{newDrive(map[string]string{"tenant": "acme:PreferNoSchedule"}), "", directcsi.DriveTaintEffectPreferNoSchedule, false},
+		{newDrive(map[string]string{"tenant": "acme:Invalid"}), "", directcsi.DriveTaintEffectNoSchedule, false},
+		{newDrive(map[string]string{"tenant": "acme:NoSchedule", "slow": ":NoSchedule"}), "tenant", directcsi.DriveTaintEffectNoSchedule, false},
+		{newDrive(map[string]string{"tenant": "acme:NoSchedule", "slow": ":NoSchedule"}), "tenant,slow", directcsi.DriveTaintEffectNoSchedule, true},
+	}
+
+	for i, testCase := range testCases {
+		if result := isTolerated(testCase.drive, mustParse(testCase.tolerations), testCase.effect); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestFilterPreferNoScheduleDrives(t *testing.T) {
+	drive1 := directcsi.DirectCSIDrive{ObjectMeta: metav1.ObjectMeta{Name: "drive-1"}}
+	drive2 := directcsi.DirectCSIDrive{
+		ObjectMeta: metav1.ObjectMeta{Name: "drive-2"},
+		Spec:       directcsi.DirectCSIDriveSpec{DriveTaint: map[string]string{"slow": ":PreferNoSchedule"}},
+	}
+
+	testCases := []struct {
+		drives         []directcsi.DirectCSIDrive
+		tolerations    []toleration
+		expectedResult []directcsi.DirectCSIDrive
+	}{
+		{[]directcsi.DirectCSIDrive{drive1, drive2}, nil, []directcsi.DirectCSIDrive{drive1}},
+		{[]directcsi.DirectCSIDrive{drive2}, nil, []directcsi.DirectCSIDrive{drive2}},
+		{[]directcsi.DirectCSIDrive{drive1, drive2}, []toleration{{key: "slow"}}, []directcsi.DirectCSIDrive{drive1, drive2}},
+	}
+
+	for i, testCase := range testCases {
+		if result := filterPreferNoScheduleDrives(testCase.drives, testCase.tolerations); !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
@@ -18,18 +18,35 @@ package controller
 
 import (
 	"context"
-	"crypto/rand"
-	"math/big"
+	"fmt"
 
 	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
 	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/iothrottle"
 	"github.com/minio/directpv/pkg/matcher"
+	"github.com/minio/directpv/pkg/utils"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const accessTierParameter = "direct-csi-min-io/access-tier"
+
+// isBlockVolumeRequest returns whether raw block access type is requested.
+func isBlockVolumeRequest(req *csi.CreateVolumeRequest) bool {
+	for _, vcap := range req.GetVolumeCapabilities() {
+		if vcap.GetBlock() != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func matchDrive(drive directcsi.DirectCSIDrive, req *csi.CreateVolumeRequest) bool {
 	// Match drive only in Ready or InUse state.
 	switch drive.Status.DriveStatus {
@@ -38,32 +55,73 @@ func matchDrive(drive directcsi.DirectCSIDrive, req *csi.CreateVolumeRequest) bo
 		return false
 	}
 
-	// Match drive if it has requested capacity.
-	if req.GetCapacityRange() != nil && drive.Status.FreeCapacity < req.GetCapacityRange().GetRequiredBytes() {
+	// Skip drive cordoned for draining.
+	if drive.IsCordoned() {
+		return false
+	}
+
+	// Skip drive failing S.M.A.R.T. health check.
+	if drive.IsUnhealthy() {
 		return false
 	}
 
-	// Match drive if requested filesystem matches.
-	if len(req.GetVolumeCapabilities()) > 0 && drive.Status.Filesystem != req.GetVolumeCapabilities()[0].GetMount().GetFsType() {
+	// Skip drive having NoSchedule taints not tolerated.
+	if !isTolerated(drive, getTolerations(req.GetParameters()), directcsi.DriveTaintEffectNoSchedule) {
 		return false
 	}
 
+	// Match drive if its user-defined labels match requested drive selector.
+	if !matchDriveLabelSelector(drive, req.GetParameters()) {
+		return false
+	}
+
+	// Match drive if it has requested capacity including overcommit if enabled.
+	if req.GetCapacityRange() != nil && getAllocatableCapacity(drive, getOvercommitRatio(drive, req.GetParameters())) < req.GetCapacityRange().GetRequiredBytes() {
+		return false
+	}
+
+	if isBlockVolumeRequest(req) {
+		// Match drive only if it has no volumes or snapshots as raw block volume uses the whole drive.
+		finalizers := drive.GetFinalizers()
+		if len(finalizers) != 1 || finalizers[0] != directcsi.DirectCSIDriveFinalizerDataProtection {
+			return false
+		}
+	} else {
+		// Skip drive dedicated to a raw block volume.
+		if drive.Status.BlockVolume != "" {
+			return false
+		}
+
+		// Match drive if requested filesystem matches.
+		if len(req.GetVolumeCapabilities()) > 0 && drive.Status.Filesystem != req.GetVolumeCapabilities()[0].GetMount().GetFsType() {
+			return false
+		}
+	}
+
 	// Match drive by access-tier if requested.
 	for key, value := range req.GetParameters() {
-		if key == "direct-csi-min-io/access-tier" && string(drive.Status.AccessTier) != value {
+		if key == accessTierParameter && string(drive.Status.AccessTier) != value {
 			return false
 		}
 	}
 
+	matchTopology := func(topology *csi.Topology) bool {
+		for key, value := range topology.GetSegments() {
+			if driveValue, found := drive.Status.Topology[key]; !found || value != driveValue {
+				return false
+			}
+		}
+		return true
+	}
+
+	// Match drive if any of the topologies matches.
 	matchTopologies := func(topologies []*csi.Topology) bool {
 		for _, topology := range topologies {
-			for key, value := range topology.GetSegments() {
-				if driveValue, found := drive.Status.Topology[key]; !found || value != driveValue {
-					return false
-				}
+			if matchTopology(topology) {
+				return true
 			}
 		}
-		return true
+		return false
 	}
 
 	// Match drive by preferred topologies if requested.
@@ -80,7 +138,58 @@ func matchDrive(drive directcsi.DirectCSIDrive, req *csi.CreateVolumeRequest) bo
 	return len(req.GetAccessibilityRequirements().GetPreferred()) == 0 && len(req.GetAccessibilityRequirements().GetRequisite()) == 0
 }
 
-func getFilteredDrives(ctx context.Context, req *csi.CreateVolumeRequest) (drives []directcsi.DirectCSIDrive, err error) {
+// matchCapacityDrive matches drive for capacity calculation by given topology, parameters and volume capabilities.
+func matchCapacityDrive(drive directcsi.DirectCSIDrive, req *csi.GetCapacityRequest) bool {
+	// Count drive only in Ready or InUse state.
+	switch drive.Status.DriveStatus {
+	case directcsi.DriveStatusReady, directcsi.DriveStatusInUse:
+	default:
+		return false
+	}
+
+	// Skip drive cordoned for draining.
+	if drive.IsCordoned() {
+		return false
+	}
+
+	// Skip drive failing S.M.A.R.T. health check.
+	if drive.IsUnhealthy() {
+		return false
+	}
+
+	// Skip drive having NoSchedule taints not tolerated.
+	if !isTolerated(drive, getTolerations(req.GetParameters()), directcsi.DriveTaintEffectNoSchedule) {
+		return false
+	}
+
+	// Match drive if its user-defined labels match requested drive selector.
+	if !matchDriveLabelSelector(drive, req.GetParameters()) {
+		return false
+	}
+
+	// Count drive if requested filesystem matches.
+	for _, vcap := range req.GetVolumeCapabilities() {
+		if fsType := vcap.GetMount().GetFsType(); fsType != "" && drive.Status.Filesystem != fsType {
+			return false
+		}
+	}
+
+	// Count drive by access-tier if requested.
+	if value, found := req.GetParameters()[accessTierParameter]; found && string(drive.Status.AccessTier) != value {
+		return false
+	}
+
+	// Count drive by accessible topology if requested.
+	for key, value := range req.GetAccessibleTopology().GetSegments() {
+		if driveValue, found := drive.Status.Topology[key]; !found || value != driveValue {
+			return false
+		}
+	}
+
+	return true
+}
+
+func getFilteredDrives(ctx context.Context, req *csi.CreateVolumeRequest, sourceDrive string) (drives []directcsi.DirectCSIDrive, err error) {
 	resultCh, err := client.ListDrives(ctx, nil, nil, nil, client.MaxThreadCount)
 	if err != nil {
 		return nil, err
@@ -95,6 +204,11 @@ func getFilteredDrives(ctx context.Context, req *csi.CreateVolumeRequest) (drive
 			return []directcsi.DirectCSIDrive{result.Drive}, nil
 		}
 
+		// Match only the drive of the content source if requested.
+		if sourceDrive != "" && result.Drive.Name != sourceDrive {
+			continue
+		}
+
 		if matchDrive(result.Drive, req) {
 			drives = append(drives, result.Drive)
 		}
@@ -103,13 +217,17 @@ func getFilteredDrives(ctx context.Context, req *csi.CreateVolumeRequest) (drive
 	return drives, nil
 }
 
-func selectDrive(ctx context.Context, req *csi.CreateVolumeRequest) (*directcsi.DirectCSIDrive, error) {
-	drives, err := getFilteredDrives(ctx, req)
+func selectDrive(ctx context.Context, req *csi.CreateVolumeRequest, sourceDrive string) (*directcsi.DirectCSIDrive, error) {
+	drives, err := getFilteredDrives(ctx, req, sourceDrive)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	if len(drives) == 0 {
+		if sourceDrive != "" {
+			return nil, status.Errorf(codes.ResourceExhausted, "drive %v of the content source does not satisfy the request", sourceDrive)
+		}
+
 		if len(req.GetAccessibilityRequirements().GetPreferred()) != 0 || len(req.GetAccessibilityRequirements().GetRequisite()) != 0 {
 			return nil, status.Error(codes.ResourceExhausted, "no drive found for requested topology")
 		}
@@ -121,26 +239,186 @@ func selectDrive(ctx context.Context, req *csi.CreateVolumeRequest) (*directcsi.
 		return nil, status.Error(codes.FailedPrecondition, "no drive found")
 	}
 
-	maxFreeCapacity := int64(-1)
-	var maxFreeCapacityDrives []directcsi.DirectCSIDrive
-	for _, drive := range drives {
-		switch {
-		case drive.Status.FreeCapacity == maxFreeCapacity:
-			maxFreeCapacityDrives = append(maxFreeCapacityDrives, drive)
-		case drive.Status.FreeCapacity > maxFreeCapacity:
-			maxFreeCapacity = drive.Status.FreeCapacity
-			maxFreeCapacityDrives = []directcsi.DirectCSIDrive{drive}
+	// Reuse the drive already reserved for this volume by a previous request.
+	if len(drives) == 1 && matcher.StringIn(drives[0].Finalizers, directcsi.DirectCSIDriveFinalizerPrefix+req.GetName()) {
+		return &drives[0], nil
+	}
+
+	if drives, err = filterAntiAffinityDrives(ctx, req, drives); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if len(drives) == 0 {
+		return nil, status.Error(codes.ResourceExhausted, "no drive found satisfying volume anti-affinity")
+	}
+
+	drives = filterPreferNoScheduleDrives(drives, getTolerations(req.GetParameters()))
+
+	selectDrives, err := getDriveSelector(ctx, req)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	drive, err := pickDrive(selectDrives(drives))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return drive, nil
+}
+
+func newCSISnapshot(snapshot *directcsi.DirectCSISnapshot) *csi.Snapshot {
+	return &csi.Snapshot{
+		SizeBytes:      snapshot.Status.Size,
+		SnapshotId:     snapshot.Name,
+		SourceVolumeId: snapshot.Status.SourceVolume,
+		CreationTime:   timestamppb.New(snapshot.CreationTimestamp.Time),
+		ReadyToUse:     snapshot.Status.ReadyToUse,
+	}
+}
+
+func newCSIVolume(volume *directcsi.DirectCSIVolume, drive *directcsi.DirectCSIDrive) *csi.Volume {
+	csiVolume := &csi.Volume{
+		VolumeId:      volume.Name,
+		CapacityBytes: volume.Status.TotalCapacity,
+	}
+	if drive != nil {
+		csiVolume.AccessibleTopology = []*csi.Topology{
+			{
+				Segments: drive.Status.Topology,
+			},
+		}
+	}
+	return csiVolume
+}
+
+// getVolumeCondition returns the condition of the volume by its Ready condition and the state of its drive.
+func getVolumeCondition(volume *directcsi.DirectCSIVolume, drive *directcsi.DirectCSIDrive) *csi.VolumeCondition {
+	abnormal := func(format string, args ...interface{}) *csi.VolumeCondition {
+		return &csi.VolumeCondition{Abnormal: true, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if drive == nil {
+		return abnormal("drive %v not found", volume.Status.Drive)
+	}
+
+	if drive.Status.DriveStatus == directcsi.DriveStatusUnavailable {
+		return abnormal("drive %v is unavailable", drive.Name)
+	}
+
+	// Drive dedicated to a raw block volume is not mounted.
+	if drive.Status.BlockVolume != volume.Name {
+		if drive.Status.Mountpoint == "" ||
+			utils.IsConditionStatus(drive.Status.Conditions, string(directcsi.DirectCSIDriveConditionMounted), metav1.ConditionFalse) {
+			return abnormal("drive %v is not mounted", drive.Name)
+		}
+	}
+
+	for _, condition := range volume.Status.Conditions {
+		if condition.Type != string(directcsi.DirectCSIVolumeConditionReady) || condition.Status == metav1.ConditionTrue {
+			continue
+		}
+
+		// Ready condition carries the reason when the volume is lost.
+		if condition.Message != "" {
+			return abnormal("volume is not ready; %v", condition.Message)
+		}
+
+		if utils.IsConditionStatus(volume.Status.Conditions, string(directcsi.DirectCSIVolumeConditionStaged), metav1.ConditionTrue) {
+			return abnormal("volume is staged but not ready")
+		}
+	}
+
+	return &csi.VolumeCondition{Abnormal: false, Message: "volume is healthy"}
+}
+
+// getContentSource returns the content source of the requested volume and the drive it must be created on.
+func (c *ControllerServer) getContentSource(ctx context.Context, req *csi.CreateVolumeRequest) (*directcsi.VolumeContentSource, string, error) {
+	source := req.GetVolumeContentSource()
+	switch {
+	case source == nil:
+		return nil, "", nil
+	case source.GetSnapshot() != nil:
+		return c.getSnapshotContentSource(ctx, req, source.GetSnapshot().GetSnapshotId())
+	case source.GetVolume() != nil:
+		return c.getVolumeContentSource(ctx, req, source.GetVolume().GetVolumeId())
+	default:
+		return nil, "", status.Error(codes.InvalidArgument, "unsupported volume content source")
+	}
+}
+
+func (c *ControllerServer) getSnapshotContentSource(ctx context.Context, req *csi.CreateVolumeRequest, snapshotID string) (*directcsi.VolumeContentSource, string, error) {
+	snapshot, err := c.directcsiClient.DirectV1beta3().DirectCSISnapshots().Get(
+		ctx, snapshotID, metav1.GetOptions{TypeMeta: utils.DirectCSISnapshotTypeMeta()},
+	)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, "", status.Errorf(codes.NotFound, "snapshot %v not found", snapshotID)
+		}
+		return nil, "", status.Errorf(codes.Internal, "could not retrieve snapshot %v; %v", snapshotID, err)
+	}
+
+	if !snapshot.Status.ReadyToUse {
+		return nil, "", status.Errorf(codes.Unavailable, "snapshot %v is not ready to use", snapshotID)
+	}
+
+	if req.GetCapacityRange() != nil && req.GetCapacityRange().GetRequiredBytes() < snapshot.Status.Size {
+		return nil, "", status.Errorf(codes.OutOfRange, "requested size %v is less than snapshot size %v", req.GetCapacityRange().GetRequiredBytes(), snapshot.Status.Size)
+	}
+
+	return &directcsi.VolumeContentSource{
+		Kind: directcsi.VolumeContentSourceKindSnapshot,
+		Name: snapshot.Name,
+	}, snapshot.Status.Drive, nil
+}
+
+func (c *ControllerServer) getVolumeContentSource(ctx context.Context, req *csi.CreateVolumeRequest, volumeID string) (*directcsi.VolumeContentSource, string, error) {
+	volume, err := c.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(
+		ctx, volumeID, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()},
+	)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, "", status.Errorf(codes.NotFound, "volume %v not found", volumeID)
 		}
+		return nil, "", status.Errorf(codes.Internal, "could not retrieve volume %v; %v", volumeID, err)
+	}
+
+	if volume.DeletionTimestamp != nil {
+		return nil, "", status.Errorf(codes.FailedPrecondition, "volume %v is being deleted", volumeID)
 	}
 
-	if len(maxFreeCapacityDrives) == 1 {
-		return &maxFreeCapacityDrives[0], nil
+	if req.GetCapacityRange() != nil && req.GetCapacityRange().GetRequiredBytes() < volume.Status.TotalCapacity {
+		return nil, "", status.Errorf(codes.OutOfRange, "requested size %v is less than volume size %v", req.GetCapacityRange().GetRequiredBytes(), volume.Status.TotalCapacity)
 	}
 
-	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(maxFreeCapacityDrives))))
+	return &directcsi.VolumeContentSource{
+		Kind: directcsi.VolumeContentSourceKindVolume,
+		Name: volume.Name,
+	}, volume.Status.Drive, nil
+}
+
+// getVolumeContext returns the parameters of the request with I/O limits overridden by the annotations of the PVC.
+func getVolumeContext(ctx context.Context, req *csi.CreateVolumeRequest) (map[string]string, error) {
+	pvcName, pvcNamespace := req.GetParameters()[pvcNameParameter], req.GetParameters()[pvcNamespaceParameter]
+	if pvcName == "" || pvcNamespace == "" {
+		return req.GetParameters(), nil
+	}
+
+	pvc, err := client.GetKubeClient().CoreV1().PersistentVolumeClaims(pvcNamespace).Get(ctx, pvcName, metav1.GetOptions{})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "random number generation failed; %v", err)
+		if errors.IsNotFound(err) {
+			return req.GetParameters(), nil
+		}
+		return nil, status.Errorf(codes.Internal, "could not retrieve PVC %v/%v; %v", pvcNamespace, pvcName, err)
 	}
 
-	return &maxFreeCapacityDrives[n.Int64()], nil
+	volumeContext := map[string]string{}
+	for key, value := range req.GetParameters() {
+		volumeContext[key] = value
+	}
+	for _, key := range iothrottle.Parameters {
+		if value, found := pvc.GetAnnotations()[key]; found {
+			volumeContext[key] = value
+		}
+	}
+	return volumeContext, nil
 }
@@ -25,7 +25,9 @@ import (
 	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
 	"github.com/minio/directpv/pkg/client"
 	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/iothrottle"
 	"github.com/minio/directpv/pkg/matcher"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -206,6 +208,76 @@ func TestGetFilteredDrives(t *testing.T) {
 		},
 	}
 
+	case10Result := []directcsi.DirectCSIDrive{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "drive-2"},
+			Status:     directcsi.DirectCSIDriveStatus{DriveStatus: directcsi.DriveStatusReady},
+		},
+	}
+	case10Objects := []runtime.Object{
+		&directcsi.DirectCSIDrive{
+			ObjectMeta: metav1.ObjectMeta{Name: "drive-1"},
+			Spec:       directcsi.DirectCSIDriveSpec{DriveTaint: map[string]string{directcsi.DriveTaintCordoned: ""}},
+			Status:     directcsi.DirectCSIDriveStatus{DriveStatus: directcsi.DriveStatusInUse},
+		},
+		&case10Result[0],
+	}
+	case10Request := &csi.CreateVolumeRequest{Name: "volume-1"}
+
+	case11Result := []directcsi.DirectCSIDrive{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "drive-2"},
+			Status: directcsi.DirectCSIDriveStatus{
+				DriveStatus: directcsi.DriveStatusReady,
+				Conditions: []metav1.Condition{
+					{Type: string(directcsi.DirectCSIDriveConditionHealthy), Status: metav1.ConditionTrue},
+				},
+			},
+		},
+	}
+	case11Objects := []runtime.Object{
+		&directcsi.DirectCSIDrive{
+			ObjectMeta: metav1.ObjectMeta{Name: "drive-1"},
+			Status: directcsi.DirectCSIDriveStatus{
+				DriveStatus: directcsi.DriveStatusReady,
+				Conditions: []metav1.Condition{
+					{Type: string(directcsi.DirectCSIDriveConditionHealthy), Status: metav1.ConditionFalse},
+				},
+			},
+		},
+		&case11Result[0],
+	}
+	case11Request := &csi.CreateVolumeRequest{Name: "volume-1"}
+
+	case12Result := []directcsi.DirectCSIDrive{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "drive-1"},
+			Status: directcsi.DirectCSIDriveStatus{
+				DriveStatus: directcsi.DriveStatusReady,
+				Topology:    map[string]string{"node": "N1", "direct.csi.min.io/enclosure": "E1"},
+			},
+		},
+	}
+	case12Objects := []runtime.Object{
+		&case12Result[0],
+		&directcsi.DirectCSIDrive{
+			ObjectMeta: metav1.ObjectMeta{Name: "drive-2"},
+			Status: directcsi.DirectCSIDriveStatus{
+				DriveStatus: directcsi.DriveStatusReady,
+				Topology:    map[string]string{"node": "N1", "direct.csi.min.io/enclosure": "E3"},
+			},
+		},
+	}
+	case12Request := &csi.CreateVolumeRequest{
+		Name: "volume-1",
+		AccessibilityRequirements: &csi.TopologyRequirement{
+			Requisite: []*csi.Topology{
+				{Segments: map[string]string{"direct.csi.min.io/enclosure": "E1"}},
+				{Segments: map[string]string{"direct.csi.min.io/enclosure": "E2"}},
+			},
+		},
+	}
+
 	testCases := []struct {
 		objects        []runtime.Object
 		request        *csi.CreateVolumeRequest
@@ -220,11 +292,14 @@ func TestGetFilteredDrives(t *testing.T) {
 		{case7Objects, case7Request, case7Result},
 		{case8Objects, case8Request, case8Result},
 		{case9Objects, case9Request, nil},
+		{case10Objects, case10Request, case10Result},
+		{case11Objects, case11Request, case11Result},
+		{case12Objects, case12Request, case12Result},
 	}
 
 	for i, testCase := range testCases {
 		client.SetLatestDirectCSIDriveInterface(clientsetfake.NewSimpleClientset(testCase.objects...).DirectV1beta3().DirectCSIDrives())
-		result, err := getFilteredDrives(context.TODO(), testCase.request)
+		result, err := getFilteredDrives(context.TODO(), testCase.request, "")
 		if err != nil {
 			t.Fatalf("case %v: unexpected error: %v", i+1, err)
 		}
@@ -257,7 +332,7 @@ func TestGetDrive(t *testing.T) {
 
 	for i, testCase := range testCases {
 		client.SetLatestDirectCSIDriveInterface(clientsetfake.NewSimpleClientset(testCase.objects...).DirectV1beta3().DirectCSIDrives())
-		result, err := selectDrive(context.TODO(), testCase.request)
+		result, err := selectDrive(context.TODO(), testCase.request, "")
 
 		if testCase.expectErr {
 			if err == nil {
@@ -288,7 +363,7 @@ func TestGetDrive(t *testing.T) {
 	request := &csi.CreateVolumeRequest{Name: "volume-1", CapacityRange: &csi.CapacityRange{RequiredBytes: 2 * GiB}}
 
 	client.SetLatestDirectCSIDriveInterface(clientsetfake.NewSimpleClientset(objects...).DirectV1beta3().DirectCSIDrives())
-	result, err := selectDrive(context.TODO(), request)
+	result, err := selectDrive(context.TODO(), request, "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -296,3 +371,49 @@ func TestGetDrive(t *testing.T) {
 		t.Fatalf("result: expected: %v, got: %v", []string{"drive-2", "drive-3"}, result.Name)
 	}
 }
+
+func TestGetVolumeContext(t *testing.T) {
+	client.FakeInit()
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pvc-1",
+			Namespace: "default",
+			Annotations: map[string]string{
+				iothrottle.ReadBPSParameter:     "20Mi",
+				"direct-csi-min-io/access-tier": "cold",
+			},
+		},
+	}
+	if _, err := client.GetKubeClient().CoreV1().PersistentVolumeClaims("default").Create(context.TODO(), pvc, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		parameters     map[string]string
+		expectedResult map[string]string
+	}{
+		{nil, nil},
+		{
+			map[string]string{iothrottle.ReadBPSParameter: "10Mi"},
+			map[string]string{iothrottle.ReadBPSParameter: "10Mi"},
+		},
+		{
+			map[string]string{pvcNameParameter: "pvc-1", pvcNamespaceParameter: "default", iothrottle.ReadBPSParameter: "10Mi", accessTierParameter: "hot"},
+			map[string]string{pvcNameParameter: "pvc-1", pvcNamespaceParameter: "default", iothrottle.ReadBPSParameter: "20Mi", accessTierParameter: "hot"},
+		},
+		{
+			map[string]string{pvcNameParameter: "pvc-2", pvcNamespaceParameter: "default", iothrottle.WriteIOPSParameter: "100"},
+			map[string]string{pvcNameParameter: "pvc-2", pvcNamespaceParameter: "default", iothrottle.WriteIOPSParameter: "100"},
+		},
+	}
+
+	for i, testCase := range testCases {
+		result, err := getVolumeContext(context.TODO(), &csi.CreateVolumeRequest{Parameters: testCase.parameters})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: result: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
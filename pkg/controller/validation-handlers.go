@@ -29,9 +29,10 @@ import (
 )
 
 const (
-	failureStatus = "Failure"
-	rootPath      = "/"
-	xfsFileSystem = "xfs"
+	failureStatus  = "Failure"
+	rootPath       = "/"
+	xfsFileSystem  = "xfs"
+	ext4FileSystem = "ext4"
 )
 
 type validationHandler struct {
@@ -140,14 +141,14 @@ func validateRequestedFormat(directCSIDrive directcsi.DirectCSIDrive, admissionR
 	}
 
 	// Filesystem validation
-	// (*) Allow only "xfs" formatting
+	// (*) Allow only "xfs" or "ext4" formatting
 	// (*) Check if `force` flag is set for formatting
 	validateFS := func() bool {
 		requestedFilesystem := requestedFormat.Filesystem
 		switch requestedFilesystem {
 		case "":
 			return true
-		case xfsFileSystem:
+		case xfsFileSystem, ext4FileSystem:
 			if !requestedFormat.Force {
 				admissionReview.Response.Allowed = false
 				admissionReview.Response.Result = &metav1.Status{
@@ -161,7 +162,7 @@ func validateRequestedFormat(directCSIDrive directcsi.DirectCSIDrive, admissionR
 			admissionReview.Response.Allowed = false
 			admissionReview.Response.Result = &metav1.Status{
 				Status:  failureStatus,
-				Message: "DirectCSI supports only xfs filesystem format",
+				Message: "DirectCSI supports only xfs or ext4 filesystem format",
 			}
 			return false
 		}
@@ -171,7 +172,7 @@ func validateRequestedFormat(directCSIDrive directcsi.DirectCSIDrive, admissionR
 }
 
 /* Validates the following admission rules
-   - Check if the fstype in the requestedFormat == "xfs"
+   - Check if the fstype in the requestedFormat is "xfs" or "ext4"
    - Check if directCSIOwned is not set to True or requestedFormat is set for root partitions (unavailable drives)
    - Check if requestedFormat is not set for a drive in-use
    - Check if force option is set if the drive has an existing filesystem or mountpoint
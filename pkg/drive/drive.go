@@ -25,6 +25,7 @@ import (
 	"github.com/google/uuid"
 	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
 	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/fs/ext4"
 	"github.com/minio/directpv/pkg/fs/xfs"
 	"github.com/minio/directpv/pkg/listener"
 	"github.com/minio/directpv/pkg/sys"
@@ -44,14 +45,25 @@ func getDevice(major, minor uint32) (string, error) {
 	return "/dev/" + name, nil
 }
 
+func makeFS(ctx context.Context, device, uuid, fsType string, force, reflink bool) error {
+	switch fsType {
+	case "", "xfs":
+		return xfs.MakeFS(ctx, device, uuid, force, reflink)
+	case "ext4":
+		return ext4.MakeFS(ctx, device, uuid, force)
+	default:
+		return fmt.Errorf("unsupported filesystem type %v", fsType)
+	}
+}
+
 type driveEventHandler struct {
 	nodeID          string
 	reflinkSupport  bool
 	getDevice       func(major, minor uint32) (string, error)
 	stat            func(name string) (os.FileInfo, error)
-	mountDevice     func(fsUUID, target string, flags []string) error
+	mountDevice     func(device, target, fsType string, flags []string) error
 	unmountDevice   func(device string) error
-	makeFS          func(ctx context.Context, device, uuid string, force, reflink bool) error
+	makeFS          func(ctx context.Context, device, uuid, fsType string, force, reflink bool) error
 	getFreeCapacity func(path string) (uint64, error)
 }
 
@@ -61,9 +73,9 @@ func newDriveEventHandler(nodeID string, reflinkSupport bool) *driveEventHandler
 		reflinkSupport:  reflinkSupport,
 		getDevice:       getDevice,
 		stat:            os.Stat,
-		mountDevice:     sys.MountXFSDevice,
+		mountDevice:     sys.MountDevice,
 		unmountDevice:   sys.UnmountDevice,
-		makeFS:          xfs.MakeFS,
+		makeFS:          makeFS,
 		getFreeCapacity: getFreeCapacity,
 	}
 }
@@ -171,6 +183,10 @@ func (handler *driveEventHandler) format(ctx context.Context, drive *directcsi.D
 	target := filepath.Join(sys.MountRoot, drive.Status.FilesystemUUID)
 	mountOpts := drive.Spec.RequestedFormat.MountOptions
 	force := drive.Spec.RequestedFormat.Force
+	fsType := drive.Spec.RequestedFormat.Filesystem
+	if fsType == "" {
+		fsType = "xfs"
+	}
 	mounted := drive.Status.Mountpoint != ""
 	formatted := drive.Status.Filesystem != ""
 
@@ -185,10 +201,10 @@ func (handler *driveEventHandler) format(ctx context.Context, drive *directcsi.D
 		}
 
 		if err == nil {
-			if err = handler.makeFS(ctx, drive.Status.Path, drive.Status.FilesystemUUID, force, handler.reflinkSupport); err != nil {
+			if err = handler.makeFS(ctx, drive.Status.Path, drive.Status.FilesystemUUID, fsType, force, handler.reflinkSupport); err != nil {
 				klog.Errorf("failed to format drive %s; %w", drive.Name, err)
 			} else {
-				drive.Status.Filesystem = "xfs"
+				drive.Status.Filesystem = fsType
 				drive.Status.AllocatedCapacity = 0
 				formatted = true
 			}
@@ -196,7 +212,7 @@ func (handler *driveEventHandler) format(ctx context.Context, drive *directcsi.D
 	}
 
 	if err == nil && formatted && !mounted {
-		if err = handler.mountDevice(device, target, mountOpts); err != nil {
+		if err = handler.mountDevice(device, target, drive.Status.Filesystem, mountOpts); err != nil {
 			klog.Error("failed to mount drive %s; %w", drive.Name, err)
 		} else {
 			drive.Status.Mountpoint = target
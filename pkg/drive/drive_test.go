@@ -42,9 +42,9 @@ func createFakeDriveEventListener() *driveEventHandler {
 		nodeID:          testNodeID,
 		getDevice:       func(major, minor uint32) (string, error) { return "", nil },
 		stat:            func(name string) (os.FileInfo, error) { return nil, nil },
-		mountDevice:     func(device, target string, flags []string) error { return nil },
+		mountDevice:     func(device, target, fsType string, flags []string) error { return nil },
 		unmountDevice:   func(device string) error { return nil },
-		makeFS:          func(ctx context.Context, device, uuid string, force, reflink bool) error { return nil },
+		makeFS:          func(ctx context.Context, device, uuid, fsType string, force, reflink bool) error { return nil },
 		getFreeCapacity: func(path string) (uint64, error) { return 0, nil },
 	}
 }
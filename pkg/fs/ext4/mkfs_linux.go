@@ -0,0 +1,43 @@
+//go:build linux
+
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ext4
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+func makeFS(ctx context.Context, device, uuid string, force bool) error {
+	// Project quota feature is required to enforce volume capacity.
+	args := []string{"-O", "quota,project", "-E", "quotatype=prjquota", "-m", "0", "-U", uuid}
+	if force {
+		args = append(args, "-F")
+	}
+	args = append(args, "-L", "DIRECTCSI", device)
+
+	if output, err := exec.CommandContext(ctx, "mkfs.ext4", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf(
+			"unable to execute command %v; output=%v; error=%w",
+			append([]string{"mkfs.ext4"}, args...), string(output), err,
+		)
+	}
+
+	return nil
+}
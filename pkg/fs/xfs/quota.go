@@ -26,6 +26,10 @@ import (
 )
 
 // Quota denotes XFS quota information.
+//
+// The XFS quota interface of quotactl(2) is served by the generic quota layer
+// of the kernel, hence project quota of ext4 filesystem mounted with prjquota
+// is also managed by the functions of this package.
 type Quota struct {
 	HardLimit    uint64
 	SoftLimit    uint64
@@ -191,15 +191,21 @@ func setProjectQuota(device string, projectID uint32, quota Quota) error {
 }
 
 func setQuota(device, path, volumeID string, quota Quota) error {
-	if info, err := getQuota(device, volumeID); err == nil {
-		klog.V(3).InfoS("Quota is already set", "Device", device, "Path", path, "VolumeID", volumeID, "ProjectID", "HardLimitSet", info.HardLimit, "HardLimit", info.HardLimit)
-		return nil
-	}
-
 	projectID := getProjectIDHash(volumeID)
-	if err := setProjectID(path, projectID); err != nil {
-		klog.ErrorS(err, "unable to set project ID", "Device", device, "Path", path)
-		return err
+
+	info, err := getQuota(device, volumeID)
+	switch {
+	case err == nil && info.HardLimit == quota.HardLimit:
+		klog.V(3).InfoS("Quota is already set", "Device", device, "Path", path, "VolumeID", volumeID, "ProjectID", projectID, "HardLimit", info.HardLimit)
+		return nil
+	case err == nil:
+		// Project ID is already set on the path; only the limits need to be updated.
+		klog.V(3).InfoS("Updating quota", "Device", device, "Path", path, "VolumeID", volumeID, "ProjectID", projectID, "HardLimitSet", info.HardLimit, "HardLimit", quota.HardLimit)
+	default:
+		if err := setProjectID(path, projectID); err != nil {
+			klog.ErrorS(err, "unable to set project ID", "Device", device, "Path", path)
+			return err
+		}
 	}
 
 	if err := setProjectQuota(device, projectID, quota); err != nil {
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/minio/directpv/pkg/utils"
 
@@ -37,6 +38,12 @@ const (
 
 	// quay.io/minio/livenessprobe:v2.2.0-go1.17
 	CSIImageLivenessProbe = "livenessprobe@sha256:928a80be4d363e0e438ff28dcdb00d8d674d3059c6149a8cda64ce6016a9a3f8"
+
+	// quay.io/minio/csi-resizer:v1.3.0
+	CSIImageCSIResizer = "csi-resizer:v1.3.0"
+
+	// quay.io/minio/csi-snapshotter:v4.2.1
+	CSIImageCSISnapshotter = "csi-snapshotter:v4.2.1"
 )
 
 func defaultIfZeroString(left, right string) string {
@@ -58,6 +65,8 @@ type Config struct {
 	CSIProvisionerImage      string
 	NodeDriverRegistrarImage string
 	LivenessProbeImage       string
+	CSIResizerImage          string
+	CSISnapshotterImage      string
 
 	// Admission controller
 	AdmissionControl bool
@@ -75,6 +84,18 @@ type Config struct {
 
 	DynamicDriveDiscovery bool
 
+	// AutoAccessTier sets access-tier of new drives by media type.
+	AutoAccessTier bool
+
+	// HealthCheckInterval enables S.M.A.R.T. health check of drives at this interval.
+	HealthCheckInterval time.Duration
+
+	// RackLabel is the node label key to read rack of the node from.
+	RackLabel string
+
+	// TopologyKeys are node label keys to read custom topology from.
+	TopologyKeys []string
+
 	// dry-run properties
 	DryRun bool
 
@@ -130,6 +151,14 @@ func (i *Config) getLivenessProbeImage() string {
 	return defaultIfZeroString(i.LivenessProbeImage, CSIImageLivenessProbe)
 }
 
+func (i *Config) getCSIResizerImage() string {
+	return defaultIfZeroString(i.CSIResizerImage, CSIImageCSIResizer)
+}
+
+func (i *Config) getCSISnapshotterImage() string {
+	return defaultIfZeroString(i.CSISnapshotterImage, CSIImageCSISnapshotter)
+}
+
 func (i *Config) conversionWebhookDNSName() string {
 	return strings.Join([]string{i.identity(), i.namespace(), "svc"}, ".") // "direct-csi-min-io.direct-csi-min-io.svc"
 }
@@ -37,8 +37,9 @@ const (
 	conversionCACert  = "conversioncacert"
 
 	// crd
-	driveCRDName  = "directcsidrives.direct.csi.min.io"
-	volumeCRDName = "directcsivolumes.direct.csi.min.io"
+	driveCRDName    = "directcsidrives.direct.csi.min.io"
+	volumeCRDName   = "directcsivolumes.direct.csi.min.io"
+	snapshotCRDName = "directcsisnapshots.direct.csi.min.io"
 
 	// Daemonset
 	volumeNameMountpointDir          = "mountpoint-dir"
@@ -64,6 +65,8 @@ const (
 	admissionControllerCertsDir    = "admission-webhook-certs"
 	admissionCertsDir              = "/etc/admission/certs"
 	csiProvisionerContainerName    = "csi-provisioner"
+	csiResizerContainerName        = "csi-resizer"
+	csiSnapshotterContainerName    = "csi-snapshotter"
 	admissionWehookDNSName         = "directcsi-validation-controller.direct-csi-min-io.svc"
 
 	// validation rules
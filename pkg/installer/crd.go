@@ -77,6 +77,41 @@ func removeVolumes(ctx context.Context, directCSIClient clientset.DirectV1beta3I
 	return err
 }
 
+func removeSnapshots(ctx context.Context, directCSIClient clientset.DirectV1beta3Interface, c *Config) error {
+	snapshotList, err := directCSIClient.DirectCSISnapshots().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	for i := range snapshotList.Items {
+		snapshot := &snapshotList.Items[i]
+		if !c.ForceRemove {
+			klog.Errorf("Cannot unregister DirectCSISnapshot CRDs. Please use `%s` to delete the resources", utils.Bold("--force"))
+			return errForceRequired
+		}
+
+		if c.DryRun {
+			continue
+		}
+
+		snapshot.SetFinalizers([]string{})
+		if _, err := directCSIClient.DirectCSISnapshots().Update(ctx, snapshot, metav1.UpdateOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if err := directCSIClient.DirectCSISnapshots().Delete(ctx, snapshot.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func removeDrives(ctx context.Context, directCSIClient clientset.DirectV1beta3Interface, c *Config) error {
 	ctx, cancelFunc := context.WithCancel(ctx)
 
@@ -143,6 +178,9 @@ func uninstallCRDDefault(ctx context.Context, c *Config) error {
 		return nil
 	}
 	directCSIClient := client.GetDirectCSIClient()
+	if err := removeSnapshots(ctx, directCSIClient, c); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
 	if err := removeVolumes(ctx, directCSIClient, c); err != nil && !apierrors.IsNotFound(err) {
 		return err
 	}
@@ -41,7 +41,7 @@ func bindata_read(data []byte, name string) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-var _config_crd_direct_csi_min_io_directcsidrives_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5a\x6d\x73\xdb\xc6\xf1\x7f\xaf\x4f\xb1\xc3\xff\x7f\xc6\x92\x4b\x40\x96\xdd\x71\x13\xce\x78\x3c\xae\x5c\x75\x3c\x89\x63\x8f\xa5\xe4\x45\x45\xb5\x59\x02\x4b\xf2\x22\xe0\x0e\xb9\x3b\x50\x62\x3a\xfd\xee\x9d\xbd\x03\x48\x90\x04\x20\x52\xad\x93\x34\xb9\x7b\x45\xde\xc3\xde\xee\xde\x3e\xdd\xfd\x70\x14\x45\xd1\x11\x16\xe2\x3b\xd2\x46\x28\x39\x02\x2c\x04\xdd\x5b\x92\xfc\xcf\xc4\xb7\x5f\x98\x58\xa8\xd3\xc5\xd9\xd1\xad\x90\xe9\x08\xce\x4b\x63\x55\xfe\x89\x8c\x2a\x75\x42\x6f\x69\x2a\xa4\xb0\x42\xc9\xa3\x9c\x2c\xa6\x68\x71\x74\x04\x80\x52\x2a\x8b\xdc\x6d\xf8\x2f\x40\xa2\xa4\xd5\x2a\xcb\x48\x47\x33\x92\xf1\x6d\x39\xa1\x49\x29\xb2\x94\xb4\x23\x5e\x6f\xbd\x78\x16\xbf\x8c\xcf\x8e\x00\x12\x4d\x6e\xf9\x95\xc8\xc9\x58\xcc\x8b\x11\xc8\x32\xcb\x8e\x00\x24\xe6\x34\x82\x54\x68\x4a\x6c\x62\x44\xaa\xc5\x82\x4c\xec\xff\xc7\x89\x11\x71\x2e\x64\x2c\xd4\x91\x29\x28\xe1\xbd\x67\x5a\x95\x45\xbd\xa0\x39\xc1\x93\xaa\xf8\xf3\xb2\xbd\x75\x93\xce\x2f\xdf\xbd\x65\xaa\x6e\x20\x13\xc6\x7e\xd5\x32\xf8\xb5\x30\xd6\x4d\x28\xb2\x52\x63\xb6\xc3\x91\x1b\x33\x42\xce\xca\x0c\xf5\xf6\xe8\x11\x80\x49\x54\x41\x23\x38\xcf\x4a\x63\x49\x1f\x01\x54\x3a\x70\xfc\x44\x95\x94\x8b\x33\xcc\x8a\x39\x9e\x79\x62\xc9\x9c\x72\xf4\xec\x02\xa8\x82\xe4\x9b\x8f\xef\xbe\x7b\x71\xb9\xd1\x0d\x90\x92\x49\xb4\x28\xac\xd3\xe7\x26\xcf\x90\x92\x54\x96\x0c\x38\x26\xe0\xfc\xd3\x5b\x50\x93\x1f\x58\x2d\xab\xd5\x85\x56\x05\x69\x2b\x6a\xbd\xf8\xd6\xb0\x8e\x46\xef\xd6\x5e\x4f\x98\x1d\x3f\x0b\x52\x36\x0b\x32\x60\xe7\x54\x0b\x46\x69\x25\x01\xa8\x29\xd8\xb9\x30\xa0\xa9\xd0\x64\x48\x7a\x43\xd9\x20\x0c\x3c\x09\x65\xcd\x1e\x5c\x92\x66\x32\x60\xe6\xaa\xcc\x52\xb6\xa6\x05\x69\x0b\x9a\x12\x35\x93\xe2\xa7\x15\x6d\x03\x56\xb9\x4d\x33\xb4\x54\x1d\xd0\xba\x09\x69\x49\x4b\xcc\x60\x81\x59\x49\x43\x40\x99\x42\x8e\x4b\xd0\xc4\xbb\x40\x29\x1b\xf4\xdc\x14\x13\xc3\x7b\xa5\x09\x84\x9c\xaa\x11\xcc\xad\x2d\xcc\xe8\xf4\x74\x26\x6c\xed\x15\x89\xca\xf3\x52\x0a\xbb\x3c\x75\x06\x2e\x26\xa5\x55\xda\x9c\xa6\xb4\xa0\xec\xd4\x88\x59\x84\x3a\x99\x0b\x4b\x89\x2d\x35\x9d\x62\x21\x22\xc7\xba\x74\x9e\x11\xe7\xe9\xff\xe9\xca\x8f\xcc\x93\x0d\x5e\xed\x92\x8d\xc3\x58\x2d\xe4\xac\x31\xe0\xac\xb4\xe7\x04\xd8\x50\x41\x18\xc0\x6a\xa9\x97\x62\xad\x68\xee\x62\xed\x7c\xfa\xcb\xe5\x15\xd4\x5b\xbb\xc3\xd8\xd6\xbe\xd3\xfb\x7a\xa1\x59\x1f\x01\x2b\x4c\xc8\x29\x69\x7f\x88\x53\xad\x72\x47\x93\x64\x5a\x28\x21\xad\xfb\x93\x64\x82\xe4\xb6\xfa\x4d\x39\xc9\x85\xe5\x73\xff\xb1\x24\x63\xf9\xac\x62\x38\x77\xa1\x02\x26\x04\x65\x91\xa2\xa5\x34\x86\x77\x12\xce\x31\xa7\xec\x1c\x0d\x7d\xf6\x03\x60\x4d\x9b\x88\x15\xbb\xdf\x11\x34\xa3\xdc\xf6\x64\xaf\xb5\xc6\x40\x1d\x83\x3a\xce\x6b\xd3\x3b\x2f\x0b\x4a\xb6\x3c\x94\xd7\x8b\xa9\x48\x9c\x83\xc4\x1b\x84\xda\x1d\xd5\x6d\x51\x53\xfd\x70\x27\x29\xdd\x1e\xdd\x62\x81\xcf\x42\x68\x4a\x77\x66\x79\x89\x26\x4a\x65\x84\xdb\xbe\xe9\x98\xbb\x42\x21\xed\x2e\x75\x4c\x53\x97\x0e\x30\xfb\xd8\xc9\x61\x8f\x7a\x7b\xd5\xc9\xad\x32\x1e\x4a\x2f\x94\xce\xb1\x85\x81\x0d\xf1\x3e\x6d\xce\xde\x52\xef\xd4\x77\x56\x24\x9d\x91\x71\xc7\x8e\xae\xfb\xf5\xcd\x6d\x2a\x32\x32\x4b\x63\x29\x6f\x1b\x7d\x40\x5a\x60\x46\x12\xea\x5b\xd9\x7e\x0e\xdc\x72\x55\x4a\xfb\xa1\x68\xa4\xda\xed\x26\x2c\xe5\x1d\x43\x0f\x32\x56\x4f\x40\xad\x71\xd9\x3a\x7e\x1f\x71\x2e\xd7\x92\x2c\x99\x88\x93\x65\x54\xad\xb0\x2a\x17\x49\x17\xc3\x2e\x52\x3c\x4a\x55\x45\xa9\x67\x8f\x52\x55\xa7\x4d\xd5\x2e\xb0\x49\x34\xda\xf2\xa3\xbd\xdc\xdd\xa2\x2d\xcd\xfe\x0e\xef\xa6\x6f\xd9\x64\xa7\x11\x76\x1b\x20\x66\x99\x4a\x38\x74\x9e\x63\x81\x89\xb0\xcb\x5d\xf5\x78\x9a\x23\xce\x80\x2f\xff\xd8\xa1\x1a\xce\x8e\x33\x57\x8a\x34\x5b\xa2\xa4\x77\xe8\x16\x13\xea\xb4\xac\x0d\xa1\x07\xe7\x35\x09\x57\x05\xa2\x90\x2c\xb3\x45\x91\x19\xe6\x0b\x94\x24\x40\x8e\x74\xd6\x57\x06\x04\x49\xa9\xf5\x6e\xfa\x58\xeb\x98\x56\x25\xc4\x9b\x8f\xef\xa0\x2e\x45\x63\x88\xa2\x08\xae\xb8\xdb\x58\x5d\x26\x96\x33\x21\x0b\x25\x53\x4a\xdd\x4e\xfe\x44\x5b\xc9\x96\x86\x99\xe0\x92\xc3\x99\x3a\xa0\xcf\x63\x53\x41\x59\x0a\x05\xda\x39\xc4\xfe\x74\xe3\xb5\x42\x62\x80\x0b\xa5\x81\xee\x31\x2f\x32\x1a\x76\xda\x24\x5c\x28\x55\x9d\xb5\x67\xec\x9f\x6e\xe8\xf4\x14\x3e\xad\xf2\xab\xdb\x4d\x4d\x0c\xe9\x85\x2f\x9b\x5d\x01\xd4\x4a\x72\xaa\xd4\x13\x53\xeb\xc8\xeb\x23\xae\x09\x7e\x25\xd5\x9d\x6c\x63\xd5\xf1\x81\xba\xc3\x73\xc6\x83\x37\x0b\x14\x19\x4e\x32\x1a\x0f\x86\x30\x1e\x7c\xd4\x6a\xa6\xc9\x70\xfd\xca\x1d\x5c\x28\x8d\x07\x6f\x69\xa6\x31\xa5\x74\x3c\xa8\xb7\xfb\x43\x81\x36\x99\xbf\x27\x3d\xa3\xaf\x68\xf9\x8a\x37\x69\xa7\xbf\x31\xff\xd2\x6a\xb4\x34\x5b\xbe\xca\x79\xe1\x8a\x16\x07\x8f\xab\x65\x41\xaf\x72\x2c\x36\x3a\xdf\x63\xf1\x30\xf5\x95\x91\x19\xb8\xbe\xe1\x24\xbd\x38\x8b\xd7\x86\xf7\xfd\x0f\x46\xc9\xd1\x78\xb0\xd6\xc8\x50\xe5\x6c\xbe\x85\x5d\x8e\x07\xad\x54\x37\x58\x1d\x8d\x07\x8e\xd9\xf1\x00\x36\x44\x1e\x8d\x07\xcc\x16\x77\x6b\x65\xd5\xa4\x9c\x8e\xc6\x83\xc9\xd2\x92\x19\x9e\x0d\x35\x15\x43\xae\xe3\x5f\xad\x77\x1d\x0f\xbe\x6f\x17\x41\xd6\x12\x2b\x3b\x27\xed\xed\xce\xc0\xbf\xda\x58\xeb\xcf\x44\x00\x19\x1a\x7b\xa5\x51\x1a\x51\x5f\xa0\xba\x82\xff\x86\x9b\xee\x2e\x63\xff\xf1\xb5\xb4\xb1\x60\xb9\xc3\x39\x67\x2d\x4c\x07\x51\x00\xbb\xa2\xc2\x7e\xc7\xf5\x21\xbb\xb8\xb7\x49\xae\xcf\x51\x3a\x21\xe3\xca\x57\x7d\x49\x3f\x21\xb8\x9b\x53\x0f\xd1\x39\x41\x29\x53\xd2\xd9\x92\xab\xd8\x64\x1d\x53\xe6\x28\x67\x5c\x36\xc2\x3b\x0e\x0a\xe8\xdc\x9e\x4b\xca\x5b\xf6\x85\x21\x2f\xec\xa6\x5a\x9a\xba\x24\x76\xf2\x31\x07\xee\x1f\xc7\x15\xef\xfb\x15\x79\x57\x55\x27\x09\x15\x96\x9d\x64\xb7\x3c\xf0\xad\x0e\xb3\x5c\xc8\x46\x4c\xf1\xb1\x59\x37\x27\x63\xb0\x2b\xcf\x6d\x1d\x5c\x35\xd7\xd7\xfd\xf3\x32\x47\x09\x9a\x30\x65\x3e\xd7\x63\x32\x75\x55\x64\xc7\x76\x9e\xa6\x0f\xc9\x38\x51\xa5\x0f\x7e\xeb\x73\xac\x8e\x8a\x4b\xff\x09\x71\x90\x74\x8e\x53\x09\xd0\xa5\x8c\x1c\xef\xbf\x26\x39\xb3\xf3\x11\xbc\x78\xfe\xa7\x97\x5f\x3c\x56\x17\x3e\x2a\x52\xfa\x57\x92\xa4\x5d\x70\xdc\x4b\x2d\xbb\xcb\x1a\xd7\x19\x27\x5f\x5c\xd7\xf2\xf1\x6c\x35\xa7\xc7\xfe\xaa\x94\xb0\xb6\xbc\x3b\x34\x60\xc8\xc2\x04\x0d\xa5\x50\x16\xac\x27\x4e\x08\x42\x1a\x8b\x32\xa1\x21\x88\xe9\x61\x9b\x88\x55\x5c\xcf\x96\x70\xf6\x7c\x08\x93\xea\x28\x76\x23\xfa\xf5\xfd\x4d\xbc\x2b\x62\x1f\xe5\x2f\x87\x5b\xfc\x0b\x03\x7c\xd4\x6a\xea\xec\x15\xee\x84\x9d\xf3\xa5\xd0\x65\xe2\xea\x1a\xdd\x97\x89\x61\x33\x1b\xd3\x4a\xee\x87\xbc\xa3\xbd\x08\xf1\x2d\x17\x52\xe4\x65\x3e\x82\x67\xbd\xe6\xd2\x5e\xab\xf8\xa6\x09\xcd\x9e\x36\xe2\xa7\xae\xcb\x12\xe4\xe0\x3a\xd3\x98\x73\x01\x96\x80\x48\xf9\xa2\x38\x15\xa4\xf7\x71\x20\x56\x41\x45\x90\x8b\x8d\x0d\x5d\x3f\x31\x55\x14\x6d\xb8\xd4\x47\xad\xd2\x32\x21\xbd\x7d\xf7\x5e\x37\x35\x5d\xdd\x00\x1b\xc7\xe6\x6e\xac\xce\x17\xfd\x2b\x0b\xd0\x3d\x1f\xd9\xea\xcd\x82\xb3\x75\x27\xc9\x9c\x50\x0a\x39\x33\x15\x8b\x7c\x81\xe7\x30\xe7\x53\xfc\xdd\x9c\x5c\xf6\x71\xaf\x36\x15\x2d\xed\xa4\x30\x22\xa5\xb6\x5b\x62\xdd\x10\x66\x25\x6a\x94\x96\x28\xe5\xe0\xc9\x01\xa3\xa2\xd1\x08\xf0\xb8\xbe\xd7\x3f\x10\x3b\xc0\x07\x1c\x1f\x82\x59\xd4\xea\x8d\xc0\xc5\x9d\x3d\x02\xce\xd9\xb3\xe7\x3d\x16\xb6\x9a\xd5\x31\xa5\x40\x6b\x49\xcb\x11\xfc\xfd\xfa\x4d\xf4\x37\x8c\x7e\xba\x39\xae\x7e\x3c\x8b\xbe\xfc\xc7\x70\x74\xf3\xb4\xf1\xf7\xe6\xe4\xf5\xff\x3f\x36\xb4\xb5\x5d\x18\xd6\x6d\xc3\x54\xab\xf4\x59\x57\xc8\xb5\x35\x0c\x5d\x6e\x55\x53\xb8\xd2\x25\x0d\xe1\x02\x33\x43\x43\xf8\x56\xba\xe4\xd7\xa5\x28\x92\x65\xc7\x3d\x95\xef\x3d\x03\x26\xd5\x5e\x13\xb9\x61\xb7\x47\xf7\x78\xb5\xf7\x7f\x74\xdf\xdc\x47\x21\xae\xa2\x55\xd3\x66\x3c\x6b\xbc\x1b\x81\x8b\xc3\x5c\x2b\xc7\x55\x7d\x1e\x27\x2a\x3f\x5d\xbf\x2b\x75\x1a\x1e\x5f\x22\xde\xa3\x5c\xc2\x3a\xd8\xfa\xea\x79\xdb\x23\x8c\xe5\xfa\x1b\x13\xad\x8c\x59\x3d\xa6\x75\x3b\x73\x26\x6e\x09\x56\x65\xb6\x0f\xed\x13\x4a\xd0\xdd\x3c\xf4\x44\x58\x8d\x7a\xd9\xb8\x6e\x41\x82\xd2\x3d\x8b\x19\x9a\x96\x59\x27\xd9\x63\x43\x04\xb1\x54\x29\xed\xe6\x88\x13\x1f\xf1\x71\x22\x32\x61\x97\x1c\xd3\x53\x4a\x94\x9c\x66\xc2\x5d\x8e\xba\x93\x45\x5e\x28\x6d\x51\x5a\xef\xc6\x9a\x66\x74\x0f\xc2\x42\xce\xa5\x2f\x19\x4e\x1c\xc7\xa9\x34\x67\x67\xcf\x5f\x5c\x96\x93\x54\xe5\x28\xe4\x45\x6e\x4f\x4f\x5e\x1f\xff\x58\x62\xc6\x11\x33\xfd\x06\x73\xba\xc8\xed\xc9\x1e\xc5\xc1\xd9\xcb\x07\xfd\xf0\xf8\xda\x7b\xdb\xcd\xf1\x75\x54\xfd\x7a\x5a\x77\x9d\xbc\x3e\x1e\xc7\xbd\xe3\x27\x4f\x99\xb5\x86\x0f\xdf\x5c\x47\x6b\x07\x8e\x6f\x9e\x9e\xbc\x6e\x8c\x9d\x3c\xd2\x9d\xdb\xdf\x11\x7c\x8b\x5a\xca\xeb\xd6\x69\x55\xc1\xd6\x3a\xe6\x93\x4b\xeb\x90\x3f\xfa\xd6\xa1\x8e\x6b\x53\xcf\x13\x5b\xff\xa3\xcf\xee\x83\x4f\x8e\x45\x74\x4b\xcb\x96\x38\xd6\xb1\x7b\xd7\x9b\x51\x8e\x45\xdb\x4b\xe3\x65\x47\x94\xdc\x7c\x5a\xe9\x7c\x51\xa9\xdc\xa2\x43\xc8\xd6\xe3\xec\x7b\xce\xeb\x5b\xa6\x89\x3e\xc7\x1b\x4c\xa6\x66\x22\xc1\xec\xcf\x99\x4a\x6e\x2f\xc5\x4f\x2d\xf1\xf1\xf1\xb4\x73\x95\x52\xf6\x4d\x99\x4f\x48\x1f\x24\x6b\xff\xbb\x63\xe7\xcb\xd0\x1e\xcf\xbe\xfb\x9a\x5d\xcf\x3b\x63\xdf\x1b\x63\x0f\x07\x1c\x45\x39\x6e\x1d\xb4\xa8\x40\x6d\x9d\x4f\x7f\xd3\x96\x54\xfb\x54\x5f\xa0\x9d\x1f\xb6\xd5\x7c\x69\x3e\x9b\x21\x68\xa5\xec\xc7\x5a\x96\x83\xd8\x32\xa4\x05\x3e\xc6\x86\xac\x2a\x54\xa6\x66\x2d\xbe\xf2\xb9\x51\x04\xab\x2c\x66\xff\x7d\x57\xed\x7a\x4a\xe6\x93\x7e\xf8\x01\x79\x77\x75\xb4\x82\x9b\x1a\x5d\x7c\x25\x38\xea\x24\xe4\x6f\x84\x23\xb0\xba\xf4\x81\xd7\x58\xa5\x71\x46\x23\x98\x72\xdd\xb6\x01\x2e\x4f\xc8\x06\x6c\x39\x60\xcb\xbe\x05\x6c\x39\x60\xcb\x55\x0b\xd8\x72\xc0\x96\x21\x60\xcb\xbb\x2b\x7f\x87\xd8\x72\x92\x90\x31\x57\xa2\xad\xb2\xdb\xd8\xfe\xcd\x6a\xe2\x6a\x53\xbf\x16\xac\x20\x7d\xd0\xed\x2b\xe0\xd9\x01\xcf\x86\x80\x67\x07\x3c\xdb\xb7\x80\x67\x07\x3c\x3b\xe0\xd9\x01\xcf\xde\xa6\x1c\xf0\x6c\x08\x78\x76\xc0\xb3\x03\x9e\x1d\xf0\xec\x80\x67\x07\x3c\x3b\xe0\xd9\x01\xcf\x0e\x78\x76\xc0\xb3\x1b\x2d\xe0\xd9\x01\xcf\x76\xed\x77\x84\x67\x3f\xf7\x93\x02\x9e\x1d\xf0\xec\x80\x67\x07\x3c\xdb\xb5\x80\x67\x07\x3c\x1b\x02\x9e\xbd\xbb\x32\xe0\xd9\x9d\xdb\x07\x3c\x3b\xe0\xd9\x01\xcf\x0e\x78\x76\x25\x42\xc0\xb3\x03\x9e\xdd\x68\x01\xcf\x5e\xb7\x80\x67\x07\x3c\xbb\x71\x0e\x01\xcf\x0e\x78\x76\x87\x6c\x01\xcf\x0e\x78\x76\xc0\xb3\x03\x9e\x1d\xf0\xec\xc6\x50\xc0\xb3\x7f\x3e\x3c\x7b\xb5\xec\xdb\x6f\xdf\xbd\xfd\xed\x43\xe1\xf8\x83\xd2\x5d\x30\x66\x83\xec\x8b\xe7\x87\x91\x15\xf2\xb3\x90\x0d\xc0\xfd\xaa\xfd\xec\xc0\x7d\xb5\xf2\x60\xb7\x08\x90\x7f\x80\xfc\x7f\x71\xc8\xff\x85\x9f\x14\x20\xff\x00\xf9\x07\xc8\x3f\x40\xfe\xae\x05\xc8\x3f\x40\xfe\x10\x20\xff\xdd\x95\x01\xf2\xef\xdc\x3e\x40\xfe\x01\xf2\x0f\x90\x7f\x80\xfc\x2b\x11\x02\xe4\x1f\x20\xff\x46\x0b\x90\xff\xba\x05\xc8\x3f\x40\xfe\x8d\x73\x08\x90\x7f\x80\xfc\x3b\x64\x0b\x90\x7f\x80\xfc\x03\xe4\x1f\x20\xff\x00\xf9\x37\x86\xfe\xb7\x21\xff\xfc\x60\x64\x32\x3d\x1c\x6f\x0f\x1f\x16\xf8\xd1\xdf\xd5\x87\x05\x68\xec\xa1\xe0\x7f\x7a\xb0\xc2\xc3\xe7\x0b\xbf\xde\xcf\x17\xae\x38\x0f\x5f\xb5\x96\x1b\xfb\xac\x7c\xc4\xe7\x0b\xbf\xc4\x27\x13\xd5\xca\xb6\xb4\xd4\xf7\x46\xff\x2b\xfb\xd6\x82\x30\xfd\x20\xb3\x96\x98\xd5\x27\xc3\x2f\xf0\x85\x86\xb9\xc3\xe2\x43\xe7\x5e\xed\x6c\xfe\xe6\xbe\xea\x00\x28\x69\x41\xd2\x5e\x5c\x1e\x6c\xaf\x7e\xe1\xa5\x53\xff\x41\x0b\x17\x24\x53\x75\xd8\x59\x2d\x84\xb6\x65\xf7\x36\xed\x87\x75\x77\x27\x3a\x3d\xa9\x65\x97\x5f\xf5\x07\x2e\xae\x67\x7d\x05\xf5\xcf\x9b\xbe\x72\xaf\xcc\xcc\x7d\xe3\x00\x03\x7f\xd9\x2b\xb2\x52\x63\x56\xfd\x6d\xc0\x42\x70\x7d\x73\xe4\xa9\x52\x5a\x7d\x73\xe2\x3b\xff\x1d\x00\x00\xff\xff\x2c\x61\x20\x6f\xec\x81\x00\x00")
+var _config_crd_direct_csi_min_io_directcsidrives_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\x03\xed\x5d\x5b\x6f\xdb\x38\x16\x7e\xcf\xaf\x20\xb2\x0b\xb4\xe9\xda\x72\x9d\x2e\xba\x33\x06\x8a\xa2\x93\x6c\x77\x83\x4e\x2f\xa8\xd3\x3e\x6c\x92\xdd\xa1\x25\xda\x66\x43\x91\x1a\x52\x72\xe2\x0e\xe6\xbf\xef\x39\xa4\x24\xcb\xb6\x24\x5b\xd9\x66\x5b\x74\xe8\x87\x69\xc4\xcb\xe1\xe1\xe1\xb9\xf2\x23\x30\x07\xfd\x7e\xff\x80\x26\xfc\x23\xd3\x86\x2b\x39\x22\xf0\x37\xbb\x4d\x99\xc4\x2f\x13\x5c\xff\x60\x02\xae\x06\x8b\xe1\xc1\x35\x97\xd1\x88\x9c\x64\x26\x55\xf1\x7b\x66\x54\xa6\x43\x76\xca\xa6\x5c\xf2\x14\x46\x1e\xc4\x2c\xa5\x11\x4d\xe9\xe8\x80\x10\x2a\xa5\x4a\x29\x36\x1b\xfc\x24\x24\x54\x32\xd5\x4a\x08\xa6\xfb\x33\x26\x83\xeb\x6c\xc2\x26\x19\x17\x11\xd3\x96\x78\xb1\xf4\xe2\x71\xf0\x34\x18\xc2\x8c\x50\x33\x3b\xfd\x9c\xc7\xcc\xa4\x34\x4e\x46\x44\x66\x42\x40\x8f\xa4\x31\x1b\x91\x88\x6b\x16\xa6\xa1\xe1\x91\xe6\x0b\x66\x02\xf7\x1d\x40\x43\x10\x73\x09\x34\x0f\x4c\xc2\x42\x5c\x7b\xa6\x55\x96\x14\x13\xaa\x03\x1c\xa9\x9c\x3f\xb7\xb7\x53\x3b\xe8\x64\x7c\x76\x8a\x54\x6d\x87\xe0\x26\x7d\x55\xd3\xf9\x33\xb4\xdb\x01\x89\xc8\x34\x15\x5b\x1c\xd9\x3e\xc3\xe5\x2c\x13\x54\x6f\xf6\x42\xa7\x09\x55\x02\xfb\x38\x11\x20\x4e\xa6\xa1\x21\x97\x81\xe5\xa7\x9f\xef\x72\x31\xa4\x22\x99\xd3\xa1\x23\x16\xce\x59\x4c\x1d\xbb\x84\xc0\x6c\xf9\xe2\xdd\xd9\xc7\x27\xe3\xb5\x66\x42\x22\x66\x42\xcd\x93\xd4\xca\x73\x9d\x67\xe8\x83\x63\x61\x86\x58\x26\xc8\xc9\xfb\x53\xa2\x26\x9f\x50\x2c\xe5\xec\x44\x03\x61\x9d\xf2\x42\x2e\xee\x57\xd1\x8e\x4a\xeb\xc6\x5a\x0f\x90\x1d\x37\x0a\x3a\x40\x2d\x60\xa1\x74\xce\x8a\x8d\xb1\x28\xdf\x01\x51\x53\x68\xe7\x86\x68\x96\x68\x66\x98\x74\x8a\xb2\x46\x98\xe0\x20\x2a\x0b\xf6\xc8\x98\x69\x24\x43\xcc\x5c\x65\x22\x42\x6d\x82\xcf\x14\x28\x84\x6a\x26\xf9\xe7\x92\x36\xac\xa8\xec\xa2\x82\xc2\x3e\xd3\x0d\x9a\x5c\x82\xa8\x25\x15\x64\x41\x45\xc6\x7a\xb0\x40\x44\x62\xba\x04\x32\xb8\x0a\xc9\x64\x85\x9e\x1d\x62\x02\xf2\x5a\x69\x06\x13\xa7\x6a\x44\xe6\x69\x9a\x98\xd1\x60\x30\xe3\x69\x61\x15\xa1\x8a\xe3\x0c\xf4\x7f\x39\xb0\x0a\xce\x27\x59\xaa\xb4\x19\x44\x6c\xc1\xc4\xc0\xf0\x59\x9f\xea\x70\xce\x53\xa0\x9e\x69\x36\x00\x31\xf6\x2d\xeb\xd2\x5a\x46\x10\x47\x7f\xd2\xb9\x1d\x99\x07\x6b\xbc\xa6\x4b\x54\x0e\x03\x14\xe5\xac\xd2\x61\xb5\xb4\xe5\x04\x50\x51\x09\x48\x96\xe6\x53\xdd\x2e\x56\x82\xc6\x26\x94\xce\xfb\xbf\x8f\xcf\x49\xb1\xb4\x3d\x8c\x4d\xe9\x5b\xb9\xaf\x26\x9a\xd5\x11\xa0\xc0\x40\x1e\x4c\xbb\x43\x9c\x6a\x15\x5b\x9a\x4c\x46\x89\x02\x09\xdb\x8f\x50\x70\x98\xb5\x41\xd4\x64\x93\x98\xa7\x78\xee\xbf\x82\x68\x53\x3c\xab\x80\x9c\x58\x57\x41\x26\x8c\x64\x09\x78\x0f\x16\x05\xe4\x4c\x42\x6b\xcc\xc4\x09\x35\xec\xde\x0f\x00\x25\x6d\xfa\x28\xd8\xfd\x8e\xa0\xea\xe5\x36\x07\x3b\xa9\x55\x3a\x0a\x1f\xd4\x70\x5e\xeb\xd6\x39\x86\xc1\x1b\x16\x8a\xf3\xf9\x94\x87\xd6\x40\x82\x35\x42\xf5\x86\x6a\x97\x28\xa8\xbe\xbd\x01\xa3\xdb\xec\xdd\x60\x01\xcf\x02\xc6\x47\x5b\xa3\xdc\x8e\x26\x4a\x09\x46\x37\x6d\xd3\x32\x77\x4e\xe1\xb0\xb7\xa9\xd3\x28\xb2\xe1\x80\x8a\x77\x8d\x1c\xb6\x88\xb7\x55\x9c\xf8\xcb\x95\x87\x45\x2f\x95\x8e\x69\xba\x63\x7b\xef\xd7\x47\x6f\x88\x77\xea\x1a\x73\x92\x56\xc9\xb0\x61\x4b\xd6\xed\xf2\xc6\xdf\x94\x0b\x66\x96\xb0\x50\x5c\xd7\xbb\x63\xb7\x04\x19\x09\x59\xdb\xcc\xfa\x73\xb0\xfa\xa8\x32\x99\xbe\x4d\x2a\xa1\x76\xf3\x07\xda\x1f\x37\x74\xed\x64\xac\x18\x40\xb5\xa6\xcb\xda\xfe\xdb\x3e\xc6\x72\x2d\x19\x88\xb5\x8f\xc1\xb2\x9f\xcf\x80\x24\x81\x87\x4d\x0c\x5b\x4f\x71\x27\x51\x25\x99\x9e\xdd\x49\x54\x8d\x3a\x55\x98\xc0\x3a\xd1\xfe\x86\x1d\xed\x65\xee\x10\xc9\x32\xb3\xbf\xc1\xdb\xe1\x1b\x3a\xd9\xa8\x84\xcd\x0a\x48\x85\x50\x21\xba\xce\x13\x9a\xd0\x10\x7c\xe1\xb6\x78\x1c\xcd\x11\x46\xc0\xa7\x7f\x6d\x10\x0d\x46\xc7\x99\x4d\x45\xaa\x3f\x70\x97\xce\xa0\x6b\x54\xa8\x51\xb3\xd6\x36\x7d\x78\x52\x90\xb0\x59\x20\xb8\x0d\xdc\x33\xfc\x2b\x0c\xf2\x45\x20\x35\x20\x14\x3d\x5d\xea\x32\x03\x88\x1e\x99\xd6\xdb\xe1\x63\x25\x63\x56\xa6\x10\x90\x72\x90\x22\x15\x0d\x08\x24\xb2\xe4\x1c\x9b\x41\x7b\x32\x20\x07\x7f\xe1\xa6\x64\x04\xf1\x1c\x57\x72\x27\x5a\x4b\x36\x33\xc8\x04\xa6\x1c\x56\xd5\x41\x7d\x2d\x27\x53\xce\x20\xdd\x48\x68\x3a\x27\x81\x3b\xdd\x60\x25\x90\x80\x10\x70\x2b\x84\xdd\x42\x7a\x2a\x58\xaf\x51\x27\x61\x94\xca\xcf\xda\x31\xf6\x9b\xed\x1a\x0c\x80\xf5\x22\xbe\xda\xd5\xd4\xc4\x40\x90\x75\x69\xb3\x4d\x80\x6a\x49\x4e\x95\x7a\x60\x0a\x19\x39\x79\x04\x05\xc1\x57\x52\xdd\xc8\x3a\x56\x2d\x1f\x54\x37\x58\xce\xe5\xe1\x8b\x05\x9c\x07\x9d\x08\x76\x79\xd8\x83\x4f\xf0\xdd\x33\xe0\x0c\xf3\x57\x6c\xc0\x44\xe9\xf2\xf0\x94\xcd\x34\x05\x59\x5e\x1e\x16\xcb\xfd\x05\x24\x13\xce\x5f\x33\x30\xc9\x57\x6c\xf9\x0c\x17\xa9\xa7\xbf\x36\x7e\x9c\x6a\xe0\x79\xb6\x7c\x16\xe3\xc4\x92\x16\x3a\x8f\x73\xa0\xf0\x2c\xa6\xc9\x5a\xe3\x6b\x9a\xec\xa6\x5e\x2a\x99\x21\x17\x57\x18\xa4\x17\xc3\x60\xa5\x78\xbf\x7c\x32\xa0\x8a\x97\x87\x2b\x89\xf4\xc0\x3d\x81\xfa\x26\xe9\xf2\xf2\xb0\x96\xea\x1a\xab\x30\xd5\x32\x0b\x5b\x5f\xdb\x32\xb4\x23\x5b\xd8\xac\x55\xaa\x26\xd9\x14\x5a\x26\x4b\x30\xe7\xde\xb0\x07\xd9\x53\x0f\xf3\xf8\x67\xab\x55\x2f\x0f\x7f\xa9\xdf\x82\x2c\x76\xac\x40\x11\xb4\xd3\x3b\x43\x7e\xaf\x63\xad\x3d\x12\x41\xc5\x42\x41\x8e\x9a\x42\xf9\x56\x14\x50\x4d\xce\x7f\xcd\x4c\xb7\xa7\xa1\xfd\xb8\x5c\x1a\x82\x63\x8a\x0d\xd6\x38\x8b\xcd\x34\x10\x05\x9d\x2f\xa9\xa0\xdd\x61\x7e\x88\x26\xee\x74\x12\xf3\x73\x2a\xed\x26\x83\xdc\x56\x5d\x4a\x0f\x09\xe0\xcd\x9c\xb5\x10\x85\xa5\x33\xb0\x64\x2d\x96\x98\xc5\x86\x2b\x9f\x32\xa7\x72\x86\x69\x23\x39\x43\xa7\x40\xad\xd9\x63\x4a\x79\x8d\xb6\xd0\xc3\x89\xcd\x54\x33\x53\xa4\xc4\x76\x7f\xc8\x81\xfd\x42\xbf\xe2\x6c\x3f\x27\x6f\xb3\xea\x30\x64\x49\x8a\x46\x12\x34\x10\x2c\xdc\x2c\x26\xb2\x7d\xa4\x78\xd7\xa8\x0b\x75\xa9\xa1\xb3\xfd\x0e\x2e\x1f\xeb\xf2\xfe\x79\x16\x83\x0f\x83\xe2\x39\x42\x3e\x57\x7d\x20\x2d\xcc\x22\x1b\x96\x73\x34\x9d\x4b\xa6\x13\x95\x39\xe7\xb7\x3a\xc7\xfc\xa8\x30\xf5\x87\x73\x82\x05\xac\xe1\xe4\x1b\x68\x12\x46\x4c\x6f\x7f\x66\x72\x96\xce\x47\xe4\xc9\xf1\xdf\x9e\xfe\x70\x57\x59\x38\xaf\xc8\xa2\x7f\x30\xc9\xb4\x75\x8e\x7b\x89\x65\x7b\x5a\xa5\x9c\xb1\xfb\x0b\x8a\x5c\x3e\x98\x95\x63\x5a\xf4\x2f\x0f\x09\x2b\xcd\xbb\x81\x80\x61\x18\xd4\x2e\x50\xa7\x44\x50\xbe\xa0\x9c\x30\x20\x40\x80\x4b\xa9\x0c\xa1\xc0\xe4\xd3\x6e\x8b\xf0\xd2\xaf\x8b\x25\x19\x1e\xf7\xc8\x24\x3f\x8a\x6d\x8f\x7e\x71\x7b\x15\x6c\x6f\xb1\x8d\xf2\x8f\xbd\x0d\xfe\xa1\x0d\x8f\x1a\x02\x0d\xea\x2b\xb9\xe1\x10\xe5\x40\x3e\x36\x12\xe7\x65\x74\x5b\x24\xde\x88\xc6\xac\xdc\xf7\x2e\xeb\xa8\x4f\x42\x72\xa5\xe1\x92\xc7\x59\x3c\x22\x8f\x5b\xd5\xa5\x3e\x57\x29\xf2\x39\x6a\xf6\xd4\x11\x37\x74\x95\x96\x50\x74\xae\x10\xe4\x62\x4c\xc0\x42\xc2\x23\x2c\x14\xc1\x0f\xe8\x7d\x0c\x08\x45\x90\x13\xc4\x64\x63\x4d\xd6\x10\xb0\x9d\x17\xad\x98\x14\xc4\xd8\x28\x0b\xa1\xa4\x6e\xa4\x08\x72\x2d\x2a\xc0\xca\xb1\xd9\x8a\xd5\xda\xa2\xbb\x65\x81\x04\x04\x8f\xac\xbc\xb3\xc0\x68\xdd\x48\x32\x86\xd4\x18\x36\x61\x72\x16\xb1\x80\x47\x37\xe7\x42\x3c\xb8\x3f\x1b\x7d\xec\xad\x4d\x4e\x4b\xdb\x5d\x18\x10\x45\x5d\x95\x58\xa6\xa0\x64\x96\x51\xd8\x5b\xca\x80\x0d\x70\x9e\xe8\x30\x72\x1a\x15\x07\x4f\x57\x75\xfd\x0e\xdf\x41\x9c\xc3\x71\x2e\x18\xb7\x9a\xdf\x11\x58\xbf\xb3\x87\xc3\x19\x3e\x3e\x6e\xd1\xb0\x72\x54\xc3\x10\x08\xf1\x78\x51\x34\x22\xff\xbe\x78\xd1\xff\x17\xed\x7f\xbe\x7a\x98\xff\xf1\xb8\xff\xe3\x7f\x7a\xa3\xab\x47\x95\xcf\xab\xa3\xe7\x7f\xbe\xab\x6b\xab\x2b\x18\x1a\x54\x35\x0f\x9f\x45\x86\x5c\x68\x43\xcf\xc6\x56\x68\x3d\xd7\x78\xa3\xf5\x92\x0a\x03\xff\x7c\x90\x36\xf8\x35\x09\x8a\xc9\x2c\x6e\x5a\xb4\x4f\x0e\x91\xd4\x61\x73\xb7\x5d\xa3\xb9\x3f\x5f\xfb\x7f\xaa\x37\xf7\x11\x88\xcd\x68\x61\xe3\x15\x7f\x56\xb9\x37\x22\xd6\x0f\x63\xae\x1c\xe4\xf9\x39\xf8\xce\x78\xb0\xba\x57\x6a\x54\x3c\x2c\x22\x5e\x53\xb9\x24\x2b\x67\xeb\xb2\xe7\x4d\x8b\x80\x6a\x1f\xf2\x6f\x1a\x6a\x65\x4c\x79\x99\xd6\x6c\xcc\x82\x5f\x43\x5e\x51\xa4\xd9\xce\xb5\x4f\x58\x48\x6d\xe5\xa1\x27\x1c\x5c\x83\x5e\x56\xca\x2d\x12\x42\x9c\xc5\x6b\x31\xc3\xa6\x99\x68\x24\xfb\xd0\x30\x08\x0f\x52\x45\x6c\x3b\x46\x1c\x39\x8f\x4f\x27\x5c\x40\x55\x88\x3e\x3d\x62\xd0\x3b\x15\xdc\x16\x47\xcd\xc1\x22\x4e\x94\x06\x57\x9e\x3a\x33\xd6\xe0\x6a\x6f\xa1\xd8\x03\x03\x83\xd4\x17\x44\x00\x96\xf9\x30\x92\x66\x38\x3c\x7e\x32\xce\x26\x91\x8a\xc1\x79\xbe\x8c\xd3\xc1\xd1\xf3\x87\xbf\x66\x54\xa0\xc7\x8c\xde\x80\xa4\xa1\xed\x68\x8f\xe4\x60\xf8\x74\xa7\x1d\x3e\xbc\x70\xd6\x06\x86\xd8\xcf\xff\x7a\x54\x34\xc1\xaa\x97\x41\x6b\xff\xd1\x23\x64\xad\x62\xc3\x57\x17\xfd\x95\x01\x07\x57\x8f\x8e\x9e\x57\xfa\x8e\xee\x68\xce\xf5\xf7\x08\x85\x59\x6c\xa7\xd7\xb5\xc3\xf2\x84\xad\xb6\xcf\x05\x97\xda\x2e\x77\xf4\xb5\x5d\x0d\x65\x53\xcb\x15\x5b\xfb\xa5\xcf\xf6\x85\x0f\xd4\x6b\xfd\x6b\xb6\xac\xf1\x63\x0d\xab\x37\xdd\x19\x01\xa1\xba\x9b\xc6\x71\x83\x97\x5c\xbf\x5a\x69\xbc\x51\xc9\xcd\xe2\xa0\xc3\x71\xb6\x5d\xe7\xb5\x4d\xd3\x8c\xdd\xc7\x1d\x8c\x50\x33\x48\x3e\xc4\x4f\x42\x85\xd7\x63\xfe\x99\x7d\x49\xda\x31\x78\x0e\xf1\x26\x8b\xe1\x3c\x3a\xed\xb5\xfd\xde\xb1\xf1\x66\x68\x8f\x6b\xdf\x7d\xd5\xae\xe5\x9e\xb1\xed\x8e\xb1\x85\x03\xf4\xa2\xe8\xb7\x3a\x4d\x4a\x28\xd4\xe2\x28\x86\x37\x59\xa3\xb6\xd4\x8b\x1e\xaf\x95\xba\x2d\x35\x5f\x9a\x7b\x53\x04\xad\x54\xfa\xae\xd8\x4b\x27\xb6\xa0\x08\xe1\xf4\x2e\x3a\x94\xaa\x44\x81\x6e\x2f\xff\xff\x28\x42\xaa\x52\x2a\xbe\xbc\xa9\x36\x5d\x25\xe3\x49\xef\xbe\x40\xde\x9e\xdd\x2f\xe1\xa6\x4a\x13\x96\x04\x07\x8d\x84\x5c\x45\x08\xe9\x11\x24\x71\xae\x21\x55\x1a\xaf\x12\xc8\x14\xf3\xb6\x35\x70\x79\x02\xc4\x3d\xb6\xec\xb1\x65\x8f\x2d\x7b\x6c\xd9\x63\xcb\x1e\x5b\xf6\xd8\xb2\xc7\x96\x37\xb1\xe5\x10\xe2\x87\x39\xe7\x75\x99\xdd\xda\xf2\x2f\xca\x81\xe5\xa2\x6e\x2e\x01\xb2\xba\x53\xf5\xe5\xf1\x6c\x8f\x67\x7b\x3c\xdb\xe3\xd9\x1e\xcf\xf6\x78\xb6\xc7\xb3\x3d\x9e\xed\xf1\x6c\x8f\x67\x7b\x3c\xdb\xe3\xd9\x1e\xcf\xf6\x78\xb6\xc7\xb3\x3d\x9e\xed\xf1\x6c\x8f\x67\x7b\x3c\xdb\xe3\xd9\x1e\xcf\xf6\x78\xf6\x16\x9e\x7d\xec\xf1\x6c\x8f\x67\x7b\x3c\xdb\xe3\xd9\x1e\xcf\xf6\x78\xb6\xc7\xb3\x3d\x9e\xed\xf1\x6c\x8f\x67\x7b\x3c\xdb\xe3\xd9\x1e\xcf\xf6\x78\xb6\xc7\xb3\x3d\x9e\xed\xf1\x6c\x8f\x67\x7b\x3c\xdb\xe3\xd9\x1e\xcf\xf6\x78\xb6\xc7\xb3\x3d\x9e\xed\xf1\xec\x3f\x0e\x9e\x5d\x4e\xfb\xf0\xe1\xec\xf4\xfb\x87\xc2\xe9\x27\xa5\x9b\x60\xcc\x0a\xd9\x27\xc7\xdd\xc8\x72\x79\x2f\x64\x3d\x70\xff\xf5\x80\xfb\x7c\x66\x67\xb3\xf0\x90\xbf\x87\xfc\xbf\x3a\xe4\xff\xc4\x43\xfe\x1e\xf2\xf7\x90\xbf\x87\xfc\x3d\xe4\xef\x21\x7f\x0f\xf9\x7b\xc8\xff\x3b\x87\xfc\x27\x98\x3b\x7f\x54\x22\xab\x2b\x10\xd6\x76\xf4\xd3\x6a\x64\x01\x16\x62\xe2\x54\xdc\x52\x6a\x7a\xe3\x88\x91\x85\x1b\x53\x13\xea\x4a\x87\x86\x04\x22\x66\x6f\xd9\x21\xfe\x43\x6c\xea\x22\x0f\xff\x4a\xc1\xbf\x52\xf0\xaf\x14\xfc\x2b\x05\xff\x4a\xc1\xbf\x52\xf0\xaf\x14\xfc\x2b\x05\xff\x4a\xc1\xbf\x52\xf0\xaf\x14\xfc\x2b\x05\xff\x4a\xc1\xbf\x52\xf0\xaf\x14\xfc\x2b\x85\x7b\x7e\xa5\x10\x77\x06\x53\xa3\xee\x4f\x04\xfc\x5b\x88\x3f\xe0\x5b\x08\x8a\xff\xf7\xe6\x6e\xef\x15\xa2\xce\x02\xf7\x2f\x2e\xbe\xcd\x17\x17\x52\xe7\x00\xca\x2e\x83\x7f\x53\x0e\x2c\x6e\x43\x20\x72\x61\x85\x42\xa4\x15\x3f\x66\x20\xd0\x9f\x41\xda\x5b\xa2\x7f\x6a\x7a\x50\x5f\x1d\x58\x4f\x11\x7c\x41\xdb\xc0\xe7\x1f\xe7\x98\x4e\x9c\xd7\x66\x4d\x3b\x9e\x9c\xd8\x99\x77\x78\x38\xf2\x35\x1e\xab\xe4\x33\xeb\xa2\x6b\x1b\x3a\xf2\x8d\xbd\x72\x61\x34\x7a\x2b\xc5\xb2\xdb\x1e\xee\xfe\x36\x46\x2b\xf7\x02\x81\x8a\x5d\x68\x62\x39\xb0\x8c\x6a\xd5\x5a\xb0\x44\x11\x68\x85\x64\xed\xb5\xff\x82\x87\x10\x0a\xb3\x70\x8e\x97\xe9\xff\x3c\x3d\x0d\x3a\xed\x14\x9f\x3a\x44\x99\xd8\x09\xf7\x8c\x8b\x71\x85\x4d\xd2\x30\x45\x0e\xcf\x06\x6f\x57\x34\x8a\x52\xa9\xc1\xe8\xee\xe3\x49\x91\xb9\xa1\xc9\x5b\xd9\xed\x74\xbf\xbb\x67\x48\xf9\xfd\x2f\x56\x10\x3b\x8e\xf1\xbc\x18\x57\x1c\x63\x39\x71\xed\xec\x4a\x7d\x92\x8b\xb8\x16\x65\x31\x34\xa5\x3d\xf8\xaf\xe9\x41\xb1\x34\xc1\xca\x6f\xc1\x41\x5a\xdd\x70\xae\x8c\xe1\xe3\x89\x97\xe3\xce\xbe\xc9\x4d\x1c\x5b\x9d\xe9\x34\x11\xa6\x45\xaa\x9b\x82\xe1\xbe\xb2\xe6\x65\xea\x35\xec\xe6\x86\x47\x1d\x56\xf9\xa6\x9f\x91\xd9\x96\xd5\xad\x89\xbb\x91\x77\xc5\x66\x6e\x1b\xf6\x25\x11\x39\x74\xf7\x13\x89\xc8\x34\x88\xcb\x7d\x56\x90\x4c\x72\x71\x75\xe0\xa8\xb2\x28\x7f\xd9\xe5\x1a\xff\x0b\xfb\xeb\xf2\xc8\x52\x85\x00\x00")
 
 func config_crd_direct_csi_min_io_directcsidrives_yaml() ([]byte, error) {
 	return bindata_read(
@@ -50,7 +50,16 @@ func config_crd_direct_csi_min_io_directcsidrives_yaml() ([]byte, error) {
 	)
 }
 
-var _config_crd_direct_csi_min_io_directcsivolumes_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5c\x5d\x8f\xdb\xb8\xd5\xbe\xf7\xaf\x38\xf0\xfb\x02\x99\x49\x2d\x4d\x9c\x14\xe9\xae\x81\x20\x08\x26\x4d\x11\x64\x53\x04\x99\x69\x2e\x3a\x9e\x76\x8f\xa4\x63\x99\x3b\x12\xa9\x90\x94\x33\xde\xa2\xff\xbd\x38\xa4\x64\xc9\xb6\xe4\x4c\x82\xee\x45\x17\xe4\xd5\x98\xa4\x1e\x1e\x9e\x6f\x3e\x17\x33\x89\xa2\x68\x82\x95\xf8\x44\xda\x08\x25\x17\x80\x95\xa0\x7b\x4b\x92\x7f\x99\xf8\xee\x07\x13\x0b\x75\xb1\x99\x4f\xee\x84\xcc\x16\x70\x59\x1b\xab\xca\x8f\x64\x54\xad\x53\x7a\x4d\x2b\x21\x85\x15\x4a\x4e\x4a\xb2\x98\xa1\xc5\xc5\x04\x00\xa5\x54\x16\x79\xda\xf0\x4f\x80\x54\x49\xab\x55\x51\x90\x8e\x72\x92\xf1\x5d\x9d\x50\x52\x8b\x22\x23\xed\xc0\xdb\xa3\x37\x4f\xe2\xe7\xf1\x7c\x02\x90\x6a\x72\x9f\x5f\x8b\x92\x8c\xc5\xb2\x5a\x80\xac\x8b\x62\x02\x20\xb1\xa4\x05\x64\x42\x53\x6a\x53\x23\x36\xaa\xa8\x4b\x32\xb1\x9f\x88\x53\x23\xe2\x52\xc8\x58\xa8\x89\xa9\x28\xe5\xc3\x73\xad\xea\xaa\xfd\xa2\xbf\xc1\x63\x35\x02\xfa\xcb\xbd\x76\x9b\x2e\xaf\xde\x7e\x72\xb0\x6e\xa5\x10\xc6\xbe\x1b\x5a\xfd\x49\x18\xeb\x76\x54\x45\xad\xb1\x38\x16\xca\x2d\x1a\x21\xf3\xba\x40\x7d\xb4\x3c\x01\x30\xa9\xaa\x68\x01\x97\x45\x6d\x2c\xe9\x09\x40\xa3\x08\x27\x53\xd4\x5c\x75\x33\xc7\xa2\x5a\xe3\xdc\xa3\xa5\x6b\x2a\xd1\x8b\x0c\xa0\x2a\x92\xaf\x3e\xbc\xfd\xf4\xec\x6a\x6f\x1a\x20\x23\x93\x6a\x51\x59\xa7\xd4\x03\xb1\x21\x23\xa9\x2c\x19\xf0\x62\xc0\xe5\xc7\xd7\xa0\x92\x5f\x58\x39\xbb\xef\x2b\xad\x2a\xd2\x56\xb4\xda\xf1\xa3\xe7\x24\xbd\xd9\x83\xd3\x1e\xb1\x40\x7e\x17\x64\xec\x1d\x64\xc0\xae\xa9\xbd\x1a\x65\xcd\x1d\x40\xad\xc0\xae\x85\x01\x4d\x95\x26\x43\xd2\xfb\xcb\x1e\x30\xf0\x26\x94\xad\x78\x70\x45\x9a\x61\xc0\xac\x55\x5d\x64\xec\x54\x1b\xd2\x16\x34\xa5\x2a\x97\xe2\xd7\x1d\xb6\x01\xab\xdc\xa1\x05\x5a\x6a\x8c\xd4\x0d\x21\x2d\x69\x89\x05\x6c\xb0\xa8\x69\x06\x28\x33\x28\x71\x0b\x9a\xf8\x14\xa8\x65\x0f\xcf\x6d\x31\x31\xbc\x57\x9a\x40\xc8\x95\x5a\xc0\xda\xda\xca\x2c\x2e\x2e\x72\x61\xdb\xe0\x48\x55\x59\xd6\x52\xd8\xed\x85\xf3\x73\x91\xd4\x56\x69\x73\x91\xd1\x86\x8a\x0b\x23\xf2\x08\x75\xba\x16\x96\x52\x5b\x6b\xba\xc0\x4a\x44\x4e\x74\xe9\x02\x24\x2e\xb3\xff\xd3\x4d\x38\x99\x47\x7b\xb2\xda\x2d\xbb\x87\xb1\x5a\xc8\xbc\xb7\xe0\x7c\xf5\x84\x05\xd8\x5b\x41\x18\xc0\xe6\x53\x7f\x8b\x4e\xd1\x3c\xc5\xda\xf9\xf8\xe7\xab\x6b\x68\x8f\x76\xc6\x38\xd4\xbe\xd3\x7b\xf7\xa1\xe9\x4c\xc0\x0a\x13\x72\x45\xda\x1b\x71\xa5\x55\xe9\x30\x49\x66\x95\x12\xd2\xba\x1f\x69\x21\x48\x1e\xaa\xdf\xd4\x49\x29\x2c\xdb\xfd\x73\x4d\xc6\xb2\xad\x62\xb8\x74\x19\x03\x12\x82\xba\xca\xd0\x52\x16\xc3\x5b\x09\x97\x58\x52\x71\x89\x86\x7e\x73\x03\xb0\xa6\x4d\xc4\x8a\x7d\x98\x09\xfa\xc9\xee\x70\xb3\xd7\x5a\x6f\xc1\x58\xb4\xb5\x39\x61\xb1\x83\x08\xbd\x72\xfb\x0f\xe3\x94\x2f\xaf\x4b\x17\x24\xf1\x1e\xd4\x70\xb0\xf2\xc0\x0d\x8a\x02\x93\x82\x2e\xb1\xc2\x54\xd8\xed\xe1\x06\x00\x8f\xb9\xe0\xa0\x78\xfe\xc7\xa3\x55\x7f\x21\x0e\x98\xdc\xe5\xa7\xfe\x48\x95\xcc\x44\x2f\xc5\xf7\x87\xb0\x54\x0e\x4c\x1f\x5c\x7b\x7a\xd9\x42\xb8\xfa\x80\x42\xf2\xa5\x2d\x8a\xc2\xb0\x5c\xa0\x24\x01\x72\x1a\xb7\x3e\x59\x10\xa4\xb5\xd6\xc7\x1e\xd5\x69\x99\x76\x59\xe5\xd5\x87\xb7\xd0\x16\xa9\x18\xa2\x28\x82\x6b\x9e\x36\x56\xd7\xa9\xe5\xe0\xe0\x4b\xc9\x8c\x32\x77\x92\x4f\xcd\x83\xb0\xb5\x61\x21\x38\x0b\xa1\xd6\xb8\x05\xf4\xae\xbd\x12\x54\x64\x50\xa1\x5d\x43\xec\xed\x1b\x77\x0a\x89\x01\xde\x28\x0d\x74\x8f\x65\x55\xd0\x6c\x10\x97\x55\x0b\x6f\x94\x6a\x8c\xed\x05\xfb\x97\x5b\xba\xb8\x80\x8f\xbb\x90\x73\xa7\xa9\xc4\x90\xde\xf8\x82\xea\x72\xe2\x20\xe4\x4a\xa9\x47\xa6\xd5\x91\xd7\x47\xdc\x02\xbe\x93\xea\x8b\x1c\x12\xd5\xc9\x81\x9a\x86\xac\x05\xb0\x9c\xbe\x6a\x7d\x68\x39\x9d\xc1\x72\xfa\x41\xab\x5c\x93\xe1\xaa\xc6\x13\x9c\x3b\x97\xd3\xd7\x94\x6b\xcc\x28\x5b\x4e\xdb\xe3\xfe\x50\xa1\x4d\xd7\xef\x49\xe7\xf4\x8e\xb6\x2f\xf8\x90\x61\xfc\xbd\xfd\x57\x56\xa3\xa5\x7c\xfb\xa2\xe4\x0f\x77\x58\x5c\x81\xaf\xb7\x15\xbd\x28\xb1\xda\x9b\x7c\x8f\xd5\xd7\xd1\x77\x4e\x66\xe0\xe6\x96\xe3\x76\x33\x8f\x3b\xc7\xfb\xf9\x17\xa3\xe4\x62\x39\xed\x34\x32\x53\x25\xbb\x6f\x65\xb7\xcb\xe9\x20\xea\x9e\xa8\x8b\xe5\xd4\x09\xbb\x9c\xc2\xde\x95\x17\xcb\x29\x8b\xc5\xd3\x5a\x59\x95\xd4\xab\xc5\x72\x9a\x6c\x2d\x99\xd9\x7c\xa6\xa9\x9a\x71\x71\x7f\xd1\x9d\xba\x9c\xfe\x3c\x7c\x05\xd9\xde\x58\xd9\x35\x69\xef\x77\x06\xfe\x3d\x24\xda\x78\x22\xf0\xa3\x40\x63\xaf\x35\x4a\x23\xda\xd6\x6a\x78\xdf\x41\x98\x1e\x7f\xc6\xf1\xe3\xcb\xab\xb1\x60\x79\xc2\x05\x67\x7b\x99\x11\x50\x00\xbb\x43\xe1\xb8\xe3\x92\xc1\x21\xee\x7d\x92\x4b\x36\x4a\x77\xc9\xb8\x89\x55\x5f\xe5\x13\x82\x2f\x6b\x3a\x01\xba\x26\xa8\x65\x46\xba\xd8\x72\x61\x4b\xbb\x9c\xb2\x46\x99\x73\x25\x81\xb7\x9c\x14\xd0\x85\x3d\x57\x99\x3b\x8e\x85\x19\x7f\x38\x8e\x5a\x9b\xb6\x4a\xba\xfb\xb1\x04\xee\x17\xe7\x15\x1f\xfb\x0d\xbc\x2b\xb4\x69\x4a\x95\xe5\x20\x89\x47\x00\xdb\x34\xcb\xb5\x2d\x62\xc4\x91\x7d\x23\xe5\xa6\x1b\x25\x19\x83\xf9\xc3\x0c\xd7\xec\xf5\xad\xc0\xba\x2e\x51\x82\x26\xcc\x58\xce\x6e\x4d\x66\x22\x45\x3b\x76\x9c\xc7\xf4\x29\x19\x13\x55\xfb\xe4\xd7\xd9\xb1\x31\x15\x77\x03\x09\x71\x92\x74\x81\xd3\x5c\x60\x4c\x19\x25\xde\xff\x44\x32\xb7\xeb\x05\x3c\x7b\xfa\xa7\xe7\x3f\x7c\xaf\x2e\x7c\x56\xa4\xec\x2f\x24\x49\xbb\xe4\xf8\x20\xb5\x1c\x7f\xd6\xeb\x70\xdc\xfd\xe2\xb6\xbc\xc7\xf9\x6e\xcf\x09\xff\x6b\x4a\x42\xe7\x79\x5f\xd0\x80\x21\x0b\x09\x1a\xca\xa0\xae\x58\x4f\x5c\x10\x84\x34\x16\x65\x4a\x33\x10\xab\x6f\x3b\x44\xec\xf2\x7a\xb1\x85\xf9\xd3\x19\x24\x8d\x29\x8e\x33\xfa\xcd\xfd\x6d\x7c\x7c\xc5\x53\xc8\x3f\xce\x0e\xe4\x17\x06\xd8\xd4\x6a\xe5\xfc\x15\xbe\x08\xbb\xe6\x3e\xd1\x55\xe2\xa6\xb3\x3e\x55\x89\x61\xbf\x1a\xd3\xee\xde\x5f\x8b\x8e\xe1\x26\xc4\x8f\x52\x48\x51\xd6\xe5\x02\x9e\x9c\x74\x97\xe1\x5e\xc5\x0f\x4d\x68\x1e\xe8\x23\x7e\x6b\xd7\x96\x20\x27\xd7\x5c\x63\xc9\x0d\x58\x0a\x22\xe3\xde\x71\x25\x48\x3f\x24\x80\x58\x05\x0d\x20\x37\x1b\x7b\xba\x7e\x64\x9a\x2c\xda\x0b\xa9\x0f\x5a\x65\x75\x4a\xfa\xb0\x1d\xef\x86\x5a\x01\x5b\x43\xac\x44\xda\x33\x9b\x6b\x62\x5d\x2c\xfa\x87\x17\xd0\x3d\x9b\x6c\xf7\x8c\xe1\x6a\x3d\x0a\x59\x12\x4a\x21\x73\xd3\x88\xc8\x3d\x3d\xa7\x39\x5f\xe2\xbf\xac\xc9\x55\x1f\xf7\x90\x6b\xb0\xb4\xbb\x85\x11\x19\x69\x1a\x87\x45\xc8\x6b\xd4\x28\x2d\x51\xc6\xc9\x93\x13\x46\x83\xd1\x4b\xf0\xd8\xb5\xfa\x5f\xc9\x1d\xe0\x13\x8e\x4f\xc1\x7c\xd5\xe6\xd9\xe0\xf2\xce\x03\x12\xce\xfc\xc9\xd3\x13\x1e\xb6\xdb\x35\xb2\xa5\x42\xcb\x6f\xc7\x05\xfc\xe3\xe6\x55\xf4\x77\x8c\x7e\xbd\x3d\x6b\xfe\x78\x12\xfd\xf8\xcf\xd9\xe2\xf6\x71\xef\xe7\xed\xf9\xcb\xff\xff\xde\xd4\x36\xf4\x64\xe8\xc6\x9e\xab\x36\xe5\xb3\xed\x90\x5b\x6f\x98\xb9\xda\xaa\x56\x70\xad\xf9\x91\xfb\x06\x0b\x43\x33\xf8\x9b\x74\xc5\x6f\x4c\x51\x24\xeb\x72\xec\xd0\x08\xa6\x0c\x35\xdc\x13\xb9\x65\x77\xc6\xf8\x7a\x73\xf6\xf7\xaa\xc4\x6d\x78\x88\x42\x5c\x47\xab\x56\xfd\x7c\xd6\x7b\x4a\x82\xcb\xc3\xdc\x2b\xc7\x4d\x7f\x1e\xa7\xaa\xbc\xe8\x9e\x9a\xa3\x8e\xc7\x8f\x88\xf7\x28\xb7\xd0\x25\x5b\xdf\x3d\x1f\x46\x84\xb1\xdc\x7f\x63\xaa\x95\x31\xbb\xf7\xf5\x78\x30\x17\xe2\x8e\x60\xd7\x66\xfb\xd4\x9e\x50\x8a\xee\xe5\xa1\x13\x61\x35\xea\x6d\xef\xb9\x05\x29\x4a\xf7\x52\x36\xb4\xaa\x8b\x51\xd8\x33\x43\x04\xb1\x54\x19\x1d\xd7\x88\x73\x9f\xf1\x31\x11\x85\xb0\x5b\xce\xe9\x19\xa5\x4a\xae\x0a\xe1\x1e\x47\xe3\xc5\xa2\xac\x94\xb6\x28\xad\x0f\x63\x4d\x39\xdd\x83\xb0\x50\x72\xeb\x4b\x86\x0b\xc7\x59\x26\xcd\x7c\xfe\xf4\xd9\x55\x9d\x64\xaa\x44\x21\xdf\x94\xf6\xe2\xfc\xe5\xd9\xe7\x1a\x0b\xce\x98\xd9\x5f\xb1\xa4\x37\xa5\x3d\x7f\x40\x73\x30\x7f\xfe\xd5\x38\x3c\xbb\xf1\xd1\x76\x7b\x76\x13\x35\x7f\x3d\x6e\xa7\xce\x5f\x9e\x2d\xe3\x93\xeb\xe7\x8f\x59\xb4\x5e\x0c\xdf\xde\x44\x5d\x00\xc7\xb7\x8f\xcf\x5f\xf6\xd6\xce\xbf\x33\x9c\x35\x7d\xae\x85\xa6\x6c\xc8\x7b\xa3\x81\xf6\x7a\x70\x5b\xd3\xb0\x0d\xae\xf9\xe2\x32\xb8\xe4\x4d\x3f\xb8\x34\xf2\x6c\x1a\x21\x31\xfa\x8b\xee\x25\x7c\xb4\x76\x1f\xdd\xd5\x09\x69\x49\x96\x4c\xc4\xcf\xb3\xa8\xc4\x2a\xba\xa3\xed\x40\x1e\x1b\x39\xfd\x18\xc2\x1f\x58\x62\x75\xcc\x3e\x70\x65\x26\xfd\x01\xed\xfa\x18\xff\x84\x45\x32\x2d\x36\x03\x89\xe4\xc4\x17\x6b\x65\xec\x37\x1f\xc3\x81\xc7\xae\xfe\x4d\x1f\x19\x8b\xb9\x90\xf9\x37\x1f\x66\x95\xc5\xe2\xb7\x20\x79\x6a\x43\xd9\x7f\x1f\x77\xd0\xc5\x8e\xa3\x24\xda\xd1\x6c\x93\xd1\x2f\x7d\x9f\xbb\x00\xab\x6b\xef\x4e\xc6\x2a\xcd\x0f\x24\x58\x71\x35\xda\xe3\xd1\x13\xb2\x81\x46\x0f\x34\x7a\x3b\x02\x8d\x1e\x68\xf4\xde\x08\x34\xfa\x4e\xcb\x81\x46\x0f\x34\xfa\x21\x7a\xa0\xd1\xdb\x11\x68\xf4\x40\xa3\x07\x1a\x3d\xd0\xe8\x87\xc8\x81\x46\x87\x40\xa3\x07\x1a\x3d\xd0\xe8\x81\x46\x0f\x34\x7a\xa0\xd1\x03\x8d\x1e\x68\xf4\xbd\x11\x68\xf4\xdf\x0f\x8d\xfe\x34\xd0\xe8\x81\x46\xf7\x23\xd0\xe8\x81\x46\xef\x8d\x40\xa3\xef\xb4\x1c\x68\xf4\x40\xa3\x1f\xa2\x07\x1a\xbd\x1d\x81\x46\x0f\x34\x7a\xa0\xd1\x03\x8d\x7e\x88\x1c\x68\x74\x08\x34\x7a\xa0\xd1\x03\x8d\x1e\x68\xf4\x40\xa3\x07\x1a\x3d\xd0\xe8\x81\x46\xdf\x1b\x81\x46\xff\xfd\xd0\xe8\xcf\x02\x8d\x1e\x68\x74\x3f\x02\x8d\x1e\x68\xf4\xde\x08\x34\xfa\x4e\xcb\x81\x46\x0f\x34\xfa\x21\x7a\xa0\xd1\xdb\x11\x68\xf4\x40\xa3\x07\x1a\x3d\xd0\xe8\x87\xc8\x81\x46\x87\x40\xa3\x07\x1a\x3d\xd0\xe8\x81\x46\x0f\x34\x7a\xa0\xd1\x03\x8d\x1e\x68\xf4\xbd\x11\x68\xf4\xff\x45\x1a\xdd\xcd\x74\x75\xd4\xbf\xd1\x7c\xfa\xd9\xfb\x47\xee\x53\x5f\xb1\xda\xff\xcc\xee\x7e\xf6\xb8\x2d\xb8\xb9\x9d\x78\x54\xca\x3e\xb5\xff\x73\x9d\x27\xff\x13\x00\x00\xff\xff\x4b\x42\xb7\x10\x0d\x5f\x00\x00")
+var _config_crd_direct_csi_min_io_directcsisnapshots_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb5\x55\x4d\x6f\xdb\x30\x0c\xbd\xfb\x57\x10\xdb\xa1\x97\xd9\x59\xd1\xa1\x18\x7c\x1b\xd2\x1d\x8a\x6d\x45\xd1\x74\xbd\x0c\x3b\xc8\x16\xe3\x68\x95\x25\x4f\x94\x82\x65\xbf\x7e\x94\x6c\x37\x89\x93\x74\xeb\x61\x3e\x59\xfc\x78\x24\x1f\x49\x29\xcb\xf3\x3c\x13\x9d\x7a\x40\x47\xca\x9a\x12\xf8\x1f\x7f\x79\x34\xf1\x44\xc5\xe3\x7b\x2a\x94\x9d\xad\xcf\xb3\x47\x65\x64\x09\xf3\x40\xde\xb6\x77\x48\x36\xb8\x1a\xaf\x70\xa9\x8c\xf2\x6c\x99\xb5\xe8\x85\x14\x5e\x94\x19\x80\x30\xc6\x7a\x11\xc5\x14\x8f\x00\xb5\x35\xde\x59\xad\xd1\xe5\x0d\x9a\xe2\x31\x54\x58\x05\xa5\x25\xba\x04\x3e\x86\x5e\xbf\x2d\x2e\x8b\x73\xf6\xa8\x1d\x26\xf7\x7b\xd5\x22\x79\xd1\x76\x25\x98\xa0\x35\x6b\x8c\x68\xb1\x04\xa9\x1c\xd6\xbe\x26\x45\x46\x74\xb4\xb2\x9e\x8a\x5e\x54\xb0\xac\x68\x95\x61\xd8\x8c\x3a\xac\x63\xf8\xc6\xd9\xd0\x8d\x3e\xbb\x06\x3d\xda\x90\x62\x5f\xde\x55\x32\x9a\x2f\xae\x17\x03\x70\xd2\x69\x45\xfe\xd3\x71\xfd\x67\x56\x25\x9b\x4e\x07\x27\xf4\xb1\xd4\x92\x9a\x94\x69\x82\x16\xee\x88\x01\xeb\xa9\xb6\x1d\x97\x35\xd7\xcc\x2e\x3a\x16\x0c\x94\xa4\xdc\xf2\xa1\xe8\xf5\x79\xc5\x1c\x5f\xf4\x70\xf5\x0a\x5b\xd1\x67\x0e\xc0\xce\xe6\xc3\xed\xf5\xc3\xc5\x62\x4f\x0c\x20\x91\x6a\xa7\x3a\x9f\xd8\x3d\xc8\x9d\xd5\xdc\x27\x24\x18\x33\x81\xf9\xdd\x15\xd8\xea\x47\xe4\xe9\x09\xa3\x73\x0c\xef\xbc\x1a\x89\xea\xbf\x9d\x89\xd9\x91\x4e\x22\x9e\xc5\xa4\x7a\x2b\x56\xf0\xa8\x70\x2c\xbf\xc2\xb1\x3a\x94\x43\x1d\x60\x97\x2c\x57\x04\x0e\x3b\x87\x84\xa6\x1f\x9e\x3d\x60\x88\x46\xc2\x8c\xe9\xc1\x02\x5d\x84\x01\xce\x3b\x68\x19\x27\x8c\x8f\x9e\x11\x6a\xdb\x18\xf5\xfb\x09\x9b\x23\xda\x14\x54\x0b\x2e\xd5\x4f\x30\x95\x61\xbe\x8d\xd0\xb0\x16\x3a\xe0\x1b\x0e\x20\xa1\x15\x1b\x86\x89\x51\x20\x98\x1d\xbc\x64\x42\x05\x7c\xb1\x0e\xd9\x71\x69\x4b\x58\x79\xdf\x51\x39\x9b\x35\xca\x8f\x9b\x52\xdb\xb6\x0d\xbc\x13\x9b\x59\x1a\x7a\x55\x05\x6f\x1d\xcd\x24\xae\x51\xcf\x48\x35\xb9\x70\xf5\x4a\x79\x46\x0f\x0e\x67\x4c\x63\x9e\x52\x37\x69\x5b\x8a\x56\xbe\x76\xc3\x6e\xd1\xd9\x5e\xae\x7e\x13\x27\x84\x18\xd1\x34\x3b\x8a\x34\xb6\xcf\x74\x20\x8e\x2d\x30\xb3\x62\x70\xed\xab\xd8\x12\x1d\x45\x91\x9d\xbb\x8f\x8b\x7b\x18\x43\xa7\x66\x4c\xd9\x4f\xbc\x6f\x1d\x69\xdb\x82\x48\x18\xf3\x81\xae\x6f\xe2\xd2\xd9\x36\x61\xa2\x91\x9d\x65\x86\xd3\xa1\xd6\x8a\xbd\x26\xa0\x14\xaa\x56\xf9\xd8\xf7\x9f\x4c\xad\x8f\xbd\x2a\x60\x9e\xae\x0f\xa8\x10\x42\xc7\x37\x0a\xca\x02\xae\x0d\x4b\x5b\xd4\x73\x41\xf8\xdf\x1b\x10\x99\xa6\x3c\x12\xfb\x6f\x2d\xd8\xbd\xf9\xa6\xc6\x3d\x6b\x3b\x0a\xbe\xcd\x7c\xa0\x67\x3a\x76\xb0\xa5\x8b\xe4\x71\xb8\xab\x91\x00\xd7\xa6\x45\x29\xf6\xe0\x8e\x2f\x6c\x0a\xe4\xd4\x1a\xa7\xc2\x93\x75\xc5\x6f\x65\xc9\xdf\x0a\xbf\x7a\x91\x93\xb1\x12\x6f\xe2\x95\xf5\x12\x27\xbe\xf1\xe5\xe6\xde\x7e\xa5\x93\x6e\x95\xb5\x1a\xc5\xf4\x56\x20\xde\xcd\x43\x8f\x9e\x9a\x32\xee\xf7\xe5\xbb\x13\x78\x71\xf7\x9b\x74\xdb\xee\xe1\xa5\x0d\x78\xb0\x3a\xbc\xa8\x80\x38\xc1\xdc\xb9\xc9\x2e\xe6\x7b\x70\x7f\x9f\x8f\x43\x94\xfc\x69\xbc\xb2\x93\x9e\x14\xf7\x90\x1f\x27\xef\x42\x1f\x83\x9f\x68\x27\x1a\x1c\x24\xdb\x91\x13\x75\x8d\x1d\x2f\xd4\xcd\xf4\xd9\x7b\xf5\x6a\xef\x0d\x4b\x47\xde\x0a\xa9\xfa\x37\x1c\xbe\x7d\xcf\x7a\x54\x94\x0f\xe3\xcb\x14\x85\x7f\x00\xe9\xca\x34\xc4\x3d\x08\x00\x00")
+
+func config_crd_direct_csi_min_io_directcsisnapshots_yaml() ([]byte, error) {
+	return bindata_read(
+		_config_crd_direct_csi_min_io_directcsisnapshots_yaml,
+		"config/crd/direct.csi.min.io_directcsisnapshots.yaml",
+	)
+}
+
+var _config_crd_direct_csi_min_io_directcsivolumes_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x5d\xdf\x6f\x1b\xb9\x11\x7e\xd7\x5f\x41\xa8\x05\x12\xa7\xda\x55\x94\x14\xe9\x9d\x80\x20\x08\xec\xe6\x60\xe4\xd2\x06\xb1\x2f\x0f\xb5\xdd\x1e\xb5\x4b\x49\x7b\xde\x25\xf7\x48\xae\x62\x5d\xd1\xff\xbd\xdf\x90\xbb\xd2\x4a\xda\x55\xac\xa0\xf7\x52\xd0\x2f\x91\xf8\x63\x38\x1c\xce\x7c\x33\xf3\x19\x88\x07\x51\x14\x0d\x78\x99\x7d\x16\xda\x64\x4a\x4e\x19\x3e\x8b\x07\x2b\x24\x7d\x33\xf1\xfd\x77\x26\xce\xd4\x78\x35\x19\xdc\x67\x32\x9d\xb2\xf3\xca\x58\x55\x7c\x12\x46\x55\x3a\x11\x17\x62\x9e\xc9\xcc\x62\xe5\xa0\x10\x96\xa7\xdc\xf2\xe9\x80\x31\x2e\xa5\xb2\x9c\x86\x0d\x7d\x65\x2c\x51\xd2\x6a\x95\xe7\x42\x47\x0b\x21\xe3\xfb\x6a\x26\x66\x55\x96\xa7\x42\x3b\xe1\xcd\xd1\xab\xe7\xf1\xab\x78\x82\x1d\x89\x16\x6e\xfb\x75\x56\x08\x63\x79\x51\x4e\x99\xac\xf2\x1c\x33\x92\x17\x62\xca\xd2\x4c\x8b\xc4\x26\x26\x5b\xa9\xbc\xc2\x92\xd8\x0f\xc4\x18\x89\x8b\x4c\x42\xe8\xc0\x94\x22\xa1\xc3\x17\x5a\x55\x65\xb3\xa3\xbd\xc0\xcb\xaa\x15\xf4\x97\xbb\x70\x8b\xce\xaf\x2e\x3f\x3b\xb1\x6e\x26\xcf\x8c\x7d\xdf\x35\xfb\x23\x26\xdc\x8a\x32\xaf\x34\xcf\x0f\x95\x72\x93\x26\x93\x8b\x2a\xe7\xfa\x60\x1a\xb3\x26\x51\x25\x2e\x73\x9e\xc3\xa6\x42\x63\xa0\x36\x84\xd3\x29\xaa\xaf\xba\x9a\xf0\xbc\x5c\xf2\x89\x97\x96\x2c\x45\xc1\xbd\xca\x8c\x61\xb7\x7c\xfb\xf1\xf2\xf3\xcb\xab\x9d\x61\xc6\x52\x61\x12\x9d\x95\xd6\x19\x75\x4f\x6d\x4c\xe2\x71\x84\x61\x5e\x0d\x76\xfe\xe9\x82\xa9\xd9\x2f\x64\x9c\xcd\xfe\x52\x43\xb4\xb6\x59\x63\x1d\xff\xd3\x72\x92\xd6\xe8\xde\x69\x4f\x48\x21\xbf\x0a\x13\xf0\x0e\x9c\x64\x97\xa2\xb9\x9a\x48\xeb\x3b\x30\x35\xc7\x78\x66\x98\x16\xa5\x16\x46\x48\xef\x2f\x3b\x82\x19\x2d\xe2\xb2\x51\x8f\x5d\x09\x4d\x62\x98\x59\xaa\x2a\x4f\xc9\xa9\xf0\xd5\x42\x42\xa2\x16\x32\xfb\x6d\x23\x1b\x27\x2a\x77\x68\xce\x71\x51\xbb\x27\x33\x93\x30\xb6\xe4\x39\x5b\xf1\xbc\x12\x23\x1c\x90\xb2\x82\xaf\x21\x86\x4e\x61\x95\x6c\xc9\x73\x4b\x4c\xcc\x3e\x28\x2d\xb0\x71\xae\xa6\x6c\x69\x6d\x69\xa6\xe3\xf1\x22\xb3\x4d\x70\x24\xaa\x28\x2a\x84\xc1\x7a\xec\xfc\x3c\x9b\x55\x56\x69\x33\x4e\xc5\x4a\xe4\x63\x93\x2d\x22\xae\x93\x65\x66\x21\xbd\xd2\x62\x0c\x33\x46\x4e\x75\xe9\x02\x24\x2e\xd2\x3f\xe8\x3a\x9c\xcc\x93\x1d\x5d\xed\x9a\xdc\xc3\x40\xa2\x5c\xb4\x26\x9c\xaf\x1e\x79\x01\xf2\x56\x06\xcb\xf2\x7a\xab\xbf\xc5\xd6\xd0\x34\x44\xd6\xf9\xf4\xd7\xab\x6b\xd6\x1c\xed\x1e\x63\xdf\xfa\xce\xee\xdb\x8d\x66\xfb\x04\x64\x30\xd8\x43\x68\xff\x88\x73\xad\x0a\x27\x53\xc8\xb4\x54\xb0\xb0\xfb\x92\xe4\x19\x76\xed\x09\x35\xd5\xac\xc8\x2c\xbd\xfb\xaf\x30\xad\xa5\xb7\x8a\xd9\xb9\x43\x0c\x36\x13\xac\x2a\x01\x22\x22\x8d\xd9\xa5\xc4\x68\x21\xf2\x73\x6e\xc4\xef\xfe\x00\x64\x69\x13\x91\x61\x1f\xf7\x04\x6d\xb0\xdb\x5f\xec\xad\xd6\x9a\x00\x80\xd9\xca\x1c\x79\xb1\xbd\x08\xbd\x72\xeb\xf7\xe3\x94\x2e\xaf\x0b\x17\x24\xf1\x8e\xa8\xee\x60\x75\x01\xbb\xe2\x59\xce\x67\xb9\x38\xe7\x25\x4f\x60\x9e\xfd\x05\x8c\x79\x99\x53\x0a\x8a\x57\x7f\x3e\x98\xf5\x17\xa2\x80\x59\x38\x7c\x6a\xff\xc0\x82\x69\xd6\x82\xf8\x9d\x10\xb3\xa2\xe8\x18\xde\xbb\xf6\xf0\xbc\x11\xe1\xf2\x03\xcf\x24\x5d\x1a\xff\xe6\x86\xf4\x62\x40\x0b\xc6\x09\xc6\xad\x07\x0b\x38\x54\xa5\xf5\xa1\x47\x6d\xad\x2c\x36\xa8\x02\x14\x62\x4d\x92\x8a\x19\x52\x1c\xbb\xa6\x61\x3c\x64\x05\x71\xf8\x44\x97\x92\x29\x42\x9c\x4e\xf2\xd0\xdc\x29\xb6\x32\xa4\x04\xa1\x10\xd7\x1a\x4e\xcf\xbd\x6b\xcf\x33\x01\x04\x2a\xb9\x5d\xb2\xd8\xbf\x6f\xbc\x35\x48\xcc\xd8\x3b\x48\x15\x0f\x48\x5c\xb9\x18\x75\xca\x25\xd3\x62\x95\xaa\x1f\xdb\x2b\xf6\x6f\x37\x35\x1e\x43\xf5\x26\xe4\xdc\x69\x6a\x66\x10\x77\x3e\xa1\x3a\x4c\xec\x14\x39\x57\xea\x89\x69\x6c\xe4\xed\x11\x37\x02\xdf\x4b\xf5\x45\x76\xa9\xea\xf4\xe0\x5a\x4c\x3b\x45\xde\x0e\xdf\x36\x3e\x74\x3b\x1c\xe1\xeb\x47\xad\x16\xd0\x8c\xb2\x1a\x0d\x10\x76\xde\x0e\x2f\xc4\x42\x73\xd8\xf2\x76\xd8\x1c\xf7\x27\x58\x26\x59\x7e\x10\x7a\x21\xde\x8b\xf5\x6b\x3a\xa4\x5b\xfe\xce\xfa\x2b\xab\xa1\xf3\x62\xfd\xba\xa0\x8d\x1b\x59\x94\x81\xaf\x21\xe1\x75\xc1\xcb\x9d\xc1\x0f\xbc\xfc\xba\xf4\x8d\x93\x19\x76\x73\x47\x71\xbb\x9a\xc4\x5b\xc7\xfb\xf9\x17\x03\x57\xbc\x1d\x6e\x2d\x32\x52\x05\xb9\x6f\x69\xd7\xb7\xc3\x4e\xa9\x3b\xaa\x62\xab\x53\x16\x57\xdf\xb9\x32\xc6\x49\x2d\x1a\xd6\xca\xaa\x59\x35\xc7\xc8\x6c\x8d\x78\x1e\x4d\x46\x00\xd4\x11\x25\xf7\xd7\xdb\x53\x6f\x87\x3f\x77\x5f\x41\x36\x37\x56\x70\x04\xed\xfd\xce\xb0\xff\x74\xa9\xd6\x0f\x04\xfe\x27\xe7\xb0\xa3\xe6\x28\xec\x9a\xd2\xaa\x7b\xdd\x5e\x98\x1e\x6e\xa3\xf8\xf1\xe9\xd5\x20\x1a\x68\xc0\x05\x67\x73\x99\x1e\xa1\xf0\xf9\x8d\x14\x8a\x3b\x4a\x19\x14\xe2\xde\x27\x29\x65\x73\xe9\x2e\x19\xd7\xb1\xea\xb3\x3c\x72\xc2\x97\xa5\x38\x22\x14\x47\x57\x88\x64\x9d\xaf\x29\xb1\x25\x5b\x4c\x59\x72\xb9\xa0\x4c\xc2\x2e\x09\x14\xb8\x0b\x7b\xca\x32\xf7\x14\x0b\x23\xda\xd8\x2f\xb5\x32\x4d\x96\x74\xf7\x23\x0d\xdc\x37\xc2\x15\x1f\xfb\xb5\x78\x97\x68\x93\x44\x94\x96\x82\x24\xee\x11\xd8\xc0\x2c\xe5\xb6\x88\x24\xf6\xac\xeb\x49\x37\xed\xc4\x63\x0c\x5f\x3c\xee\xe1\xea\xb5\xbe\x14\x58\x56\x05\x30\x0c\x65\x75\x4a\x7a\x6e\xe7\x60\xad\x84\xdb\xbe\xe3\xbc\x4c\x0f\xc9\x7c\xa6\x2a\x0f\x7e\xdb\x77\xac\x9f\x8a\xaa\x01\xbc\x13\x0e\x70\x81\x53\x5f\xa0\xcf\x18\x05\x7f\xf8\x51\xc8\x85\x5d\x4e\xd9\xcb\x17\x7f\x79\xf5\xdd\xb7\xda\xc2\xa3\xa2\x48\x7f\x10\x52\x68\x07\x8e\x8f\x32\xcb\xe1\xb6\x56\x85\xe3\xee\x17\x37\xe9\x3d\x5e\x6c\xd6\x1c\xf1\xbf\x3a\x25\x6c\x3d\xef\x0b\x12\x86\x11\x28\x67\x50\xba\xa4\xa8\x68\xc8\x4e\x94\x10\x90\xe0\x2c\x97\x09\x6a\xce\x6c\x7e\xda\x21\xd9\x06\xd7\xf3\x35\x9b\xbc\x18\xb1\x59\xfd\x14\x87\x88\x7e\xf3\x70\x17\x1f\x5e\xf1\x98\xe4\xef\x47\x7b\xfa\x63\x8c\x9e\x1a\x89\x86\xfc\x95\x7d\xc9\x90\xe5\x60\x1f\x97\x89\xeb\xca\xfa\x58\x26\xde\xcb\xc6\x62\x73\xef\xaf\x45\x47\x77\x11\x52\x3b\x0d\xfa\xcc\xa2\x2a\xa6\xec\xf9\x51\x77\xe9\xae\x55\xfc\x0f\x9c\xdf\x3c\xd2\x47\xfc\xd2\x6d\x59\xc2\x09\x5c\x91\xe4\x0a\x2a\xc0\x12\x96\xa5\x54\x3b\x02\x07\xf4\x63\x02\x88\x4c\x50\x0b\xa4\x62\x63\xc7\xd6\x48\xd8\x1e\x45\x5b\x21\x85\x1c\x9b\x56\x09\xaa\xec\x5e\x89\xb0\x2b\xbd\x06\x34\x48\x5a\xcf\xe6\x8a\x58\x17\x8b\xbe\xf1\x42\x01\x42\x4f\xb6\x69\x63\x28\x5b\xf7\x8a\x2c\x04\x97\xb8\x84\xa9\x55\xa4\x9a\x9e\x60\xce\xa7\x78\xc0\x9f\xcb\x3e\xae\x91\xab\x65\x69\x77\x0b\x03\x53\x68\xd1\x2f\x96\xb3\x45\xc5\x71\x37\x2b\xa0\x06\xc0\x93\x00\xa3\x96\xd1\x02\x78\xbe\x2d\xf5\xbf\x82\x1d\xcc\x03\x8e\x87\x60\xba\x6a\xdd\x36\x38\xdc\x79\x04\xe0\x4c\x9e\xbf\x38\xe2\x61\x9b\x55\x3d\x4b\x90\xe2\xa9\x77\x9c\xb2\x7f\xde\xbc\x8d\xfe\xc1\xa3\xdf\xee\x9e\xd6\x1f\x9e\x47\xdf\xff\x6b\x34\xbd\x7b\xd6\xfa\x7a\x77\xf6\xe6\x8f\xdf\x0a\x6d\x5d\x2d\x43\x8f\xab\xd6\xe9\xb3\xa9\x90\x1b\x6f\x18\xb9\xdc\x8a\xd1\x6b\x4d\x4d\xee\x3b\x9e\x1b\xfc\xf3\x93\x74\xc9\xaf\xcf\x50\x42\x22\xc2\x7a\xe6\x22\x36\x24\x51\xc3\xfe\x69\x77\x46\xff\x7c\x7d\xf6\xb7\x9a\xc4\x2d\x78\x8c\x41\x5c\x45\x8b\x8b\xb7\xf0\xac\xd5\x4a\x32\x87\xc3\x54\x2b\xc7\x75\x7d\x0e\xec\x2c\xc6\xdb\x56\xb3\xd7\xf1\xa8\x89\xf8\xc0\xe5\x9a\x6d\xc1\xd6\x57\xcf\xfb\x11\x61\x2c\xd5\xdf\x3c\xd1\xca\x98\x4d\x7f\xdd\x1f\xcc\x79\x76\x8f\xba\xa2\x29\xb3\x3d\xb4\xcf\x44\xc2\x5d\xe7\xa1\x67\x19\xa0\x41\xaf\x5b\xed\x16\x4b\x90\x67\xa9\x53\x36\x62\x5e\xe5\xbd\x62\x9f\x1a\x81\xf4\x20\x55\x2a\x0e\x73\xc4\x99\x47\x7c\x3e\xcb\x72\x74\x85\x84\xe9\xa9\xc0\xec\x3c\xcf\x5c\x73\xd4\x9f\x2c\x8a\x52\x69\x40\xb9\xf5\x61\xac\x01\xb5\x0f\x68\xf6\x10\x60\x28\x7d\x61\x02\x44\xe6\xd3\x54\x9a\xc9\xe4\xc5\xcb\xab\x6a\x96\xaa\x02\xe0\xf9\xae\xb0\xe3\xb3\x37\x4f\x7f\xad\x78\x4e\x88\x99\xfe\x0d\x96\xc6\xd8\xd9\x23\x8a\x83\xc9\xab\xaf\xc6\xe1\xd3\x1b\x1f\x6d\x08\xc4\xa8\xfe\xf4\xac\x19\xc2\xa9\xb7\xf1\xd1\xf9\xb3\x67\xa4\x5a\x2b\x86\xef\x6e\xa2\x6d\x00\xc7\x77\xcf\xce\xde\xb4\xe6\xce\xbe\x31\x9c\x89\xe9\x40\x83\x99\x76\x79\x6f\xd4\x51\x5e\x77\x2e\xab\x0b\xb6\xce\x39\x9f\x5c\x3a\xa7\xfc\xd3\x77\x4e\xf5\xb4\x4d\x3d\x24\x46\x7b\xd2\x75\xc2\x07\x73\x0f\x11\xd1\xba\x5a\x0a\x34\x39\x11\xb5\x67\x11\xfa\xb5\xe8\x5e\xac\x3b\x70\xac\xe7\xf4\x43\x11\xfe\x40\x08\x3a\x64\x1f\x28\x33\x0b\xfd\x11\x2d\xf8\x74\x70\xc2\x8b\xa4\x3a\x5b\x89\x93\x76\x2c\x95\xb1\x27\x1f\x43\x81\x47\xae\x7e\xd2\x26\xbc\xd6\x02\xa3\x27\x1f\x66\x95\xe5\xf9\xef\x41\xf2\x00\x63\xd2\xff\xbd\xdc\x4e\x17\x3b\x8c\x92\x68\x43\xb3\x0d\x7a\x77\xfa\x3a\x17\xa0\x8f\xd4\xe4\x07\xac\xd2\xd4\x20\xb1\x39\x65\xa3\x1d\x1e\x7d\x06\x69\x81\x46\x0f\x34\x7a\xa0\xd1\x03\x8d\x1e\x68\xf4\x40\xa3\x07\x1a\x3d\xd0\xe8\x81\x46\x0f\x34\x7a\xa0\xd1\x03\x8d\x1e\x68\xf4\x40\xa3\x07\x1a\x3d\xd0\xe8\x81\x46\x0f\x34\x7a\xa0\xd1\x03\x8d\x1e\x68\xf4\x40\xa3\x07\x1a\xbd\x97\x46\x7f\x11\x68\xf4\x40\xa3\x07\x1a\x3d\xd0\xe8\x81\x46\x0f\x34\x7a\xa0\xd1\x03\x8d\x1e\x68\xf4\x40\xa3\x07\x1a\x3d\xd0\xe8\x81\x46\x0f\x34\x7a\xa0\xd1\x03\x8d\x1e\x68\xf4\x40\xa3\x07\x1a\x3d\xd0\xe8\x81\x46\x0f\x34\x7a\xa0\xd1\xfb\x68\xf4\x97\x81\x46\x0f\x34\x7a\xa0\xd1\x03\x8d\x1e\x68\xf4\x40\xa3\x07\x1a\x3d\xd0\xe8\x81\x46\x0f\x34\x7a\xa0\xd1\x03\x8d\x1e\x68\xf4\x40\xa3\x07\x1a\x3d\xd0\xe8\x81\x46\x0f\x34\x7a\xa0\xd1\x03\x8d\x1e\x68\xf4\xe3\x2f\x42\x3b\x11\x9f\x57\x2e\x2a\x0f\x77\xee\x40\x89\xe7\x63\xce\xdb\x3b\x36\xb4\x0c\x05\xd0\x86\x3a\x13\x6c\xb5\xfd\x6f\xc0\x0f\x0a\xac\x52\x95\x15\x51\x91\xbe\xe9\x39\x44\x98\xe3\x8d\xdb\x21\xdd\xf7\x58\x5d\x1d\x11\xd8\xe8\x4b\x62\x08\x16\x7b\x15\x6d\x19\xa7\xbe\x58\x3c\xf8\x06\x6f\x97\xbc\xaf\xaf\x3c\xba\xb1\x3f\x48\x22\xa7\x7a\xc7\x30\x9d\x34\x38\xc1\x7d\xff\x2f\x7f\x81\xc2\xf5\x42\xd8\x8b\xee\x9b\xed\xf8\xc7\xf5\x76\x65\xd3\xbf\x3b\x83\x30\xe5\x3b\x5b\x03\x95\x9d\xee\x5f\x75\xe7\x9a\xb9\x85\x3b\x23\x71\x20\x11\x15\x6a\xe5\x3e\xc7\xa7\x2b\x4e\xf7\x3d\x85\x3f\x3c\xea\x42\xa7\x81\x53\xbd\xda\xaa\x22\x4b\xf6\x75\x5b\x6a\x65\x6d\x2e\xd2\x8f\x2a\xed\x50\x83\xa7\x3e\x8d\xf2\xfc\xe3\xd1\xc0\x3d\xaa\xec\xee\xdb\xb4\x0f\x24\xb4\x03\x66\x54\x33\xa8\xb9\xac\xed\xe4\x98\xc6\xcd\xaf\x35\x7e\xba\xbc\xe8\x82\xf4\xba\x04\x2c\x49\xc6\x92\xaf\x88\xc0\xb8\x1c\xff\x1d\xf5\x85\xe3\xdb\xd1\x90\xc6\xa7\x44\x4b\xf8\xd5\xdc\xe1\xaf\xe6\xdc\xc8\xb6\x36\xf7\xbc\x8f\x2f\x69\x76\xfe\x38\xc4\x70\xb8\xf3\xd7\x1e\xdc\xd7\x16\x5f\xce\x6e\xee\x06\x5e\xaa\x48\x3f\x37\x7f\xc7\x81\x06\xff\x0b\x0b\x78\x42\x46\x61\x63\x00\x00")
 
 func config_crd_direct_csi_min_io_directcsivolumes_yaml() ([]byte, error) {
 	return bindata_read(
@@ -81,8 +90,9 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() ([]byte, error){
-	"config/crd/direct.csi.min.io_directcsidrives.yaml":  config_crd_direct_csi_min_io_directcsidrives_yaml,
-	"config/crd/direct.csi.min.io_directcsivolumes.yaml": config_crd_direct_csi_min_io_directcsivolumes_yaml,
+	"config/crd/direct.csi.min.io_directcsidrives.yaml":    config_crd_direct_csi_min_io_directcsidrives_yaml,
+	"config/crd/direct.csi.min.io_directcsisnapshots.yaml": config_crd_direct_csi_min_io_directcsisnapshots_yaml,
+	"config/crd/direct.csi.min.io_directcsivolumes.yaml":   config_crd_direct_csi_min_io_directcsivolumes_yaml,
 }
 
 // AssetDir returns the file names below a certain
@@ -128,8 +138,9 @@ type _bintree_t struct {
 var _bintree = &_bintree_t{nil, map[string]*_bintree_t{
 	"config": {nil, map[string]*_bintree_t{
 		"crd": {nil, map[string]*_bintree_t{
-			"direct.csi.min.io_directcsidrives.yaml":  {config_crd_direct_csi_min_io_directcsidrives_yaml, map[string]*_bintree_t{}},
-			"direct.csi.min.io_directcsivolumes.yaml": {config_crd_direct_csi_min_io_directcsivolumes_yaml, map[string]*_bintree_t{}},
+			"direct.csi.min.io_directcsidrives.yaml":    {config_crd_direct_csi_min_io_directcsidrives_yaml, map[string]*_bintree_t{}},
+			"direct.csi.min.io_directcsisnapshots.yaml": {config_crd_direct_csi_min_io_directcsisnapshots_yaml, map[string]*_bintree_t{}},
+			"direct.csi.min.io_directcsivolumes.yaml":   {config_crd_direct_csi_min_io_directcsivolumes_yaml, map[string]*_bintree_t{}},
 		}},
 	}},
 }}
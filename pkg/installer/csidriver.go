@@ -37,6 +37,7 @@ var (
 func createCSIDriver(ctx context.Context, c *Config) error {
 	podInfoOnMount := true
 	attachRequired := false
+	storageCapacity := true
 
 	gvk, err := client.GetGroupKindVersions("storage.k8s.io", "CSIDriver", "v1", "v1beta1", "v1alpha1")
 	if err != nil {
@@ -58,8 +59,9 @@ func createCSIDriver(ctx context.Context, c *Config) error {
 				Labels:      defaultLabels,
 			},
 			Spec: storagev1.CSIDriverSpec{
-				PodInfoOnMount: &podInfoOnMount,
-				AttachRequired: &attachRequired,
+				PodInfoOnMount:  &podInfoOnMount,
+				AttachRequired:  &attachRequired,
+				StorageCapacity: &storageCapacity,
 				VolumeLifecycleModes: []storagev1.VolumeLifecycleMode{
 					storagev1.VolumeLifecyclePersistent,
 					storagev1.VolumeLifecycleEphemeral,
@@ -91,8 +93,9 @@ func createCSIDriver(ctx context.Context, c *Config) error {
 				Labels:      defaultLabels,
 			},
 			Spec: storagev1beta1.CSIDriverSpec{
-				PodInfoOnMount: &podInfoOnMount,
-				AttachRequired: &attachRequired,
+				PodInfoOnMount:  &podInfoOnMount,
+				AttachRequired:  &attachRequired,
+				StorageCapacity: &storageCapacity,
 				VolumeLifecycleModes: []storagev1beta1.VolumeLifecycleMode{
 					storagev1beta1.VolumeLifecyclePersistent,
 					storagev1beta1.VolumeLifecycleEphemeral,
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/utils"
@@ -148,6 +149,18 @@ func createDaemonSet(ctx context.Context, c *Config) error {
 					if c.DynamicDriveDiscovery {
 						args = append(args, "--dynamic-drive-discovery")
 					}
+					if c.AutoAccessTier {
+						args = append(args, "--auto-access-tier")
+					}
+					if c.HealthCheckInterval > 0 {
+						args = append(args, fmt.Sprintf("--health-check-interval=%v", c.HealthCheckInterval))
+					}
+					if c.RackLabel != "" {
+						args = append(args, fmt.Sprintf("--rack-label=%v", c.RackLabel))
+					}
+					if len(c.TopologyKeys) != 0 {
+						args = append(args, fmt.Sprintf("--topology-keys=%v", strings.Join(c.TopologyKeys, ",")))
+					}
 					return args
 				}(),
 				SecurityContext: securityContext,
@@ -139,12 +139,33 @@ func createDeployment(ctx context.Context, c *Config) error {
 					"--leader-election",
 					"--feature-gates=Topology=true",
 					"--strict-topology",
+					"--enable-capacity",
+					"--capacity-ownerref-level=2",
+					"--extra-create-metadata",
 				},
 				Env: []corev1.EnvVar{
 					{
 						Name:  endpointEnvVarCSI,
 						Value: "unix:///csi/csi.sock",
 					},
+					{
+						Name: "NAMESPACE",
+						ValueFrom: &corev1.EnvVarSource{
+							FieldRef: &corev1.ObjectFieldSelector{
+								APIVersion: "v1",
+								FieldPath:  "metadata.namespace",
+							},
+						},
+					},
+					{
+						Name: "POD_NAME",
+						ValueFrom: &corev1.EnvVarSource{
+							FieldRef: &corev1.ObjectFieldSelector{
+								APIVersion: "v1",
+								FieldPath:  "metadata.name",
+							},
+						},
+					},
 				},
 				VolumeMounts: []corev1.VolumeMount{
 					newVolumeMount(volumeNameSocketDir, "/csi", false, false),
@@ -168,6 +189,54 @@ func createDeployment(ctx context.Context, c *Config) error {
 					Privileged: &privileged,
 				},
 			},
+			{
+				Name:  csiResizerContainerName,
+				Image: filepath.Join(c.DirectCSIContainerRegistry, c.DirectCSIContainerOrg, c.getCSIResizerImage()),
+				Args: []string{
+					fmt.Sprintf("--v=%d", logLevel),
+					"--timeout=300s",
+					fmt.Sprintf("--csi-address=$(%s)", endpointEnvVarCSI),
+					"--leader-election",
+				},
+				Env: []corev1.EnvVar{
+					{
+						Name:  endpointEnvVarCSI,
+						Value: "unix:///csi/csi.sock",
+					},
+				},
+				VolumeMounts: []corev1.VolumeMount{
+					newVolumeMount(volumeNameSocketDir, "/csi", false, false),
+				},
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				TerminationMessagePath:   "/var/log/controller-csi-resizer-termination-log",
+				SecurityContext: &corev1.SecurityContext{
+					Privileged: &privileged,
+				},
+			},
+			{
+				Name:  csiSnapshotterContainerName,
+				Image: filepath.Join(c.DirectCSIContainerRegistry, c.DirectCSIContainerOrg, c.getCSISnapshotterImage()),
+				Args: []string{
+					fmt.Sprintf("--v=%d", logLevel),
+					"--timeout=300s",
+					fmt.Sprintf("--csi-address=$(%s)", endpointEnvVarCSI),
+					"--leader-election",
+				},
+				Env: []corev1.EnvVar{
+					{
+						Name:  endpointEnvVarCSI,
+						Value: "unix:///csi/csi.sock",
+					},
+				},
+				VolumeMounts: []corev1.VolumeMount{
+					newVolumeMount(volumeNameSocketDir, "/csi", false, false),
+				},
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				TerminationMessagePath:   "/var/log/controller-csi-snapshotter-termination-log",
+				SecurityContext: &corev1.SecurityContext{
+					Privileged: &privileged,
+				},
+			},
 			{
 				Name:  directCSIContainerName,
 				Image: filepath.Join(c.DirectCSIContainerRegistry, c.DirectCSIContainerOrg, c.DirectCSIContainerImage),
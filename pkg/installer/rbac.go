@@ -121,6 +121,8 @@ func createClusterRole(ctx context.Context, c *Config) error {
 					clusterRoleVerbWatch,
 					clusterRoleVerbCreate,
 					clusterRoleVerbDelete,
+					clusterRoleVerbUpdate,
+					clusterRoleVerbPatch,
 				},
 				Resources: []string{
 					"persistentvolumes",
@@ -143,6 +145,17 @@ func createClusterRole(ctx context.Context, c *Config) error {
 					"",
 				},
 			},
+			{
+				Verbs: []string{
+					clusterRoleVerbPatch,
+				},
+				Resources: []string{
+					"persistentvolumeclaims/status",
+				},
+				APIGroups: []string{
+					"",
+				},
+			},
 			{
 				Verbs: []string{
 					clusterRoleVerbGet,
@@ -234,6 +247,89 @@ func createClusterRole(ctx context.Context, c *Config) error {
 					"storage.k8s.io",
 				},
 			},
+			{
+				Verbs: []string{
+					clusterRoleVerbGet,
+					clusterRoleVerbList,
+					clusterRoleVerbWatch,
+					clusterRoleVerbCreate,
+					clusterRoleVerbUpdate,
+					clusterRoleVerbPatch,
+					clusterRoleVerbDelete,
+				},
+				Resources: []string{
+					"csistoragecapacities",
+				},
+				APIGroups: []string{
+					"storage.k8s.io",
+				},
+			},
+			{
+				Verbs: []string{
+					clusterRoleVerbGet,
+					clusterRoleVerbList,
+					clusterRoleVerbWatch,
+				},
+				Resources: []string{
+					"volumesnapshotclasses",
+				},
+				APIGroups: []string{
+					"snapshot.storage.k8s.io",
+				},
+			},
+			{
+				Verbs: []string{
+					clusterRoleVerbGet,
+					clusterRoleVerbList,
+					clusterRoleVerbWatch,
+					clusterRoleVerbCreate,
+					clusterRoleVerbUpdate,
+					clusterRoleVerbPatch,
+					clusterRoleVerbDelete,
+				},
+				Resources: []string{
+					"volumesnapshotcontents",
+				},
+				APIGroups: []string{
+					"snapshot.storage.k8s.io",
+				},
+			},
+			{
+				Verbs: []string{
+					clusterRoleVerbUpdate,
+					clusterRoleVerbPatch,
+				},
+				Resources: []string{
+					"volumesnapshotcontents/status",
+				},
+				APIGroups: []string{
+					"snapshot.storage.k8s.io",
+				},
+			},
+			{
+				Verbs: []string{
+					clusterRoleVerbGet,
+				},
+				Resources: []string{
+					"replicasets",
+					"deployments",
+				},
+				APIGroups: []string{
+					"apps",
+				},
+			},
+			{
+				Verbs: []string{
+					clusterRoleVerbGet,
+					clusterRoleVerbList,
+				},
+				Resources: []string{
+					"statefulsets",
+				},
+				APIGroups: []string{
+					"apps",
+				},
+			},
 			{
 				Verbs: []string{
 					clusterRoleVerbGet,
@@ -295,7 +391,7 @@ func createClusterRole(ctx context.Context, c *Config) error {
 					clusterRoleVerbDelete,
 				},
 				Resources: []string{
-					"directcsidrives", "directcsivolumes",
+					"directcsidrives", "directcsivolumes", "directcsisnapshots",
 				},
 				APIGroups: []string{
 					"direct.csi.min.io",
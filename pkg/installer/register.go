@@ -146,6 +146,13 @@ func syncCRD(ctx context.Context, existingCRD *apiextensions.CustomResourceDefin
 }
 
 func setConversionWebhook(ctx context.Context, crdObj *apiextensions.CustomResourceDefinition, c *Config) error {
+	if crdObj.Name == snapshotCRDName {
+		// Snapshots are served in a single version; no conversion is required.
+		crdObj.Spec.Conversion = &apiextensions.CustomResourceConversion{
+			Strategy: apiextensions.NoneConverter,
+		}
+		return nil
+	}
 
 	getServiceRef := func() *apiextensions.ServiceReference {
 		path := func() string {
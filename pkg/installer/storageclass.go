@@ -64,7 +64,7 @@ func uninstallStorageClassDefault(ctx context.Context, c *Config) error {
 }
 
 func createStorageClass(ctx context.Context, c *Config, name string) error {
-	allowExpansion := false
+	allowExpansion := true
 	allowTopologiesWithName := corev1.TopologySelectorTerm{
 		MatchLabelExpressions: []corev1.TopologySelectorLabelRequirement{
 			{
@@ -0,0 +1,215 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package iothrottle
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// I/O limit parameters accepted in storage class parameters and PVC annotations.
+const (
+	ReadBPSParameter   = "direct-csi-min-io/read-bps"
+	WriteBPSParameter  = "direct-csi-min-io/write-bps"
+	ReadIOPSParameter  = "direct-csi-min-io/read-iops"
+	WriteIOPSParameter = "direct-csi-min-io/write-iops"
+)
+
+// Parameters is the list of I/O limit parameters.
+var Parameters = []string{ReadBPSParameter, WriteBPSParameter, ReadIOPSParameter, WriteIOPSParameter}
+
+var (
+	cgroupRoot      = "/sys/fs/cgroup"
+	sysDevBlockRoot = "/sys/dev/block"
+
+	// ErrCgroupNotFound denotes cgroup of the pod is not found.
+	ErrCgroupNotFound = errors.New("pod cgroup not found")
+)
+
+// Limits denotes I/O limits of a volume; zero value of a limit means unlimited.
+type Limits struct {
+	ReadBPS   uint64
+	WriteBPS  uint64
+	ReadIOPS  uint64
+	WriteIOPS uint64
+}
+
+// IsZero returns whether no limit is set.
+func (limits Limits) IsZero() bool {
+	return limits == Limits{}
+}
+
+func formatLimit(value uint64) string {
+	if value == 0 {
+		return "max"
+	}
+	return strconv.FormatUint(value, 10)
+}
+
+// format returns io.max entry of the limits for the device.
+func (limits Limits) format(major, minor uint32) string {
+	return fmt.Sprintf("%v:%v rbps=%v wbps=%v riops=%v wiops=%v",
+		major, minor,
+		formatLimit(limits.ReadBPS),
+		formatLimit(limits.WriteBPS),
+		formatLimit(limits.ReadIOPS),
+		formatLimit(limits.WriteIOPS),
+	)
+}
+
+func parseBPS(key, value string) (uint64, error) {
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %v value %v; %w", key, value, err)
+	}
+	bps, ok := quantity.AsInt64()
+	if !ok || bps < 0 {
+		return 0, fmt.Errorf("invalid %v value %v; must be a non-negative integer", key, value)
+	}
+	return uint64(bps), nil
+}
+
+func parseIOPS(key, value string) (uint64, error) {
+	iops, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %v value %v; %w", key, value, err)
+	}
+	return iops, nil
+}
+
+// ParseLimits parses I/O limits from given parameters.
+func ParseLimits(parameters map[string]string) (limits Limits, err error) {
+	for key, value := range parameters {
+		switch key {
+		case ReadBPSParameter:
+			limits.ReadBPS, err = parseBPS(key, value)
+		case WriteBPSParameter:
+			limits.WriteBPS, err = parseBPS(key, value)
+		case ReadIOPSParameter:
+			limits.ReadIOPS, err = parseIOPS(key, value)
+		case WriteIOPSParameter:
+			limits.WriteIOPS, err = parseIOPS(key, value)
+		}
+		if err != nil {
+			return Limits{}, err
+		}
+	}
+	return limits, nil
+}
+
+// findPodCgroup searches kubepods hierarchy under dir for the cgroup of the pod.
+// Both cgroupfs (pod<uid>) and systemd (kubepods-<qos>-pod<uid_with_underscores>.slice) layouts are supported.
+func findPodCgroup(dir, podUID string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	podNames := []string{"pod" + podUID, "pod" + strings.ReplaceAll(podUID, "-", "_")}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		for _, podName := range podNames {
+			if entry.Name() == podName || strings.HasSuffix(entry.Name(), "-"+podName+".slice") {
+				return filepath.Join(dir, entry.Name()), nil
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		switch {
+		case strings.HasPrefix(name, "kubepods") && !strings.Contains(name, "-pod"), name == "burstable", name == "besteffort":
+			// Descend into kubepods and QoS class cgroups only.
+			path, err := findPodCgroup(filepath.Join(dir, entry.Name()), podUID)
+			if !errors.Is(err, ErrCgroupNotFound) {
+				return path, err
+			}
+		}
+	}
+
+	return "", ErrCgroupNotFound
+}
+
+func getPodCgroup(podUID string) (string, error) {
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+		return "", fmt.Errorf("cgroup v2 is not available at %v; %w", cgroupRoot, err)
+	}
+	return findPodCgroup(cgroupRoot, podUID)
+}
+
+// getDiskMajorMinor returns major/minor number of the disk of given partition;
+// io.max accepts whole disks only.
+func getDiskMajorMinor(major, minor uint32) (uint32, uint32, error) {
+	devDir := filepath.Join(sysDevBlockRoot, fmt.Sprintf("%v:%v", major, minor))
+	if _, err := os.Stat(filepath.Join(devDir, "partition")); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return major, minor, nil
+		}
+		return 0, 0, err
+	}
+
+	// Partition directory is a subdirectory of its disk directory in sysfs.
+	partitionDir, err := filepath.EvalSymlinks(devDir)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(partitionDir), "dev"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var diskMajor, diskMinor uint32
+	if _, err := fmt.Sscanf(strings.TrimSpace(string(data)), "%d:%d", &diskMajor, &diskMinor); err != nil {
+		return 0, 0, fmt.Errorf("unable to parse major/minor of disk of %v:%v; %w", major, minor, err)
+	}
+	return diskMajor, diskMinor, nil
+}
+
+func writeIOMax(podUID string, major, minor uint32, limits Limits) error {
+	cgroupPath, err := getPodCgroup(podUID)
+	if err != nil {
+		return err
+	}
+
+	if major, minor, err = getDiskMajorMinor(major, minor); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(cgroupPath, "io.max"), []byte(limits.format(major, minor)), 0644)
+}
+
+// SetLimits applies I/O limits on the device to the cgroup of the pod.
+func SetLimits(podUID string, major, minor uint32, limits Limits) error {
+	return writeIOMax(podUID, major, minor, limits)
+}
+
+// ClearLimits removes I/O limits on the device from the cgroup of the pod.
+func ClearLimits(podUID string, major, minor uint32) error {
+	return writeIOMax(podUID, major, minor, Limits{})
+}
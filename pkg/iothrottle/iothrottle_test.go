@@ -0,0 +1,188 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package iothrottle
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseLimits(t *testing.T) {
+	testCases := []struct {
+		parameters     map[string]string
+		expectedLimits Limits
+		expectErr      bool
+	}{
+		{nil, Limits{}, false},
+		{map[string]string{"direct-csi-min-io/access-tier": "hot"}, Limits{}, false},
+		{
+			map[string]string{
+				ReadBPSParameter:   "100Mi",
+				WriteBPSParameter:  "1048576",
+				ReadIOPSParameter:  "1000",
+				WriteIOPSParameter: "500",
+			},
+			Limits{ReadBPS: 100 * 1024 * 1024, WriteBPS: 1048576, ReadIOPS: 1000, WriteIOPS: 500},
+			false,
+		},
+		{map[string]string{WriteBPSParameter: "10M"}, Limits{WriteBPS: 10000000}, false},
+		{map[string]string{ReadBPSParameter: "fast"}, Limits{}, true},
+		{map[string]string{ReadBPSParameter: "-1Mi"}, Limits{}, true},
+		{map[string]string{ReadIOPSParameter: "1k"}, Limits{}, true},
+		{map[string]string{WriteIOPSParameter: "-10"}, Limits{}, true},
+	}
+
+	for i, testCase := range testCases {
+		limits, err := ParseLimits(testCase.parameters)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if limits != testCase.expectedLimits {
+			t.Fatalf("case %v: limits: expected: %+v, got: %+v", i+1, testCase.expectedLimits, limits)
+		}
+	}
+}
+
+func TestLimitsFormat(t *testing.T) {
+	testCases := []struct {
+		limits         Limits
+		expectedResult string
+	}{
+		{Limits{}, "8:16 rbps=max wbps=max riops=max wiops=max"},
+		{Limits{ReadBPS: 1048576, WriteIOPS: 100}, "8:16 rbps=1048576 wbps=max riops=max wiops=100"},
+	}
+
+	for i, testCase := range testCases {
+		if result := testCase.limits.format(8, 16); result != testCase.expectedResult {
+			t.Fatalf("case %v: result: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestFindPodCgroup(t *testing.T) {
+	root := t.TempDir()
+	dirs := []string{
+		"system.slice/pod1234.slice",
+		"kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod0a1b2c3d_4e5f_6789_abcd_ef0123456789.slice",
+		"kubepods.slice/kubepods-pod11111111_2222_3333_4444_555555555555.slice",
+		"kubepods/besteffort/pod66666666-7777-8888-9999-000000000000",
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		podUID       string
+		expectedPath string
+	}{
+		{"0a1b2c3d-4e5f-6789-abcd-ef0123456789", filepath.Join(root, dirs[1])},
+		{"11111111-2222-3333-4444-555555555555", filepath.Join(root, dirs[2])},
+		{"66666666-7777-8888-9999-000000000000", filepath.Join(root, dirs[3])},
+		{"1234", ""},
+		{"aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee", ""},
+	}
+
+	for i, testCase := range testCases {
+		path, err := findPodCgroup(root, testCase.podUID)
+		if testCase.expectedPath == "" {
+			if !errors.Is(err, ErrCgroupNotFound) {
+				t.Fatalf("case %v: expected ErrCgroupNotFound, got: %v, %v", i+1, path, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if path != testCase.expectedPath {
+			t.Fatalf("case %v: path: expected: %v, got: %v", i+1, testCase.expectedPath, path)
+		}
+	}
+}
+
+func TestSetLimits(t *testing.T) {
+	root := t.TempDir()
+
+	cgroupRoot = filepath.Join(root, "cgroup")
+	sysDevBlockRoot = filepath.Join(root, "dev", "block")
+	defer func() {
+		cgroupRoot = "/sys/fs/cgroup"
+		sysDevBlockRoot = "/sys/dev/block"
+	}()
+
+	podUID := "0a1b2c3d-4e5f-6789-abcd-ef0123456789"
+	podCgroup := filepath.Join(cgroupRoot, "kubepods", "pod"+podUID)
+	diskDir := filepath.Join(root, "devices", "sda")
+	for _, dir := range []string{podCgroup, filepath.Join(diskDir, "sda1"), sysDevBlockRoot} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{
+		filepath.Join(cgroupRoot, "cgroup.controllers"): "cpu io memory pids\n",
+		filepath.Join(diskDir, "dev"):                   "8:0\n",
+		filepath.Join(diskDir, "sda1", "dev"):           "8:1\n",
+		filepath.Join(diskDir, "sda1", "partition"):     "1\n",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(diskDir, filepath.Join(sysDevBlockRoot, "8:0")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(diskDir, "sda1"), filepath.Join(sysDevBlockRoot, "8:1")); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		major, minor uint32
+		limits       Limits
+		expectedData string
+	}{
+		{8, 0, Limits{ReadBPS: 1024}, "8:0 rbps=1024 wbps=max riops=max wiops=max"},
+		{8, 1, Limits{WriteIOPS: 10}, "8:0 rbps=max wbps=max riops=max wiops=10"},
+		{8, 1, Limits{}, "8:0 rbps=max wbps=max riops=max wiops=max"},
+	}
+
+	for i, testCase := range testCases {
+		if err := SetLimits(podUID, testCase.major, testCase.minor, testCase.limits); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		data, err := os.ReadFile(filepath.Join(podCgroup, "io.max"))
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if string(data) != testCase.expectedData {
+			t.Fatalf("case %v: io.max: expected: %v, got: %v", i+1, testCase.expectedData, string(data))
+		}
+	}
+
+	if err := ClearLimits("aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee", 8, 0); !errors.Is(err, ErrCgroupNotFound) {
+		t.Fatalf("expected ErrCgroupNotFound, got: %v", err)
+	}
+}
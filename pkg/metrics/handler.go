@@ -20,6 +20,7 @@ import (
 	"context"
 	"net/http"
 
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
 	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/utils"
 
@@ -51,6 +52,7 @@ func (c *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *metricsCollector) Collect(ch chan<- prometheus.Metric) {
 	c.volumeStatsEmitter(context.Background(), ch, c.getxfsVolumeStats)
+	c.driveStatsEmitter(context.Background(), ch, getDriveIOStats, getDriveHealth)
 }
 
 func (c *metricsCollector) volumeStatsEmitter(
@@ -84,6 +86,39 @@ func (c *metricsCollector) volumeStatsEmitter(
 	}
 }
 
+func (c *metricsCollector) driveStatsEmitter(
+	ctx context.Context,
+	ch chan<- prometheus.Metric,
+	ioStatsGetter driveIOStatsGetter,
+	healthGetter driveHealthGetter) {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	resultCh, err := client.ListDrives(
+		ctx,
+		[]utils.LabelValue{utils.NewLabelValue(c.nodeID)},
+		nil,
+		nil,
+		client.MaxThreadCount,
+	)
+	if err != nil {
+		klog.V(3).Infof("Error while listing DirectCSI Drives: %v", err)
+		return
+	}
+
+	for result := range resultCh {
+		if result.Err != nil {
+			return
+		}
+
+		if result.Drive.Status.DriveStatus != directcsi.DriveStatusTerminating {
+			publishDriveStats(&result.Drive, ch)
+			publishDriveIOStats(&result.Drive, ch, ioStatsGetter)
+			publishDriveHealth(&result.Drive, ch, healthGetter)
+		}
+	}
+}
+
 func metricsHandler(nodeID string) http.Handler {
 
 	registry := prometheus.NewRegistry()
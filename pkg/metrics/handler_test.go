@@ -26,6 +26,8 @@ import (
 	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
 	"github.com/minio/directpv/pkg/client"
 	fakedirect "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/sys"
+	"github.com/minio/directpv/pkg/sys/smart"
 	"github.com/minio/directpv/pkg/utils"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -181,3 +183,107 @@ func TestVolumeStatsEmitter(t *testing.T) {
 	wg.Wait()
 	cancel()
 }
+
+func TestDriveStatsEmitter(t *testing.T) {
+	createTestDrive := func(name string, driveStatus directcsi.DriveStatus) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					string(utils.NodeLabelKey): testNodeName,
+				},
+				Finalizers: []string{
+					directcsi.DirectCSIDriveFinalizerDataProtection,
+					directcsi.DirectCSIDriveFinalizerPrefix + "volume-1",
+					directcsi.DirectCSIDriveFinalizerPrefix + "volume-2",
+				},
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:          testNodeName,
+				DriveStatus:       driveStatus,
+				AccessTier:        directcsi.AccessTierHot,
+				TotalCapacity:     mb30,
+				FreeCapacity:      mb10,
+				AllocatedCapacity: mb20,
+				ReadOnly:          true,
+				Conditions: []metav1.Condition{
+					{Type: string(directcsi.DirectCSIDriveConditionMounted), Status: metav1.ConditionTrue},
+				},
+			},
+		}
+	}
+
+	testIOStats := &sys.IOStats{ReadIOs: 11, WriteIOs: 12, ReadSectors: 13, WriteSectors: 14, IOTicks: 15, TimeInQueue: 16, InFlight: 17}
+	testIOStatsGetter := func(drive *directcsi.DirectCSIDrive) (*sys.IOStats, error) {
+		return testIOStats, nil
+	}
+
+	testHealth := &smart.Health{CriticalWarning: 1, MediaErrors: 2, ReallocatedSectors: 3, PercentageUsed: 4, Temperature: 5}
+	testHealthGetter := func(drive *directcsi.DirectCSIDrive) (*smart.Health, error) {
+		return testHealth, nil
+	}
+
+	testObjects := []runtime.Object{
+		createTestDrive("drive-1", directcsi.DriveStatusInUse),
+		createTestDrive("drive-2", directcsi.DriveStatusInUse),
+		createTestDrive("drive-3", directcsi.DriveStatusTerminating),
+	}
+	client.SetLatestDirectCSIDriveInterface(fakedirect.NewSimpleClientset(testObjects...).DirectV1beta3().DirectCSIDrives())
+
+	expectedValues := map[string]float64{
+		"directcsi_drive_bytes_total":                         mb30,
+		"directcsi_drive_bytes_free":                          mb10,
+		"directcsi_drive_bytes_allocated":                     mb20,
+		"directcsi_drive_volumes":                             2,
+		"directcsi_drive_mounted":                             1,
+		"directcsi_drive_read_only":                           1,
+		"directcsi_drive_io_reads_completed_total":            11,
+		"directcsi_drive_io_writes_completed_total":           12,
+		"directcsi_drive_io_read_sectors_total":               13,
+		"directcsi_drive_io_written_sectors_total":            14,
+		"directcsi_drive_io_time_milliseconds_total":          15,
+		"directcsi_drive_io_time_weighted_milliseconds_total": 16,
+		"directcsi_drive_io_in_flight":                        17,
+		"directcsi_drive_health_critical_warning":             1,
+		"directcsi_drive_health_media_errors":                 2,
+		"directcsi_drive_health_reallocated_sectors":          3,
+		"directcsi_drive_health_percentage_used":              4,
+		"directcsi_drive_health_temperature_celsius":          5,
+	}
+
+	metricChan := make(chan prometheus.Metric)
+	go func() {
+		defer close(metricChan)
+		createFakeMetricsCollector().driveStatsEmitter(context.TODO(), metricChan, testIOStatsGetter, testHealthGetter)
+	}()
+
+	noOfMetricsReceived := 0
+	for metric := range metricChan {
+		metricOut := dto.Metric{}
+		if err := metric.Write(&metricOut); err != nil {
+			t.Fatal(err)
+		}
+		fqName := getFQNameFromDesc(metric.Desc().String())
+		value, found := expectedValues[fqName]
+		if !found {
+			t.Fatalf("unexpected metric %v", fqName)
+		}
+		var got float64
+		switch {
+		case metricOut.Gauge != nil:
+			got = metricOut.Gauge.GetValue()
+		case metricOut.Counter != nil:
+			got = metricOut.Counter.GetValue()
+		}
+		if value != got {
+			t.Fatalf("metric %v: expected: %v, got: %v", fqName, value, got)
+		}
+		noOfMetricsReceived++
+	}
+
+	// Terminating drive must not be published.
+	if expected := 2 * len(expectedValues); noOfMetricsReceived != expected {
+		t.Fatalf("number of metrics: expected: %v, got: %v", expected, noOfMetricsReceived)
+	}
+}
@@ -23,7 +23,10 @@ import (
 	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/fs/xfs"
 	"github.com/minio/directpv/pkg/sys"
+	"github.com/minio/directpv/pkg/sys/smart"
 	"github.com/minio/directpv/pkg/utils"
+	"github.com/minio/directpv/pkg/volume"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/klog/v2"
@@ -102,3 +105,104 @@ func publishVolumeStats(ctx context.Context, vol *directcsi.DirectCSIVolume, ch
 		float64(volStats.TotalBytes), string(tenantName), vol.Name, vol.Status.NodeName,
 	)
 }
+
+func boolToFloat64(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+func publishDriveStats(drive *directcsi.DirectCSIDrive, ch chan<- prometheus.Metric) {
+	publish := func(name, help string, value float64) {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("directcsi", "drive", name),
+				help,
+				[]string{"drive", "node", "path", "status", "access_tier"}, nil),
+			prometheus.GaugeValue,
+			value, drive.Name, drive.Status.NodeName, drive.Status.Path, string(drive.Status.DriveStatus), string(drive.Status.AccessTier),
+		)
+	}
+
+	mounted := meta.IsStatusConditionTrue(drive.Status.Conditions, string(directcsi.DirectCSIDriveConditionMounted))
+
+	publish("bytes_total", "Total number of bytes of the drive", float64(drive.Status.TotalCapacity))
+	publish("bytes_free", "Total number of bytes free in the drive", float64(drive.Status.FreeCapacity))
+	publish("bytes_allocated", "Total number of bytes allocated to the volumes in the drive", float64(drive.Status.AllocatedCapacity))
+	publish("volumes", "Number of volumes placed in the drive", float64(len(volume.GetVolumeNames(drive))))
+	publish("mounted", "Whether the drive is mounted (1) or not (0)", boolToFloat64(mounted))
+	publish("read_only", "Whether the drive is read-only (1) or not (0)", boolToFloat64(drive.Status.ReadOnly))
+}
+
+type driveIOStatsGetter func(*directcsi.DirectCSIDrive) (*sys.IOStats, error)
+
+func getDriveIOStats(drive *directcsi.DirectCSIDrive) (*sys.IOStats, error) {
+	device, err := sys.GetDeviceName(drive.Status.MajorNumber, drive.Status.MinorNumber)
+	if err != nil {
+		return nil, err
+	}
+	return sys.GetIOStats(device)
+}
+
+func publishDriveIOStats(drive *directcsi.DirectCSIDrive, ch chan<- prometheus.Metric, ioStatsFn driveIOStatsGetter) {
+	ioStats, err := ioStatsFn(drive)
+	if err != nil {
+		klog.V(3).Infof("Error while getting drive I/O stats: %v", err)
+		return
+	}
+
+	publish := func(name, help string, valueType prometheus.ValueType, value float64) {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("directcsi", "drive_io", name),
+				help,
+				[]string{"drive", "node"}, nil),
+			valueType,
+			value, drive.Name, drive.Status.NodeName,
+		)
+	}
+
+	publish("reads_completed_total", "Total number of reads completed by the drive", prometheus.CounterValue, float64(ioStats.ReadIOs))
+	publish("writes_completed_total", "Total number of writes completed by the drive", prometheus.CounterValue, float64(ioStats.WriteIOs))
+	publish("read_sectors_total", "Total number of sectors read from the drive", prometheus.CounterValue, float64(ioStats.ReadSectors))
+	publish("written_sectors_total", "Total number of sectors written to the drive", prometheus.CounterValue, float64(ioStats.WriteSectors))
+	publish("time_milliseconds_total", "Total time the drive was busy doing I/Os in milliseconds", prometheus.CounterValue, float64(ioStats.IOTicks))
+	publish("time_weighted_milliseconds_total", "Total time spent by I/Os in the drive queue in milliseconds", prometheus.CounterValue, float64(ioStats.TimeInQueue))
+	publish("in_flight", "Number of I/Os currently in flight in the drive", prometheus.GaugeValue, float64(ioStats.InFlight))
+}
+
+type driveHealthGetter func(*directcsi.DirectCSIDrive) (*smart.Health, error)
+
+func getDriveHealth(drive *directcsi.DirectCSIDrive) (*smart.Health, error) {
+	device, err := sys.GetDeviceName(drive.Status.MajorNumber, drive.Status.MinorNumber)
+	if err != nil {
+		return nil, err
+	}
+	return smart.GetHealth("/dev/" + device)
+}
+
+func publishDriveHealth(drive *directcsi.DirectCSIDrive, ch chan<- prometheus.Metric, driveHealthFn driveHealthGetter) {
+	health, err := driveHealthFn(drive)
+	if err != nil {
+		klog.V(5).Infof("Error while getting drive health: %v", err)
+		return
+	}
+
+	publish := func(name, help string, value float64) {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("directcsi", "drive_health", name),
+				help,
+				[]string{"drive", "node"}, nil),
+			prometheus.GaugeValue,
+			value, drive.Name, drive.Status.NodeName,
+		)
+	}
+
+	publish("critical_warning", "Critical warning bits reported by the drive", float64(health.CriticalWarning))
+	publish("media_errors", "Total number of unrecovered media errors of the drive", float64(health.MediaErrors))
+	publish("reallocated_sectors", "Total number of reallocated sectors of the drive", float64(health.ReallocatedSectors))
+	publish("percentage_used", "Estimated percentage of the drive life used", float64(health.PercentageUsed))
+	publish("temperature_celsius", "Current temperature of the drive in Celsius", float64(health.Temperature))
+}
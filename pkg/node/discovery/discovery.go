@@ -47,20 +47,12 @@ func getRootBlockFile(devName string) string {
 }
 
 // NewDiscovery creates drive discovery.
-func NewDiscovery(ctx context.Context, identity, nodeID, rack, zone, region string) (*Discovery, error) {
+func NewDiscovery(ctx context.Context, nodeID string, topology map[string]string, autoAccessTier bool) (*Discovery, error) {
 	config, err := client.GetKubeConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	topologies := map[string]string{
-		string(utils.TopologyDriverIdentity): identity,
-		string(utils.TopologyDriverRack):     rack,
-		string(utils.TopologyDriverZone):     zone,
-		string(utils.TopologyDriverRegion):   region,
-		string(utils.TopologyDriverNode):     nodeID,
-	}
-
 	directClientset, err := clientset.NewForConfig(config)
 	if err != nil {
 		return nil, err
@@ -68,7 +60,8 @@ func NewDiscovery(ctx context.Context, identity, nodeID, rack, zone, region stri
 	d := &Discovery{
 		NodeID:          nodeID,
 		directcsiClient: directClientset,
-		driveTopology:   topologies,
+		driveTopology:   topology,
+		autoAccessTier:  autoAccessTier,
 	}
 
 	if err := d.readRemoteDrives(ctx); err != nil {
@@ -166,6 +159,9 @@ func (d *Discovery) Init(ctx context.Context, loopBackOnly bool) error {
 }
 
 func (d *Discovery) createNewDrive(ctx context.Context, localDriveState directcsi.DirectCSIDriveStatus) error {
+	if d.autoAccessTier {
+		localDriveState.AccessTier = client.GetMediaAccessTier(localDriveState)
+	}
 	return client.CreateDrive(ctx, client.NewDirectCSIDrive(uuid.New().String(), localDriveState))
 }
 
@@ -34,4 +34,5 @@ type Discovery struct {
 	remoteDrives    []*remoteDrive
 	driveTopology   map[string]string
 	mounts          map[string][]sys.MountInfo
+	autoAccessTier  bool
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
 	"github.com/minio/directpv/pkg/sys"
@@ -54,7 +55,7 @@ func (d *Discovery) verifyDriveMount(existingDrive *directcsi.DirectCSIDrive) er
 			if err != nil {
 				return err
 			}
-			if err = sys.Mount("/dev/"+name, mountTarget, "xfs", nil, "prjquota"); err != nil {
+			if err = sys.MountDevice("/dev/"+name, mountTarget, existingDrive.Status.Filesystem, nil); err != nil {
 				return err
 			}
 			existingDrive.Status.Mountpoint = mountTarget
@@ -65,12 +66,26 @@ func (d *Discovery) verifyDriveMount(existingDrive *directcsi.DirectCSIDrive) er
 
 func syncDriveStatesOnDiscovery(existingObj *directcsi.DirectCSIDrive, localDrive *directcsi.DirectCSIDrive) {
 	var existingVersion string
+	preservedLabels := map[string]string{}
 	if labels := existingObj.GetLabels(); labels != nil {
 		existingVersion = labels[string(utils.VersionLabelKey)]
+		for key, value := range labels {
+			if strings.HasPrefix(key, utils.DriveUserLabelPrefix) || key == string(utils.OvercommitRatioLabelKey) {
+				preservedLabels[key] = value
+			}
+		}
 	}
 
-	// overwrite existing object labels
+	// overwrite existing object labels except user-defined labels
 	existingObj.SetLabels(localDrive.GetLabels())
+	labels := existingObj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for key, value := range preservedLabels {
+		labels[key] = value
+	}
+	existingObj.SetLabels(labels)
 	utils.UpdateLabels(existingObj, map[utils.LabelKey]utils.LabelValue{
 		utils.AccessTierLabelKey: utils.NewLabelValue(string(existingObj.Status.AccessTier)),
 		utils.VersionLabelKey:    utils.LabelValue(existingVersion),
@@ -119,6 +134,10 @@ func syncDriveStatesOnDiscovery(existingObj *directcsi.DirectCSIDrive, localDriv
 	existingObj.Status.Partitioned = localDrive.Status.Partitioned
 	existingObj.Status.SwapOn = localDrive.Status.SwapOn
 	existingObj.Status.Master = localDrive.Status.Master
+	existingObj.Status.Rotational = localDrive.Status.Rotational
+	existingObj.Status.Transport = localDrive.Status.Transport
+	existingObj.Status.Scheduler = localDrive.Status.Scheduler
+	existingObj.Status.NrRequests = localDrive.Status.NrRequests
 }
 
 func (d *Discovery) syncDrive(ctx context.Context, localDrive *directcsi.DirectCSIDrive) error {
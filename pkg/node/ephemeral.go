@@ -0,0 +1,382 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/fs/xfs"
+	"github.com/minio/directpv/pkg/iothrottle"
+	"github.com/minio/directpv/pkg/matcher"
+	"github.com/minio/directpv/pkg/utils"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+const (
+	ephemeralKey           = "csi.storage.k8s.io/ephemeral"
+	ephemeralSizeAttribute = "size"
+)
+
+func isEphemeralVolumeRequest(req *csi.NodePublishVolumeRequest) bool {
+	return req.GetVolumeContext()[ephemeralKey] == "true"
+}
+
+func isEphemeralVolume(vol *directcsi.DirectCSIVolume) bool {
+	return vol.GetLabels()[string(utils.EphemeralLabelKey)] == "true"
+}
+
+// getEphemeralVolumeSize parses the size attribute of inline volume.
+func getEphemeralVolumeSize(volumeContext map[string]string) (int64, error) {
+	value, found := volumeContext[ephemeralSizeAttribute]
+	if !found {
+		return 0, fmt.Errorf("required volume attribute not found: %v", ephemeralSizeAttribute)
+	}
+
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %v %v; %w", ephemeralSizeAttribute, value, err)
+	}
+	if quantity.Sign() <= 0 {
+		return 0, fmt.Errorf("%v must be positive; %v", ephemeralSizeAttribute, value)
+	}
+
+	return quantity.Value(), nil
+}
+
+// matchEphemeralDrive returns whether the drive can hold an inline volume of the size.
+func matchEphemeralDrive(drive *directcsi.DirectCSIDrive, size int64) bool {
+	switch drive.Status.DriveStatus {
+	case directcsi.DriveStatusReady, directcsi.DriveStatusInUse:
+	default:
+		return false
+	}
+
+	if drive.Status.BlockVolume != "" || drive.IsCordoned() || drive.IsUnhealthy() {
+		return false
+	}
+
+	// Inline volumes have no tolerations; invalid drive taints are treated as NoSchedule taints.
+	for _, taintValue := range drive.Spec.DriveTaint {
+		if _, effect, err := directcsi.ParseDriveTaintValue(taintValue); err != nil || effect == directcsi.DriveTaintEffectNoSchedule {
+			return false
+		}
+	}
+
+	return drive.Status.FreeCapacity >= size
+}
+
+// reserveEphemeralDrive reserves capacity for the inline volume on a local drive having most free capacity.
+func (n *NodeServer) reserveEphemeralDrive(ctx context.Context, volumeName string, size int64) (*directcsi.DirectCSIDrive, error) {
+	driveInterface := n.directcsiClient.DirectV1beta3().DirectCSIDrives()
+	driveList, err := driveInterface.List(ctx, metav1.ListOptions{
+		TypeMeta:      utils.DirectCSIDriveTypeMeta(),
+		LabelSelector: fmt.Sprintf("%s=%s", utils.NodeLabelKey, utils.NewLabelValue(n.NodeID)),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to list drives; %v", err)
+	}
+
+	var drives []directcsi.DirectCSIDrive
+	for _, drive := range driveList.Items {
+		if matchEphemeralDrive(&drive, size) {
+			drives = append(drives, drive)
+		}
+	}
+	sort.SliceStable(drives, func(i, j int) bool {
+		return drives[i].Status.FreeCapacity > drives[j].Status.FreeCapacity
+	})
+
+	finalizer := directcsi.DirectCSIDriveFinalizerPrefix + volumeName
+	for _, selectedDrive := range drives {
+		var drive *directcsi.DirectCSIDrive
+		reserved := false
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			drive, err = driveInterface.Get(
+				ctx, selectedDrive.Name, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+			)
+			if err != nil {
+				return err
+			}
+
+			// Drive is already reserved for this volume by a previous request.
+			if matcher.StringIn(drive.Finalizers, finalizer) {
+				reserved = true
+				return nil
+			}
+
+			if !matchEphemeralDrive(drive, size) {
+				return nil
+			}
+
+			drive.Status.FreeCapacity -= size
+			drive.Status.AllocatedCapacity += size
+			drive.Status.DriveStatus = directcsi.DriveStatusInUse
+			drive.SetFinalizers(append(drive.GetFinalizers(), finalizer))
+
+			drive, err = driveInterface.Update(
+				ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+			)
+			reserved = err == nil
+			return err
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "could not reserve drive[%s] %v", selectedDrive.Name, err)
+		}
+		if reserved {
+			klog.V(4).InfoS("Reserved DirectCSI drive for inline volume",
+				"drive-name", drive.Name,
+				"volume", volumeName)
+			return drive, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.ResourceExhausted, "no drive found on node %v for inline volume %v of size %v", n.NodeID, volumeName, size)
+}
+
+// releaseEphemeralDrive releases capacity reserved for the inline volume on the drive.
+func (n *NodeServer) releaseEphemeralDrive(ctx context.Context, driveName, volumeName string, size int64) error {
+	driveInterface := n.directcsiClient.DirectV1beta3().DirectCSIDrives()
+	finalizer := directcsi.DirectCSIDriveFinalizerPrefix + volumeName
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		drive, err := driveInterface.Get(
+			ctx, driveName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+		)
+		if err != nil {
+			return err
+		}
+
+		var finalizers []string
+		for _, value := range drive.GetFinalizers() {
+			if value != finalizer {
+				finalizers = append(finalizers, value)
+			}
+		}
+		if len(finalizers) == len(drive.GetFinalizers()) {
+			return nil
+		}
+
+		if len(finalizers) == 1 && finalizers[0] == directcsi.DirectCSIDriveFinalizerDataProtection {
+			drive.Status.DriveStatus = directcsi.DriveStatusReady
+		}
+		drive.SetFinalizers(finalizers)
+		drive.Status.FreeCapacity += size
+		drive.Status.AllocatedCapacity -= size
+
+		_, err = driveInterface.Update(
+			ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+		)
+		return err
+	})
+}
+
+// createEphemeralVolume creates DirectCSIVolume of the inline volume on a local drive.
+func (n *NodeServer) createEphemeralVolume(ctx context.Context, volumeName string, size int64) (*directcsi.DirectCSIVolume, error) {
+	drive, err := n.reserveEphemeralDrive(ctx, volumeName, size)
+	if err != nil {
+		return nil, err
+	}
+
+	newVolume := &directcsi.DirectCSIVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: volumeName,
+			Finalizers: []string{
+				directcsi.DirectCSIVolumeFinalizerPurgeProtection,
+			},
+		},
+		Status: directcsi.DirectCSIVolumeStatus{
+			Drive:             drive.Name,
+			NodeName:          drive.Status.NodeName,
+			TotalCapacity:     size,
+			AvailableCapacity: size,
+			UsedCapacity:      0,
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(directcsi.DirectCSIVolumeConditionStaged),
+					Status:             metav1.ConditionFalse,
+					Message:            "",
+					Reason:             string(directcsi.DirectCSIVolumeReasonNotInUse),
+					LastTransitionTime: metav1.Now(),
+				},
+				{
+					Type:               string(directcsi.DirectCSIVolumeConditionPublished),
+					Status:             metav1.ConditionFalse,
+					Message:            "",
+					Reason:             string(directcsi.DirectCSIVolumeReasonNotInUse),
+					LastTransitionTime: metav1.Now(),
+				},
+				{
+					Type:               string(directcsi.DirectCSIVolumeConditionReady),
+					Status:             metav1.ConditionFalse,
+					Message:            "",
+					Reason:             string(directcsi.DirectCSIVolumeReasonNotReady),
+					LastTransitionTime: metav1.Now(),
+				},
+			},
+		},
+	}
+
+	utils.UpdateLabels(newVolume, map[utils.LabelKey]utils.LabelValue{
+		utils.NodeLabelKey:      utils.NewLabelValue(drive.Status.NodeName),
+		utils.DrivePathLabelKey: utils.NewLabelValue(utils.SanitizeDrivePath(drive.Status.Path)),
+		utils.DriveLabelKey:     utils.NewLabelValue(drive.Name),
+		utils.VersionLabelKey:   directcsi.Version,
+		utils.CreatedByLabelKey: utils.DirectCSIDriverName,
+		utils.EphemeralLabelKey: "true",
+	})
+
+	volumeInterface := n.directcsiClient.DirectV1beta3().DirectCSIVolumes()
+	vol, err := volumeInterface.Create(ctx, newVolume, metav1.CreateOptions{})
+	if err != nil {
+		if !errors.IsAlreadyExists(err) {
+			if releaseErr := n.releaseEphemeralDrive(ctx, drive.Name, volumeName, size); releaseErr != nil {
+				klog.ErrorS(releaseErr, "unable to release drive reserved for inline volume", "drive", drive.Name, "volume", volumeName)
+			}
+			return nil, status.Errorf(codes.Internal, "could not create volume %s; %v", volumeName, err)
+		}
+		if vol, err = volumeInterface.Get(
+			ctx, volumeName, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()},
+		); err != nil {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+	}
+
+	return vol, nil
+}
+
+// publishEphemeralVolume creates the inline volume on a local drive with XFS quota of its size
+// and bind-mounts it to target path.
+func (n *NodeServer) publishEphemeralVolume(ctx context.Context, req *csi.NodePublishVolumeRequest) (*csi.NodePublishVolumeResponse, error) {
+	if req.GetTargetPath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "target path must not be empty")
+	}
+
+	if req.GetVolumeCapability().GetBlock() != nil {
+		return nil, status.Error(codes.InvalidArgument, "raw block inline volume is not supported")
+	}
+
+	size, err := getEphemeralVolumeSize(req.GetVolumeContext())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limits, err := iothrottle.ParseLimits(req.GetVolumeContext())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	volumeInterface := n.directcsiClient.DirectV1beta3().DirectCSIVolumes()
+	vol, err := volumeInterface.Get(ctx, req.GetVolumeId(), metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+	switch {
+	case err == nil:
+		if !isEphemeralVolume(vol) {
+			return nil, status.Errorf(codes.AlreadyExists, "volume %v is not an inline volume", vol.Name)
+		}
+	case errors.IsNotFound(err):
+		if vol, err = n.createEphemeralVolume(ctx, req.GetVolumeId(), size); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	drive, err := n.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(
+		ctx, vol.Status.Drive, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+	)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if err := checkDrive(drive, vol.Name, n.probeMounts); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	device, err := n.getDevice(drive.Status.MajorNumber, drive.Status.MinorNumber)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to find device for major/minor %v:%v; %v", drive.Status.MajorNumber, drive.Status.MinorNumber, err)
+	}
+
+	path := filepath.Join(drive.Status.Mountpoint, vol.Name)
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, err
+	}
+
+	quota := xfs.Quota{
+		HardLimit: uint64(vol.Status.TotalCapacity),
+		SoftLimit: uint64(vol.Status.TotalCapacity),
+	}
+	if err := n.setQuota(ctx, device, path, vol.Name, quota); err != nil {
+		return nil, status.Errorf(codes.Internal, "Error while setting xfs limits: %v", err)
+	}
+
+	if err := os.MkdirAll(req.GetTargetPath(), 0755); err != nil {
+		return nil, err
+	}
+
+	if err := n.safeBindMount(path, req.GetTargetPath(), false, req.GetReadonly()); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed volume publish: %v", err)
+	}
+
+	if !limits.IsZero() {
+		podUID := req.GetVolumeContext()[podUIDKey]
+		if err := n.setVolumeIOLimits(ctx, vol, podUID, limits); err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to set I/O limits; %v", err)
+		}
+		vol.Status.ThrottledPods = map[string]string{req.GetTargetPath(): podUID}
+	}
+
+	podName, podNS, _ := getPodInfo(ctx, req)
+	vol.Labels[string(utils.PodNameLabelKey)] = podName
+	vol.Labels[string(utils.PodNSLabelKey)] = podNS
+
+	conditions := vol.Status.Conditions
+	for i, c := range conditions {
+		switch c.Type {
+		case string(directcsi.DirectCSIVolumeConditionPublished):
+			conditions[i].Status = utils.BoolToCondition(true)
+			conditions[i].Reason = string(directcsi.DirectCSIVolumeReasonInUse)
+		case string(directcsi.DirectCSIVolumeConditionStaged):
+		case string(directcsi.DirectCSIVolumeConditionReady):
+			conditions[i].Status = utils.BoolToCondition(true)
+			conditions[i].Reason = string(directcsi.DirectCSIVolumeReasonReady)
+		}
+	}
+	vol.Status.HostPath = path
+	vol.Status.TargetPaths = []string{req.GetTargetPath()}
+	vol.Status.ContainerPath = req.GetTargetPath()
+
+	if _, err := volumeInterface.Update(ctx, vol, metav1.UpdateOptions{
+		TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &csi.NodePublishVolumeResponse{}, nil
+}
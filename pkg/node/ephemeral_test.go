@@ -0,0 +1,253 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	goerrors "errors"
+	"testing"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/client"
+	fakedirect "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/fs/xfs"
+	"github.com/minio/directpv/pkg/iothrottle"
+	"github.com/minio/directpv/pkg/matcher"
+	"github.com/minio/directpv/pkg/utils"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestGetEphemeralVolumeSize(t *testing.T) {
+	testCases := []struct {
+		volumeContext map[string]string
+		expectedSize  int64
+		expectErr     bool
+	}{
+		{map[string]string{}, 0, true},
+		{map[string]string{ephemeralSizeAttribute: "1Gi"}, 1024 * 1024 * 1024, false},
+		{map[string]string{ephemeralSizeAttribute: "100M"}, 100 * 1000 * 1000, false},
+		{map[string]string{ephemeralSizeAttribute: "0"}, 0, true},
+		{map[string]string{ephemeralSizeAttribute: "large"}, 0, true},
+	}
+
+	for i, testCase := range testCases {
+		size, err := getEphemeralVolumeSize(testCase.volumeContext)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if size != testCase.expectedSize {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedSize, size)
+		}
+	}
+}
+
+func TestMatchEphemeralDrive(t *testing.T) {
+	newDrive := func(driveStatus directcsi.DriveStatus, freeCapacity int64) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			Status: directcsi.DirectCSIDriveStatus{DriveStatus: driveStatus, FreeCapacity: freeCapacity},
+		}
+	}
+
+	taintedDrive := newDrive(directcsi.DriveStatusInUse, mb100)
+	taintedDrive.Spec.DriveTaint = map[string]string{"key": "value:NoSchedule"}
+	preferNoScheduleDrive := newDrive(directcsi.DriveStatusInUse, mb100)
+	preferNoScheduleDrive.Spec.DriveTaint = map[string]string{"key": "value:PreferNoSchedule"}
+	blockDrive := newDrive(directcsi.DriveStatusInUse, mb100)
+	blockDrive.Status.BlockVolume = "volume-1"
+
+	testCases := []struct {
+		drive          *directcsi.DirectCSIDrive
+		expectedResult bool
+	}{
+		{newDrive(directcsi.DriveStatusReady, mb100), true},
+		{newDrive(directcsi.DriveStatusInUse, mb50), true},
+		{newDrive(directcsi.DriveStatusInUse, mb20), false},
+		{newDrive(directcsi.DriveStatusAvailable, mb100), false},
+		{taintedDrive, false},
+		{preferNoScheduleDrive, true},
+		{blockDrive, false},
+	}
+
+	for i, testCase := range testCases {
+		if result := matchEphemeralDrive(testCase.drive, mb50); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestPublishUnpublishEphemeralVolume(t *testing.T) {
+	client.FakeInit()
+
+	testMountPointDir := t.TempDir()
+	testTargetPath := t.TempDir()
+
+	newDrive := func(name string, freeCapacity int64) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Finalizers: []string{directcsi.DirectCSIDriveFinalizerDataProtection},
+				Labels:     map[string]string{string(utils.NodeLabelKey): testNodeName},
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				Mountpoint:    testMountPointDir,
+				NodeName:      testNodeName,
+				DriveStatus:   directcsi.DriveStatusReady,
+				FreeCapacity:  freeCapacity,
+				TotalCapacity: mb100,
+			},
+		}
+	}
+
+	request := &csi.NodePublishVolumeRequest{
+		VolumeId:   "csi-ephemeral-1",
+		TargetPath: testTargetPath,
+		VolumeCapability: &csi.VolumeCapability{
+			AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+			AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+		},
+		VolumeContext: map[string]string{
+			ephemeralKey:                "true",
+			ephemeralSizeAttribute:      "20Mi",
+			podUIDKey:                   "pod-uid-1",
+			iothrottle.ReadBPSParameter: "10Mi",
+		},
+	}
+
+	var quotaPath string
+	var quota xfs.Quota
+	var setUID, clearUID string
+	ns := createFakeNodeServer()
+	ns.directcsiClient = fakedirect.NewSimpleClientset(newDrive("drive-1", mb50), newDrive("drive-2", mb100))
+	ns.setQuota = func(ctx context.Context, device, path, volumeID string, q xfs.Quota) error {
+		quotaPath, quota = path, q
+		return nil
+	}
+	ns.setIOLimits = func(podUID string, major, minor uint32, limits iothrottle.Limits) error {
+		setUID = podUID
+		return nil
+	}
+	ns.clearIOLimits = func(podUID string, major, minor uint32) error {
+		clearUID = podUID
+		return nil
+	}
+
+	ctx := context.TODO()
+	if _, err := ns.NodePublishVolume(ctx, request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Retried request must not reserve the drive again.
+	if _, err := ns.NodePublishVolume(ctx, request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	directCSIClient := ns.directcsiClient.DirectV1beta3()
+	volume, err := directCSIClient.DirectCSIVolumes().Get(ctx, "csi-ephemeral-1", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if volume.Status.Drive != "drive-2" || volume.Status.TotalCapacity != mb20 || !isEphemeralVolume(volume) {
+		t.Fatalf("unexpected volume: drive %v, capacity %v, labels %v", volume.Status.Drive, volume.Status.TotalCapacity, volume.Labels)
+	}
+	if volume.Status.ContainerPath != testTargetPath || quotaPath != volume.Status.HostPath || quota.HardLimit != uint64(mb20) {
+		t.Fatalf("unexpected publish: container path %v, host path %v, quota path %v, quota %+v", volume.Status.ContainerPath, volume.Status.HostPath, quotaPath, quota)
+	}
+	if !utils.IsCondition(volume.Status.Conditions, string(directcsi.DirectCSIVolumeConditionPublished), metav1.ConditionTrue, string(directcsi.DirectCSIVolumeReasonInUse), "") {
+		t.Fatalf("unexpected status.conditions after publishing = %v", volume.Status.Conditions)
+	}
+	if setUID != "pod-uid-1" || volume.Status.ThrottledPods[testTargetPath] != "pod-uid-1" {
+		t.Fatalf("unexpected I/O limits: pod %v, throttled pods %v", setUID, volume.Status.ThrottledPods)
+	}
+
+	drive, err := directCSIClient.DirectCSIDrives().Get(ctx, "drive-2", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if drive.Status.FreeCapacity != mb100-mb20 || drive.Status.DriveStatus != directcsi.DriveStatusInUse {
+		t.Fatalf("unexpected drive: free capacity %v, status %v", drive.Status.FreeCapacity, drive.Status.DriveStatus)
+	}
+	if !matcher.StringIn(drive.Finalizers, directcsi.DirectCSIDriveFinalizerPrefix+"csi-ephemeral-1") {
+		t.Fatalf("volume finalizer not found in drive finalizers %v", drive.Finalizers)
+	}
+
+	if _, err := ns.NodeUnpublishVolume(ctx, &csi.NodeUnpublishVolumeRequest{VolumeId: "csi-ephemeral-1", TargetPath: testTargetPath}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := directCSIClient.DirectCSIVolumes().Get(ctx, "csi-ephemeral-1", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()}); !errors.IsNotFound(err) {
+		t.Fatalf("expected volume to be deleted; %v", err)
+	}
+	if clearUID != "pod-uid-1" {
+		t.Fatalf("I/O limits of pod-uid-1 not cleared; got: %v", clearUID)
+	}
+
+	request.VolumeId = "csi-ephemeral-2"
+	request.VolumeContext[ephemeralSizeAttribute] = "1Gi"
+	if _, err := ns.NodePublishVolume(ctx, request); err == nil {
+		t.Fatalf("expected error for no matching drive, but succeeded")
+	}
+}
+
+func TestCreateEphemeralVolumeReleasesDrive(t *testing.T) {
+	drive := &directcsi.DirectCSIDrive{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "drive-1",
+			Finalizers: []string{directcsi.DirectCSIDriveFinalizerDataProtection},
+			Labels:     map[string]string{string(utils.NodeLabelKey): testNodeName},
+		},
+		Status: directcsi.DirectCSIDriveStatus{
+			NodeName:      testNodeName,
+			DriveStatus:   directcsi.DriveStatusReady,
+			FreeCapacity:  mb100,
+			TotalCapacity: mb100,
+		},
+	}
+
+	clientset := fakedirect.NewSimpleClientset(drive)
+	clientset.PrependReactor("create", "directcsivolumes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, goerrors.New("volume creation failed")
+	})
+	ns := createFakeNodeServer()
+	ns.directcsiClient = clientset
+
+	ctx := context.TODO()
+	if _, err := ns.createEphemeralVolume(ctx, "csi-ephemeral-1", mb20); err == nil {
+		t.Fatalf("expected error, but succeeded")
+	}
+
+	result, err := clientset.DirectV1beta3().DirectCSIDrives().Get(ctx, "drive-1", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status.FreeCapacity != mb100 || result.Status.AllocatedCapacity != 0 || result.Status.DriveStatus != directcsi.DriveStatusReady {
+		t.Fatalf("drive not released: free capacity %v, allocated capacity %v, status %v", result.Status.FreeCapacity, result.Status.AllocatedCapacity, result.Status.DriveStatus)
+	}
+	if matcher.StringIn(result.Finalizers, directcsi.DirectCSIDriveFinalizerPrefix+"csi-ephemeral-1") {
+		t.Fatalf("volume finalizer found in drive finalizers %v", result.Finalizers)
+	}
+}
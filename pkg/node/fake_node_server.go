@@ -21,6 +21,7 @@ import (
 
 	directsetfake "github.com/minio/directpv/pkg/clientset/fake"
 	"github.com/minio/directpv/pkg/fs/xfs"
+	"github.com/minio/directpv/pkg/iothrottle"
 	"github.com/minio/directpv/pkg/sys"
 )
 
@@ -42,9 +43,16 @@ func createFakeNodeServer() *NodeServer {
 		getDevice:     func(major, minor uint32) (string, error) { return "", nil },
 		safeBindMount: func(source, target string, recursive, readOnly bool) error { return nil },
 		safeUnmount:   func(target string, force, detach, expire bool) error { return nil },
+		unmountDevice: func(device string) error { return nil },
 		getQuota: func(ctx context.Context, device, volumeID string) (quota *xfs.Quota, err error) {
 			return &xfs.Quota{}, nil
 		},
-		setQuota: func(ctx context.Context, device, path, volumeID string, quota xfs.Quota) (err error) { return nil },
+		setQuota:     func(ctx context.Context, device, path, volumeID string, quota xfs.Quota) (err error) { return nil },
+		copyDir:      func(src, dst string, fallback bool) error { return nil },
+		isReadOnlyFS: func(path string) (bool, error) { return false, nil },
+		setIOLimits: func(podUID string, major, minor uint32, limits iothrottle.Limits) error {
+			return nil
+		},
+		clearIOLimits: func(podUID string, major, minor uint32) error { return nil },
 	}
 }
@@ -0,0 +1,162 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/sys/smart"
+	"github.com/minio/directpv/pkg/utils"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// HealthThresholds denotes S.M.A.R.T. attribute limits crossing which marks the drive unhealthy.
+// Zero value of a limit disables its check.
+type HealthThresholds struct {
+	MediaErrors        uint64
+	ReallocatedSectors uint64
+	PercentageUsed     int64
+	Temperature        int64
+}
+
+// checkHealth returns the reasons of the health crossing the thresholds.
+func checkHealth(health *smart.Health, thresholds HealthThresholds) (reasons []string) {
+	if health.CriticalWarning != 0 {
+		reasons = append(reasons, fmt.Sprintf("critical warning %#02x", health.CriticalWarning))
+	}
+	if thresholds.MediaErrors > 0 && health.MediaErrors > thresholds.MediaErrors {
+		reasons = append(reasons, fmt.Sprintf("media errors %v exceeds %v", health.MediaErrors, thresholds.MediaErrors))
+	}
+	if thresholds.ReallocatedSectors > 0 && health.ReallocatedSectors > thresholds.ReallocatedSectors {
+		reasons = append(reasons, fmt.Sprintf("reallocated sectors %v exceeds %v", health.ReallocatedSectors, thresholds.ReallocatedSectors))
+	}
+	if thresholds.PercentageUsed > 0 && health.PercentageUsed > thresholds.PercentageUsed {
+		reasons = append(reasons, fmt.Sprintf("percentage used %v%% exceeds %v%%", health.PercentageUsed, thresholds.PercentageUsed))
+	}
+	if thresholds.Temperature > 0 && health.Temperature > thresholds.Temperature {
+		reasons = append(reasons, fmt.Sprintf("temperature %vC exceeds %vC", health.Temperature, thresholds.Temperature))
+	}
+	return reasons
+}
+
+// setHealthyCondition sets healthy condition of the drive and returns whether the condition is changed.
+func setHealthyCondition(drive *directcsi.DirectCSIDrive, health *smart.Health, thresholds HealthThresholds) bool {
+	condition := metav1.Condition{
+		Type:   string(directcsi.DirectCSIDriveConditionHealthy),
+		Status: metav1.ConditionTrue,
+		Reason: string(directcsi.DirectCSIDriveReasonHealthy),
+	}
+	if reasons := checkHealth(health, thresholds); len(reasons) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(directcsi.DirectCSIDriveReasonUnhealthy)
+		condition.Message = strings.Join(reasons, "; ")
+	}
+
+	existing := meta.FindStatusCondition(drive.Status.Conditions, condition.Type)
+	if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+		return false
+	}
+
+	meta.SetStatusCondition(&drive.Status.Conditions, condition)
+	return true
+}
+
+func updateDriveHealth(ctx context.Context, name string, health *smart.Health, thresholds HealthThresholds) error {
+	driveInterface := client.GetLatestDirectCSIDriveInterface()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		drive, err := driveInterface.Get(ctx, name, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			return err
+		}
+		if !setHealthyCondition(drive, health, thresholds) {
+			return nil
+		}
+		_, err = driveInterface.Update(ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		return err
+	})
+}
+
+func checkDrivesHealth(ctx context.Context, nodeID string, thresholds HealthThresholds, getHealth func(devicePath string) (*smart.Health, error)) {
+	resultCh, err := client.ListDrives(
+		ctx,
+		[]utils.LabelValue{utils.NewLabelValue(nodeID)},
+		nil,
+		nil,
+		client.MaxThreadCount,
+	)
+	if err != nil {
+		klog.Error(err)
+		return
+	}
+
+	for result := range resultCh {
+		if result.Err != nil {
+			klog.Error(result.Err)
+			return
+		}
+
+		drive := &result.Drive
+		if drive.Status.DriveStatus == directcsi.DriveStatusTerminating {
+			continue
+		}
+
+		device, err := getDevice(drive.Status.MajorNumber, drive.Status.MinorNumber)
+		if err != nil {
+			klog.V(5).InfoS("unable to find device", "Name", drive.Name, "Status.Path", drive.Status.Path, "err", err)
+			continue
+		}
+
+		health, err := getHealth(device)
+		if err != nil {
+			// Devices like loopback, virtual and USB do not support S.M.A.R.T.
+			klog.V(5).InfoS("unable to read S.M.A.R.T. health", "Name", drive.Name, "device", device, "err", err)
+			continue
+		}
+
+		if err := updateDriveHealth(ctx, drive.Name, health, thresholds); err != nil {
+			klog.ErrorS(err, "unable to update drive health", "Name", drive.Name, "Status.Path", drive.Status.Path)
+		}
+	}
+}
+
+// StartHealthMonitor periodically checks S.M.A.R.T. health of drives on this node and
+// marks the drives crossing thresholds unhealthy for scheduling.
+func StartHealthMonitor(ctx context.Context, nodeID string, interval time.Duration, thresholds HealthThresholds) {
+	klog.V(3).InfoS("Starting drive health monitor", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		checkDrivesHealth(ctx, nodeID, thresholds, smart.GetHealth)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
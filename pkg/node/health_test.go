@@ -0,0 +1,92 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"reflect"
+	"testing"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/sys/smart"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckHealth(t *testing.T) {
+	thresholds := HealthThresholds{MediaErrors: 10, ReallocatedSectors: 10, PercentageUsed: 100, Temperature: 70}
+
+	testCases := []struct {
+		health          *smart.Health
+		thresholds      HealthThresholds
+		expectedReasons []string
+	}{
+		{&smart.Health{}, thresholds, nil},
+		{&smart.Health{MediaErrors: 10, ReallocatedSectors: 10, PercentageUsed: 100, Temperature: 70}, thresholds, nil},
+		{&smart.Health{CriticalWarning: 0x01}, thresholds, []string{"critical warning 0x01"}},
+		{
+			&smart.Health{MediaErrors: 11, ReallocatedSectors: 12, PercentageUsed: 101, Temperature: 75},
+			thresholds,
+			[]string{
+				"media errors 11 exceeds 10",
+				"reallocated sectors 12 exceeds 10",
+				"percentage used 101% exceeds 100%",
+				"temperature 75C exceeds 70C",
+			},
+		},
+		{&smart.Health{MediaErrors: 100, Temperature: 90}, HealthThresholds{}, nil},
+	}
+
+	for i, testCase := range testCases {
+		reasons := checkHealth(testCase.health, testCase.thresholds)
+		if !reflect.DeepEqual(reasons, testCase.expectedReasons) {
+			t.Fatalf("case %v: reasons: expected: %v, got: %v", i+1, testCase.expectedReasons, reasons)
+		}
+	}
+}
+
+func TestSetHealthyCondition(t *testing.T) {
+	thresholds := HealthThresholds{Temperature: 70}
+	drive := &directcsi.DirectCSIDrive{}
+
+	testCases := []struct {
+		health          *smart.Health
+		expectedChanged bool
+		expectedStatus  metav1.ConditionStatus
+	}{
+		{&smart.Health{Temperature: 40}, true, metav1.ConditionTrue},
+		{&smart.Health{Temperature: 50}, false, metav1.ConditionTrue},
+		{&smart.Health{Temperature: 80}, true, metav1.ConditionFalse},
+		{&smart.Health{Temperature: 80}, false, metav1.ConditionFalse},
+		{&smart.Health{Temperature: 85}, true, metav1.ConditionFalse},
+		{&smart.Health{Temperature: 60}, true, metav1.ConditionTrue},
+	}
+
+	for i, testCase := range testCases {
+		changed := setHealthyCondition(drive, testCase.health, thresholds)
+		if changed != testCase.expectedChanged {
+			t.Fatalf("case %v: changed: expected: %v, got: %v", i+1, testCase.expectedChanged, changed)
+		}
+		condition := meta.FindStatusCondition(drive.Status.Conditions, string(directcsi.DirectCSIDriveConditionHealthy))
+		if condition == nil || condition.Status != testCase.expectedStatus {
+			t.Fatalf("case %v: condition: expected status: %v, got: %+v", i+1, testCase.expectedStatus, condition)
+		}
+		if drive.IsUnhealthy() != (testCase.expectedStatus == metav1.ConditionFalse) {
+			t.Fatalf("case %v: unexpected unhealthy state %v", i+1, drive.IsUnhealthy())
+		}
+	}
+}
@@ -18,12 +18,16 @@ package node
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	"github.com/minio/directpv/pkg/client"
 	"github.com/minio/directpv/pkg/clientset"
 	"github.com/minio/directpv/pkg/drive"
 	"github.com/minio/directpv/pkg/fs/xfs"
+	"github.com/minio/directpv/pkg/iothrottle"
 	"github.com/minio/directpv/pkg/metrics"
+	"github.com/minio/directpv/pkg/snapshot"
 	"github.com/minio/directpv/pkg/sys"
 	"github.com/minio/directpv/pkg/utils"
 	"github.com/minio/directpv/pkg/volume"
@@ -60,14 +64,21 @@ type NodeServer struct { //revive:disable-line:exported
 	getDevice       func(major, minor uint32) (string, error)
 	safeBindMount   func(source, target string, recursive, readOnly bool) error
 	safeUnmount     func(target string, force, detach, expire bool) error
+	unmountDevice   func(device string) error
 	getQuota        func(ctx context.Context, device, volumeID string) (quota *xfs.Quota, err error)
 	setQuota        func(ctx context.Context, device, path, volumeID string, quota xfs.Quota) (err error)
+	copyDir         func(src, dst string, fallback bool) error
+	isReadOnlyFS    func(path string) (bool, error)
+	setIOLimits     func(podUID string, major, minor uint32, limits iothrottle.Limits) error
+	clearIOLimits   func(podUID string, major, minor uint32) error
+	customTopology  map[string]string
+	topologyMutex   sync.RWMutex
 }
 
 //revive:enable-line:exported
 
 // NewNodeServer creates node server.
-func NewNodeServer(ctx context.Context, identity, nodeID, rack, zone, region string, dynamicDriveDiscovery, reflinkSupport, loopbackOnly bool) (*NodeServer, error) {
+func NewNodeServer(ctx context.Context, identity, nodeID, rack, zone, region, rackLabel string, topologyKeys []string, dynamicDriveDiscovery, reflinkSupport, loopbackOnly, autoAccessTier bool) (*NodeServer, error) {
 	config, err := client.GetKubeConfig()
 	if err != nil {
 		return &NodeServer{}, err
@@ -89,22 +100,24 @@ func NewNodeServer(ctx context.Context, identity, nodeID, rack, zone, region str
 		getDevice:       getDevice,
 		safeBindMount:   safeBindMount,
 		safeUnmount:     sys.SafeUnmount,
+		unmountDevice:   sys.UnmountDevice,
 		getQuota:        xfs.GetQuota,
 		setQuota:        xfs.SetQuota,
+		copyDir:         sys.CopyDir,
+		isReadOnlyFS:    sys.IsReadOnlyFS,
+		setIOLimits:     iothrottle.SetLimits,
+		clearIOLimits:   iothrottle.ClearLimits,
 	}
 
+	initTopology(ctx, nodeServer, rackLabel, topologyKeys)
+
 	if dynamicDriveDiscovery {
 		handler := &ueventHandler{
-			nodeID: nodeID,
-			topology: map[string]string{
-				string(utils.TopologyDriverIdentity): identity,
-				string(utils.TopologyDriverRack):     rack,
-				string(utils.TopologyDriverZone):     zone,
-				string(utils.TopologyDriverRegion):   region,
-				string(utils.TopologyDriverNode):     nodeID,
-			},
+			nodeID:                nodeID,
+			getTopology:           nodeServer.getTopology,
 			dynamicDriveDiscovery: dynamicDriveDiscovery,
 			loopbackOnly:          loopbackOnly,
+			autoAccessTier:        autoAccessTier,
 		}
 		if loopbackOnly {
 			if err := sys.CreateLoopDevices(); err != nil {
@@ -131,6 +144,14 @@ func NewNodeServer(ctx context.Context, identity, nodeID, rack, zone, region str
 		}
 	}()
 
+	go func() {
+		if err := snapshot.StartController(ctx, nodeID); err != nil {
+			klog.Error(err)
+		}
+	}()
+
+	go volume.StartPurger(ctx, nodeID)
+
 	go metrics.ServeMetrics(ctx, nodeID)
 
 	return nodeServer, nil
@@ -139,13 +160,7 @@ func NewNodeServer(ctx context.Context, identity, nodeID, rack, zone, region str
 // NodeGetInfo gets node information.
 func (ns *NodeServer) NodeGetInfo(ctx context.Context, req *csi.NodeGetInfoRequest) (*csi.NodeGetInfoResponse, error) {
 	topology := &csi.Topology{
-		Segments: map[string]string{
-			string(utils.TopologyDriverIdentity): ns.Identity,
-			string(utils.TopologyDriverRack):     ns.Rack,
-			string(utils.TopologyDriverZone):     ns.Zone,
-			string(utils.TopologyDriverRegion):   ns.Region,
-			string(utils.TopologyDriverNode):     ns.NodeID,
-		},
+		Segments: ns.getTopology(),
 	}
 
 	return &csi.NodeGetInfoResponse{
@@ -173,6 +188,9 @@ func (ns *NodeServer) NodeGetCapabilities(ctx context.Context, req *csi.NodeGetC
 		Capabilities: []*csi.NodeServiceCapability{
 			nodeCap(csi.NodeServiceCapability_RPC_GET_VOLUME_STATS),
 			nodeCap(csi.NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME),
+			nodeCap(csi.NodeServiceCapability_RPC_EXPAND_VOLUME),
+			nodeCap(csi.NodeServiceCapability_RPC_VOLUME_CONDITION),
+			nodeCap(csi.NodeServiceCapability_RPC_SINGLE_NODE_MULTI_WRITER),
 		},
 	}, nil
 }
@@ -203,34 +221,116 @@ func (ns *NodeServer) NodeGetVolumeStats(ctx context.Context, req *csi.NodeGetVo
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
+	// Raw block volume uses the whole drive.
+	if drive.Status.BlockVolume == vID {
+		return &csi.NodeGetVolumeStatsResponse{
+			Usage: []*csi.VolumeUsage{
+				{
+					Total: vol.Status.TotalCapacity,
+					Unit:  csi.VolumeUsage_BYTES,
+				},
+			},
+			VolumeCondition: newVolumeCondition(checkBlockDrive(drive, vID)),
+		}, nil
+	}
+
 	device, err := ns.getDevice(drive.Status.MajorNumber, drive.Status.MinorNumber)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Unable to find device for major/minor %v:%v; %v", drive.Status.MajorNumber, drive.Status.MinorNumber, err)
 	}
-	quota, err := ns.getQuota(ctx, device, vID)
-	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "Error while getting xfs volume stats: %v", err)
-	}
 
 	volUsage := &csi.VolumeUsage{
-		Available: vol.Status.TotalCapacity - int64(quota.CurrentSpace),
-		Total:     vol.Status.TotalCapacity,
-		Used:      int64(quota.CurrentSpace),
-		Unit:      csi.VolumeUsage_BYTES,
+		Total: vol.Status.TotalCapacity,
+		Unit:  csi.VolumeUsage_BYTES,
+	}
+
+	err = ns.checkVolume(vol, drive)
+	if err == nil {
+		var quota *xfs.Quota
+		switch quota, err = ns.getQuota(ctx, device, vID); {
+		case err != nil:
+			err = fmt.Errorf("unable to get quota of volume %v; %w", vID, err)
+		case quota.HardLimit == 0:
+			err = fmt.Errorf("quota project of volume %v not found", vID)
+		default:
+			volUsage.Available = vol.Status.TotalCapacity - int64(quota.CurrentSpace)
+			volUsage.Used = int64(quota.CurrentSpace)
+			if terr := ns.trackUsage(ctx, vol, drive, volUsage.Used); terr != nil {
+				klog.ErrorS(terr, "unable to track volume usage", "volumeID", vID, "drive", drive.Name)
+			}
+		}
+	}
+	if err != nil {
+		klog.ErrorS(err, "volume is abnormal", "volumeID", vID, "volumePath", volumePath)
 	}
 
 	return &csi.NodeGetVolumeStatsResponse{
 		Usage: []*csi.VolumeUsage{
 			volUsage,
 		},
-		VolumeCondition: &csi.VolumeCondition{
-			Abnormal: false,
-			Message:  "",
-		},
+		VolumeCondition: newVolumeCondition(err),
 	}, nil
 }
 
-// NodeExpandVolume returns unimplemented error.
-func (ns *NodeServer) NodeExpandVolume(ctx context.Context, in *csi.NodeExpandVolumeRequest) (*csi.NodeExpandVolumeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "unimplemented")
+// NodeExpandVolume raises XFS project quota of the volume to its expanded capacity.
+func (ns *NodeServer) NodeExpandVolume(ctx context.Context, req *csi.NodeExpandVolumeRequest) (*csi.NodeExpandVolumeResponse, error) {
+	klog.V(3).InfoS("NodeExpandVolumeRequest",
+		"volumeID", req.GetVolumeId(),
+		"volumePath", req.GetVolumePath(),
+		"capacity", req.GetCapacityRange())
+
+	vID := req.GetVolumeId()
+	if vID == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume ID missing in request")
+	}
+	volumePath := req.GetVolumePath()
+	if volumePath == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume path missing in request")
+	}
+
+	directCSIClient := ns.directcsiClient.DirectV1beta3()
+	vol, err := directCSIClient.DirectCSIVolumes().Get(ctx, vID, metav1.GetOptions{
+		TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+	})
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	size := req.GetCapacityRange().GetRequiredBytes()
+	if size > vol.Status.TotalCapacity {
+		return nil, status.Errorf(codes.FailedPrecondition, "volume %v is not yet expanded to %v bytes by controller", vID, size)
+	}
+
+	drive, err := directCSIClient.DirectCSIDrives().Get(ctx, vol.Status.Drive, metav1.GetOptions{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+	})
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	// Raw block volume always has the whole drive; nothing to expand.
+	if drive.Status.BlockVolume == vID {
+		return &csi.NodeExpandVolumeResponse{CapacityBytes: vol.Status.TotalCapacity}, nil
+	}
+
+	if vol.Status.HostPath == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "volume %v is not yet staged", vID)
+	}
+
+	device, err := ns.getDevice(drive.Status.MajorNumber, drive.Status.MinorNumber)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to find device for major/minor %v:%v; %v", drive.Status.MajorNumber, drive.Status.MinorNumber, err)
+	}
+
+	quota := xfs.Quota{
+		HardLimit: uint64(vol.Status.TotalCapacity),
+		SoftLimit: uint64(vol.Status.TotalCapacity),
+	}
+	// Quota is set on the volume directory on the drive as the volume path may be
+	// published read-only.
+	if err := ns.setQuota(ctx, device, vol.Status.HostPath, vID, quota); err != nil {
+		return nil, status.Errorf(codes.Internal, "Error while setting xfs limits: %v", err)
+	}
+
+	return &csi.NodeExpandVolumeResponse{CapacityBytes: vol.Status.TotalCapacity}, nil
 }
@@ -0,0 +1,262 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/client"
+	fakedirect "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/fs/xfs"
+	"github.com/minio/directpv/pkg/sys"
+	"github.com/minio/directpv/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestNodeExpandVolume(t *testing.T) {
+	drive := &directcsi.DirectCSIDrive{
+		TypeMeta:   utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "drive-1"},
+		Status:     directcsi.DirectCSIDriveStatus{DriveStatus: directcsi.DriveStatusInUse},
+	}
+	volume := &directcsi.DirectCSIVolume{
+		TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "volume-1"},
+		Status: directcsi.DirectCSIVolumeStatus{
+			Drive:         "drive-1",
+			TotalCapacity: mb50,
+			HostPath:      "/var/lib/direct-csi/mnt/drive-1/volume-1",
+		},
+	}
+	unstagedVolume := &directcsi.DirectCSIVolume{
+		TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "volume-3"},
+		Status: directcsi.DirectCSIVolumeStatus{
+			Drive:         "drive-1",
+			TotalCapacity: mb50,
+		},
+	}
+
+	testCases := []struct {
+		req           *csi.NodeExpandVolumeRequest
+		expectErr     bool
+		expectedQuota uint64
+	}{
+		{&csi.NodeExpandVolumeRequest{VolumePath: "/path"}, true, 0},
+		{&csi.NodeExpandVolumeRequest{VolumeId: "volume-1"}, true, 0},
+		{&csi.NodeExpandVolumeRequest{VolumeId: "volume-2", VolumePath: "/path"}, true, 0},
+		{&csi.NodeExpandVolumeRequest{VolumeId: "volume-1", VolumePath: "/path", CapacityRange: &csi.CapacityRange{RequiredBytes: mb200}}, true, 0},
+		{&csi.NodeExpandVolumeRequest{VolumeId: "volume-1", VolumePath: "/path", CapacityRange: &csi.CapacityRange{RequiredBytes: mb50}}, false, mb50},
+		{&csi.NodeExpandVolumeRequest{VolumeId: "volume-3", VolumePath: "/path", CapacityRange: &csi.CapacityRange{RequiredBytes: mb50}}, true, 0},
+	}
+
+	for i, testCase := range testCases {
+		var quota uint64
+		var quotaPath string
+		nodeServer := createFakeNodeServer()
+		nodeServer.directcsiClient = fakedirect.NewSimpleClientset(drive, volume, unstagedVolume)
+		nodeServer.setQuota = func(ctx context.Context, device, path, volumeID string, q xfs.Quota) error {
+			quota, quotaPath = q.HardLimit, path
+			return nil
+		}
+
+		result, err := nodeServer.NodeExpandVolume(context.TODO(), testCase.req)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if result.CapacityBytes != mb50 {
+			t.Fatalf("case %v: expected capacity: %v, got: %v", i+1, mb50, result.CapacityBytes)
+		}
+		if quota != testCase.expectedQuota {
+			t.Fatalf("case %v: expected quota: %v, got: %v", i+1, testCase.expectedQuota, quota)
+		}
+		if quotaPath != volume.Status.HostPath {
+			t.Fatalf("case %v: expected quota path: %v, got: %v", i+1, volume.Status.HostPath, quotaPath)
+		}
+	}
+}
+
+func TestNodeGetVolumeStats(t *testing.T) {
+	newDrive := func(name, volume string) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta:   utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: name, Finalizers: []string{directcsi.DirectCSIDriveFinalizerPrefix + volume}},
+			Status:     directcsi.DirectCSIDriveStatus{DriveStatus: directcsi.DriveStatusInUse},
+		}
+	}
+	newVolume := func(name, drive, stagingPath string) *directcsi.DirectCSIVolume {
+		return &directcsi.DirectCSIVolume{
+			TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: directcsi.DirectCSIVolumeStatus{
+				Drive:         drive,
+				TotalCapacity: mb50,
+				StagingPath:   stagingPath,
+			},
+		}
+	}
+
+	objects := []runtime.Object{
+		newDrive("drive-1", "volume-1"),
+		newDrive("drive-2", "volume-3"),
+		newVolume("volume-1", "drive-1", "/staging/volume-1"),
+		newVolume("volume-2", "drive-1", "/staging/volume-2"),
+		newVolume("volume-3", "drive-2", ""),
+	}
+
+	testCases := []struct {
+		volumeID         string
+		readOnly         bool
+		readOnlyErr      error
+		hardLimit        uint64
+		expectedAbnormal bool
+	}{
+		{"volume-1", false, nil, mb50, false},
+		{"volume-1", true, nil, mb50, true},
+		{"volume-1", false, syscall.EIO, mb50, true},
+		{"volume-1", false, nil, 0, true},
+		{"volume-2", false, nil, mb50, true},
+		{"volume-3", false, nil, mb50, true},
+	}
+
+	for i, testCase := range testCases {
+		nodeServer := createFakeNodeServer()
+		nodeServer.directcsiClient = fakedirect.NewSimpleClientset(objects...)
+		nodeServer.probeMounts = func() (map[string][]sys.MountInfo, error) {
+			return map[string][]sys.MountInfo{"0:0": {{MountPoint: sys.MountRoot}, {MountPoint: "/staging/volume-1"}}}, nil
+		}
+		nodeServer.isReadOnlyFS = func(path string) (bool, error) {
+			// Publish target path may be read-only; staging path must be probed.
+			if path != "/staging/volume-1" {
+				return false, fmt.Errorf("unexpected path %v probed", path)
+			}
+			return testCase.readOnly, testCase.readOnlyErr
+		}
+		nodeServer.getQuota = func(ctx context.Context, device, volumeID string) (*xfs.Quota, error) {
+			return &xfs.Quota{HardLimit: testCase.hardLimit, SoftLimit: testCase.hardLimit, CurrentSpace: mb20}, nil
+		}
+
+		result, err := nodeServer.NodeGetVolumeStats(context.TODO(), &csi.NodeGetVolumeStatsRequest{VolumeId: testCase.volumeID, VolumePath: "/path"})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if result.GetVolumeCondition().GetAbnormal() != testCase.expectedAbnormal {
+			t.Fatalf("case %v: expected abnormal: %v, got: %v", i+1, testCase.expectedAbnormal, result.GetVolumeCondition())
+		}
+		if !testCase.expectedAbnormal && result.GetUsage()[0].GetUsed() != mb20 {
+			t.Fatalf("case %v: expected used: %v, got: %v", i+1, mb20, result.GetUsage()[0].GetUsed())
+		}
+	}
+}
+
+func TestTrackUsage(t *testing.T) {
+	client.FakeInit()
+
+	newDrive := func(name string, allocated int64) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta:   utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: directcsi.DirectCSIDriveStatus{
+				DriveStatus:       directcsi.DriveStatusInUse,
+				AllocatedCapacity: allocated,
+				FreeCapacity:      mb100 - allocated,
+				TotalCapacity:     mb100,
+			},
+		}
+	}
+	newVolume := func(name, drive string, used int64) *directcsi.DirectCSIVolume {
+		volume := &directcsi.DirectCSIVolume{
+			TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: directcsi.DirectCSIVolumeStatus{
+				Drive:             drive,
+				TotalCapacity:     mb100,
+				AvailableCapacity: mb100 - used,
+				UsedCapacity:      used,
+			},
+		}
+		utils.UpdateLabels(volume, map[utils.LabelKey]utils.LabelValue{utils.DriveLabelKey: utils.NewLabelValue(drive)})
+		return volume
+	}
+
+	withHighWatermark := func(drive *directcsi.DirectCSIDrive, status metav1.ConditionStatus) *directcsi.DirectCSIDrive {
+		drive.Status.Conditions = []metav1.Condition{
+			{Type: string(directcsi.DirectCSIDriveConditionHighWatermark), Status: status},
+		}
+		return drive
+	}
+
+	testCases := []struct {
+		drive             *directcsi.DirectCSIDrive
+		volumeUsed        int64
+		used              int64
+		expectedCondition metav1.ConditionStatus
+	}{
+		{newDrive("drive-1", mb50), 0, mb20, ""},
+		{newDrive("drive-1", 2*mb100), 0, mb20, metav1.ConditionFalse},
+		{newDrive("drive-1", 2*mb100), 0, mb50, metav1.ConditionTrue},
+		{withHighWatermark(newDrive("drive-1", 2*mb100), metav1.ConditionFalse), 0, mb50, metav1.ConditionTrue},
+		{withHighWatermark(newDrive("drive-1", 2*mb100), metav1.ConditionTrue), mb50, mb20, metav1.ConditionFalse},
+		// Usage of the volume is unchanged; condition is left as is.
+		{withHighWatermark(newDrive("drive-1", 2*mb100), metav1.ConditionFalse), mb50, mb50, metav1.ConditionFalse},
+		{withHighWatermark(newDrive("drive-1", mb50), metav1.ConditionTrue), mb50, mb50, metav1.ConditionFalse},
+	}
+
+	for i, testCase := range testCases {
+		volume := newVolume("volume-1", "drive-1", testCase.volumeUsed)
+		nodeServer := createFakeNodeServer()
+		nodeServer.directcsiClient = fakedirect.NewSimpleClientset(testCase.drive, volume, newVolume("volume-2", "drive-1", mb50))
+
+		if err := nodeServer.trackUsage(context.TODO(), volume, testCase.drive, testCase.used); err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+
+		result, err := nodeServer.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(context.TODO(), "volume-1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if result.Status.UsedCapacity != testCase.used || result.Status.AvailableCapacity != mb100-testCase.used {
+			t.Fatalf("case %v: unexpected capacity; used: %v, available: %v", i+1, result.Status.UsedCapacity, result.Status.AvailableCapacity)
+		}
+
+		drive, err := nodeServer.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(context.TODO(), "drive-1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		var condition metav1.ConditionStatus
+		if c := meta.FindStatusCondition(drive.Status.Conditions, string(directcsi.DirectCSIDriveConditionHighWatermark)); c != nil {
+			condition = c.Status
+		}
+		if condition != testCase.expectedCondition {
+			t.Fatalf("case %v: expected high watermark condition: %v, got: %v", i+1, testCase.expectedCondition, condition)
+		}
+	}
+}
@@ -18,12 +18,15 @@ package node
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
 	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/iothrottle"
 	"github.com/minio/directpv/pkg/utils"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -38,6 +41,7 @@ import (
 const (
 	podNameKey      = "csi.storage.k8s.io/pod.name"
 	podNamespaceKey = "csi.storage.k8s.io/pod.namespace"
+	podUIDKey       = "csi.storage.k8s.io/pod.uid"
 )
 
 func parseVolumeContext(volumeContext map[string]string) (name, ns string, err error) {
@@ -79,6 +83,32 @@ func getPodInfo(ctx context.Context, req *csi.NodePublishVolumeRequest) (podName
 	return
 }
 
+// getTargetPaths returns publish target paths of the volume including the legacy container path.
+func getTargetPaths(vol *directcsi.DirectCSIVolume) []string {
+	if len(vol.Status.TargetPaths) == 0 && vol.Status.ContainerPath != "" {
+		return []string{vol.Status.ContainerPath}
+	}
+	return vol.Status.TargetPaths
+}
+
+func addTargetPath(targetPaths []string, targetPath string) []string {
+	for _, path := range targetPaths {
+		if path == targetPath {
+			return targetPaths
+		}
+	}
+	return append(targetPaths, targetPath)
+}
+
+func removeTargetPath(targetPaths []string, targetPath string) (result []string) {
+	for _, path := range targetPaths {
+		if path != targetPath {
+			result = append(result, path)
+		}
+	}
+	return result
+}
+
 // NodePublishVolume is node publish volume request handler.
 func (n *NodeServer) NodePublishVolume(ctx context.Context, req *csi.NodePublishVolumeRequest) (*csi.NodePublishVolumeResponse, error) {
 	klog.V(3).InfoS("NodePublishVolumeRequest",
@@ -90,6 +120,10 @@ func (n *NodeServer) NodePublishVolume(ctx context.Context, req *csi.NodePublish
 		return nil, status.Error(codes.InvalidArgument, "volume ID must not be empty")
 	}
 
+	if isEphemeralVolumeRequest(req) {
+		return n.publishEphemeralVolume(ctx, req)
+	}
+
 	if req.GetStagingTargetPath() == "" {
 		return nil, status.Error(codes.InvalidArgument, "Staging target path must not be empty")
 	}
@@ -98,6 +132,11 @@ func (n *NodeServer) NodePublishVolume(ctx context.Context, req *csi.NodePublish
 		return nil, status.Error(codes.InvalidArgument, "target path must not be empty")
 	}
 
+	limits, err := iothrottle.ParseLimits(req.GetVolumeContext())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	podName, podNS, podLabels := getPodInfo(ctx, req)
 
 	volumeInterface := n.directcsiClient.DirectV1beta3().DirectCSIVolumes()
@@ -111,6 +150,16 @@ func (n *NodeServer) NodePublishVolume(ctx context.Context, req *csi.NodePublish
 		return nil, status.Errorf(codes.FailedPrecondition, "volume %v is not yet staged, but requested with %v", vol.Name, req.GetStagingTargetPath())
 	}
 
+	mode := req.GetVolumeCapability().GetAccessMode().GetMode()
+	if mode == csi.VolumeCapability_AccessMode_SINGLE_NODE_SINGLE_WRITER {
+		for _, targetPath := range getTargetPaths(vol) {
+			if targetPath != req.GetTargetPath() {
+				return nil, status.Errorf(codes.FailedPrecondition, "volume %v is already published at %v", vol.Name, targetPath)
+			}
+		}
+	}
+	readOnly := req.GetReadonly() || mode == csi.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY
+
 	volumeLabels := vol.GetLabels()
 	if volumeLabels == nil {
 		volumeLabels = make(map[string]string)
@@ -124,16 +173,35 @@ func (n *NodeServer) NodePublishVolume(ctx context.Context, req *csi.NodePublish
 	}
 	vol.Labels = volumeLabels
 
-	if err := checkStagingTargetPath(req.GetStagingTargetPath(), n.probeMounts); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
-	}
+	if req.GetVolumeCapability().GetBlock() != nil {
+		if err := n.publishBlockVolume(ctx, vol, req.GetTargetPath(), readOnly); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := checkStagingTargetPath(req.GetStagingTargetPath(), n.probeMounts); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 
-	if err := os.MkdirAll(req.GetTargetPath(), 0755); err != nil {
-		return nil, err
+		if err := os.MkdirAll(req.GetTargetPath(), 0755); err != nil {
+			return nil, err
+		}
+
+		if err := n.safeBindMount(req.GetStagingTargetPath(), req.GetTargetPath(), false, readOnly); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed volume publish: %v", err)
+		}
 	}
 
-	if err := n.safeBindMount(req.GetStagingTargetPath(), req.GetTargetPath(), false, req.GetReadonly()); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed volume publish: %v", err)
+	// Limits are applied once the volume is published so that a failed publish
+	// leaves no limits behind.
+	if !limits.IsZero() {
+		podUID := req.GetVolumeContext()[podUIDKey]
+		if err := n.setVolumeIOLimits(ctx, vol, podUID, limits); err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to set I/O limits; %v", err)
+		}
+		if vol.Status.ThrottledPods == nil {
+			vol.Status.ThrottledPods = map[string]string{}
+		}
+		vol.Status.ThrottledPods[req.GetTargetPath()] = podUID
 	}
 
 	conditions := vol.Status.Conditions
@@ -143,6 +211,7 @@ func (n *NodeServer) NodePublishVolume(ctx context.Context, req *csi.NodePublish
 			conditions[i].Reason = string(directcsi.DirectCSIVolumeReasonInUse)
 		}
 	}
+	vol.Status.TargetPaths = addTargetPath(getTargetPaths(vol), req.GetTargetPath())
 	vol.Status.ContainerPath = req.GetTargetPath()
 
 	_, err = volumeInterface.Update(ctx, vol, metav1.UpdateOptions{
@@ -155,6 +224,85 @@ func (n *NodeServer) NodePublishVolume(ctx context.Context, req *csi.NodePublish
 	return &csi.NodePublishVolumeResponse{}, nil
 }
 
+// publishBlockVolume bind-mounts the device of the drive dedicated to the raw block volume to target path.
+func (n *NodeServer) publishBlockVolume(ctx context.Context, vol *directcsi.DirectCSIVolume, targetPath string, readOnly bool) error {
+	drive, err := n.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(
+		ctx, vol.Status.Drive, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+	)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	if err := checkBlockDrive(drive, vol.Name); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	device, err := n.getDevice(drive.Status.MajorNumber, drive.Status.MinorNumber)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Unable to find device for major/minor %v:%v; %v", drive.Status.MajorNumber, drive.Status.MinorNumber, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return err
+	}
+
+	// Bind-mount of a device requires a regular file as target.
+	file, err := os.OpenFile(targetPath, os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	file.Close()
+
+	if err := n.safeBindMount(device, targetPath, false, readOnly); err != nil {
+		return status.Errorf(codes.Internal, "failed volume publish: %v", err)
+	}
+
+	return nil
+}
+
+// setVolumeIOLimits applies I/O limits on the drive of the volume to the cgroup of the pod.
+func (n *NodeServer) setVolumeIOLimits(ctx context.Context, vol *directcsi.DirectCSIVolume, podUID string, limits iothrottle.Limits) error {
+	if podUID == "" {
+		return fmt.Errorf("required volume context key not found: %v", podUIDKey)
+	}
+
+	drive, err := n.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(
+		ctx, vol.Status.Drive, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+	)
+	if err != nil {
+		return err
+	}
+
+	return n.setIOLimits(podUID, drive.Status.MajorNumber, drive.Status.MinorNumber, limits)
+}
+
+// clearVolumeIOLimits removes I/O limits on the drive of the volume from the cgroup of the pod.
+func (n *NodeServer) clearVolumeIOLimits(ctx context.Context, vol *directcsi.DirectCSIVolume, podUID string) error {
+	drive, err := n.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(
+		ctx, vol.Status.Drive, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = n.clearIOLimits(podUID, drive.Status.MajorNumber, drive.Status.MinorNumber)
+	if goerrors.Is(err, iothrottle.ErrCgroupNotFound) {
+		// Pod is already gone along with its cgroup.
+		return nil
+	}
+	return err
+}
+
+// isPodThrottled returns whether the pod has I/O limits set by any other publish of the volume.
+func isPodThrottled(throttledPods map[string]string, podUID string) bool {
+	for _, uid := range throttledPods {
+		if uid == podUID {
+			return true
+		}
+	}
+	return false
+}
+
 // NodeUnpublishVolume is node unpublish volume handler.
 func (n *NodeServer) NodeUnpublishVolume(ctx context.Context, req *csi.NodeUnpublishVolumeRequest) (*csi.NodeUnpublishVolumeResponse, error) {
 	klog.V(3).InfoS("NodeUnPublishVolumeRequest",
@@ -185,6 +333,45 @@ func (n *NodeServer) NodeUnpublishVolume(ctx context.Context, req *csi.NodeUnpub
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	if isEphemeralVolume(vol) {
+		if podUID, found := vol.Status.ThrottledPods[containerPath]; found {
+			if err := n.clearVolumeIOLimits(ctx, vol, podUID); err != nil {
+				return nil, status.Errorf(codes.Internal, "unable to clear I/O limits; %v", err)
+			}
+		}
+
+		// Volume controller removes the volume directory and releases the drive.
+		if err := vclient.Delete(ctx, vol.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return nil, status.Errorf(codes.Internal, "could not delete volume [%s]: %v", vol.Name, err)
+		}
+		return &csi.NodeUnpublishVolumeResponse{}, nil
+	}
+
+	if podUID, found := vol.Status.ThrottledPods[containerPath]; found {
+		delete(vol.Status.ThrottledPods, containerPath)
+		if !isPodThrottled(vol.Status.ThrottledPods, podUID) {
+			if err := n.clearVolumeIOLimits(ctx, vol, podUID); err != nil {
+				return nil, status.Errorf(codes.Internal, "unable to clear I/O limits; %v", err)
+			}
+		}
+		if len(vol.Status.ThrottledPods) == 0 {
+			vol.Status.ThrottledPods = nil
+		}
+	}
+
+	targetPaths := removeTargetPath(getTargetPaths(vol), containerPath)
+	if len(targetPaths) != 0 {
+		// Volume is still published to other pods on this node.
+		vol.Status.TargetPaths = targetPaths
+		vol.Status.ContainerPath = targetPaths[len(targetPaths)-1]
+		if _, err := vclient.Update(ctx, vol, metav1.UpdateOptions{
+			TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+		}); err != nil {
+			return nil, err
+		}
+		return &csi.NodeUnpublishVolumeResponse{}, nil
+	}
+
 	conditions := vol.Status.Conditions
 	for i, c := range conditions {
 		switch c.Type {
@@ -195,6 +382,7 @@ func (n *NodeServer) NodeUnpublishVolume(ctx context.Context, req *csi.NodeUnpub
 		case string(directcsi.DirectCSIVolumeConditionReady):
 		}
 	}
+	vol.Status.TargetPaths = nil
 	vol.Status.ContainerPath = ""
 
 	if _, err := vclient.Update(ctx, vol, metav1.UpdateOptions{
@@ -18,7 +18,9 @@ package node
 
 import (
 	"context"
+	"errors"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
@@ -26,7 +28,9 @@ import (
 	"github.com/minio/directpv/pkg/utils"
 
 	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/client"
 	fakedirect "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/iothrottle"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -191,3 +195,245 @@ func TestPublishUnpublishVolume(t *testing.T) {
 		t.Errorf("unexpected status.conditions after unstaging = %v", volObj.Status.Conditions)
 	}
 }
+
+func TestPublishUnpublishVolumeIOLimits(t *testing.T) {
+	client.FakeInit()
+
+	testStagingPath := t.TempDir()
+	testContainerPath := t.TempDir()
+	testOtherContainerPath := t.TempDir()
+	podUID := "0a1b2c3d-4e5f-6789-abcd-ef0123456789"
+
+	drive := &directcsi.DirectCSIDrive{
+		TypeMeta:   utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "drive-1"},
+		Status:     directcsi.DirectCSIDriveStatus{MajorNumber: 8, MinorNumber: 16},
+	}
+	volume := &directcsi.DirectCSIVolume{
+		TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "volume-1"},
+		Status:     directcsi.DirectCSIVolumeStatus{Drive: "drive-1", StagingPath: testStagingPath},
+	}
+
+	request := &csi.NodePublishVolumeRequest{
+		VolumeId:          "volume-1",
+		StagingTargetPath: testStagingPath,
+		TargetPath:        testContainerPath,
+		VolumeCapability: &csi.VolumeCapability{
+			AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+			AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+		},
+		VolumeContext: map[string]string{
+			podNameKey:                    "pod-1",
+			podNamespaceKey:               "default",
+			podUIDKey:                     podUID,
+			iothrottle.ReadBPSParameter:   "10Mi",
+			iothrottle.WriteIOPSParameter: "100",
+		},
+	}
+
+	var setUID, clearUID string
+	var setLimits iothrottle.Limits
+	ns := createFakeNodeServer()
+	ns.directcsiClient = fakedirect.NewSimpleClientset(drive, volume)
+	ns.probeMounts = func() (map[string][]sys.MountInfo, error) {
+		return map[string][]sys.MountInfo{"0:0": {{MountPoint: testStagingPath}}}, nil
+	}
+	ns.setIOLimits = func(podUID string, major, minor uint32, limits iothrottle.Limits) error {
+		if major != 8 || minor != 16 {
+			t.Fatalf("unexpected major/minor %v:%v", major, minor)
+		}
+		setUID, setLimits = podUID, limits
+		return nil
+	}
+	ns.clearIOLimits = func(podUID string, major, minor uint32) error {
+		clearUID = podUID
+		return iothrottle.ErrCgroupNotFound
+	}
+
+	ctx := context.TODO()
+	if _, err := ns.NodePublishVolume(ctx, request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedLimits := iothrottle.Limits{ReadBPS: 10 * 1024 * 1024, WriteIOPS: 100}
+	if setUID != podUID || setLimits != expectedLimits {
+		t.Fatalf("set I/O limits: expected: %v %+v, got: %v %+v", podUID, expectedLimits, setUID, setLimits)
+	}
+
+	volumeInterface := ns.directcsiClient.DirectV1beta3().DirectCSIVolumes()
+	volObj, err := volumeInterface.Get(ctx, "volume-1", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value := volObj.Status.ThrottledPods[testContainerPath]; value != podUID {
+		t.Fatalf("throttled pod: expected: %v, got: %v", podUID, value)
+	}
+
+	// Publishing to another pod keeps the I/O limits of each pod.
+	otherPodUID := "9a8b7c6d-5e4f-3210-abcd-ef0123456789"
+	otherRequest := &csi.NodePublishVolumeRequest{
+		VolumeId:          "volume-1",
+		StagingTargetPath: testStagingPath,
+		TargetPath:        testOtherContainerPath,
+		VolumeCapability:  request.VolumeCapability,
+		VolumeContext: map[string]string{
+			podNameKey:                  "pod-2",
+			podNamespaceKey:             "default",
+			podUIDKey:                   otherPodUID,
+			iothrottle.ReadBPSParameter: "20Mi",
+		},
+	}
+	if _, err := ns.NodePublishVolume(ctx, otherRequest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := ns.NodeUnpublishVolume(ctx, &csi.NodeUnpublishVolumeRequest{VolumeId: "volume-1", TargetPath: testOtherContainerPath}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clearUID != otherPodUID {
+		t.Fatalf("clear I/O limits: expected: %v, got: %v", otherPodUID, clearUID)
+	}
+	volObj, err = volumeInterface.Get(ctx, "volume-1", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedThrottledPods := map[string]string{testContainerPath: podUID}
+	if !reflect.DeepEqual(volObj.Status.ThrottledPods, expectedThrottledPods) {
+		t.Fatalf("throttled pods: expected: %v, got: %v", expectedThrottledPods, volObj.Status.ThrottledPods)
+	}
+
+	if _, err := ns.NodeUnpublishVolume(ctx, &csi.NodeUnpublishVolumeRequest{VolumeId: "volume-1", TargetPath: testContainerPath}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clearUID != podUID {
+		t.Fatalf("clear I/O limits: expected: %v, got: %v", podUID, clearUID)
+	}
+
+	volObj, err = volumeInterface.Get(ctx, "volume-1", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if volObj.Status.ThrottledPods != nil {
+		t.Fatalf("throttled pods are not removed; %v", volObj.Status.ThrottledPods)
+	}
+
+	// Failed publish must not set I/O limits.
+	setUID = ""
+	ns.safeBindMount = func(source, target string, recursive, readOnly bool) error {
+		return errors.New("mount failed")
+	}
+	if _, err := ns.NodePublishVolume(ctx, request); err == nil {
+		t.Fatalf("expected error for failed mount, but succeeded")
+	}
+	if setUID != "" {
+		t.Fatalf("I/O limits set for pod %v on failed publish", setUID)
+	}
+
+	request.VolumeContext[iothrottle.ReadBPSParameter] = "fast"
+	if _, err := ns.NodePublishVolume(ctx, request); err == nil {
+		t.Fatalf("expected error for invalid I/O limit, but succeeded")
+	}
+}
+
+func TestPublishUnpublishVolumeAccessModes(t *testing.T) {
+	client.FakeInit()
+
+	testStagingPath := t.TempDir()
+	testTargetPath1 := t.TempDir()
+	testTargetPath2 := t.TempDir()
+
+	volume := &directcsi.DirectCSIVolume{
+		TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "volume-1"},
+		Status: directcsi.DirectCSIVolumeStatus{
+			StagingPath: testStagingPath,
+			Conditions: []metav1.Condition{
+				{
+					Type:   string(directcsi.DirectCSIVolumeConditionPublished),
+					Status: metav1.ConditionFalse,
+					Reason: string(directcsi.DirectCSIVolumeReasonNotInUse),
+				},
+			},
+		},
+	}
+
+	newRequest := func(targetPath string, mode csi.VolumeCapability_AccessMode_Mode) *csi.NodePublishVolumeRequest {
+		return &csi.NodePublishVolumeRequest{
+			VolumeId:          "volume-1",
+			StagingTargetPath: testStagingPath,
+			TargetPath:        targetPath,
+			VolumeCapability: &csi.VolumeCapability{
+				AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+				AccessMode: &csi.VolumeCapability_AccessMode{Mode: mode},
+			},
+		}
+	}
+
+	readOnlyMounts := map[string]bool{}
+	ns := createFakeNodeServer()
+	ns.directcsiClient = fakedirect.NewSimpleClientset(volume)
+	ns.probeMounts = func() (map[string][]sys.MountInfo, error) {
+		return map[string][]sys.MountInfo{"0:0": {{MountPoint: testStagingPath}}}, nil
+	}
+	ns.safeBindMount = func(source, target string, recursive, readOnly bool) error {
+		readOnlyMounts[target] = readOnly
+		return nil
+	}
+
+	ctx := context.TODO()
+	volumeInterface := ns.directcsiClient.DirectV1beta3().DirectCSIVolumes()
+	getVolume := func() *directcsi.DirectCSIVolume {
+		volObj, err := volumeInterface.Get(ctx, "volume-1", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return volObj
+	}
+
+	if _, err := ns.NodePublishVolume(ctx, newRequest(testTargetPath1, csi.VolumeCapability_AccessMode_SINGLE_NODE_MULTI_WRITER)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := ns.NodePublishVolume(ctx, newRequest(testTargetPath2, csi.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if readOnlyMounts[testTargetPath1] || !readOnlyMounts[testTargetPath2] {
+		t.Fatalf("read-only mounts: expected: %v=false, %v=true, got: %v", testTargetPath1, testTargetPath2, readOnlyMounts)
+	}
+
+	volObj := getVolume()
+	expectedTargetPaths := []string{testTargetPath1, testTargetPath2}
+	if !reflect.DeepEqual(volObj.Status.TargetPaths, expectedTargetPaths) {
+		t.Fatalf("target paths: expected: %v, got: %v", expectedTargetPaths, volObj.Status.TargetPaths)
+	}
+
+	// ReadWriteOncePod must not be published to another target path.
+	if _, err := ns.NodePublishVolume(ctx, newRequest(t.TempDir(), csi.VolumeCapability_AccessMode_SINGLE_NODE_SINGLE_WRITER)); err == nil {
+		t.Fatalf("expected error for single node single writer, but succeeded")
+	}
+
+	if _, err := ns.NodeUnpublishVolume(ctx, &csi.NodeUnpublishVolumeRequest{VolumeId: "volume-1", TargetPath: testTargetPath1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	volObj = getVolume()
+	if !reflect.DeepEqual(volObj.Status.TargetPaths, []string{testTargetPath2}) || volObj.Status.ContainerPath != testTargetPath2 {
+		t.Fatalf("expected: %v, got: target paths %v, container path %v", testTargetPath2, volObj.Status.TargetPaths, volObj.Status.ContainerPath)
+	}
+	if !utils.IsCondition(volObj.Status.Conditions, string(directcsi.DirectCSIVolumeConditionPublished), metav1.ConditionTrue, string(directcsi.DirectCSIVolumeReasonInUse), "") {
+		t.Fatalf("unexpected status.conditions after partial unpublish = %v", volObj.Status.Conditions)
+	}
+
+	if _, err := ns.NodeUnpublishVolume(ctx, &csi.NodeUnpublishVolumeRequest{VolumeId: "volume-1", TargetPath: testTargetPath2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	volObj = getVolume()
+	if len(volObj.Status.TargetPaths) != 0 || volObj.Status.ContainerPath != "" {
+		t.Fatalf("expected empty target paths, got: target paths %v, container path %v", volObj.Status.TargetPaths, volObj.Status.ContainerPath)
+	}
+	if !utils.IsCondition(volObj.Status.Conditions, string(directcsi.DirectCSIVolumeConditionPublished), metav1.ConditionFalse, string(directcsi.DirectCSIVolumeReasonNotInUse), "") {
+		t.Fatalf("unexpected status.conditions after unpublish = %v", volObj.Status.Conditions)
+	}
+
+	// ReadWriteOncePod can be published once nothing else uses the volume.
+	if _, err := ns.NodePublishVolume(ctx, newRequest(testTargetPath1, csi.VolumeCapability_AccessMode_SINGLE_NODE_SINGLE_WRITER)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -18,6 +18,7 @@ package node
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -60,6 +61,11 @@ func (n *NodeServer) NodeStageVolume(ctx context.Context, req *csi.NodeStageVolu
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
+	// Volume directory is being copied to the target drive; writes to the source are lost.
+	if vol.Status.TargetDrive != "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "volume %v is being moved to drive %v", vID, vol.Status.TargetDrive)
+	}
+
 	drive, err := dclient.Get(ctx, vol.Status.Drive, metav1.GetOptions{
 		TypeMeta: utils.DirectCSIDriveTypeMeta(),
 	})
@@ -67,30 +73,16 @@ func (n *NodeServer) NodeStageVolume(ctx context.Context, req *csi.NodeStageVolu
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
-	if err := checkDrive(drive, req.GetVolumeId(), n.probeMounts); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
-	}
-
-	path := filepath.Join(drive.Status.Mountpoint, vID)
-	if err := os.MkdirAll(path, 0755); err != nil {
-		return nil, err
-	}
-
-	if err := n.safeBindMount(path, stagingTargetPath, false, false); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed stage volume: %v", err)
-	}
-
-	quota := xfs.Quota{
-		HardLimit: uint64(vol.Status.TotalCapacity),
-		SoftLimit: uint64(vol.Status.TotalCapacity),
-	}
-
-	device, err := n.getDevice(drive.Status.MajorNumber, drive.Status.MinorNumber)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Unable to find device for major/minor %v:%v; %v", drive.Status.MajorNumber, drive.Status.MinorNumber, err)
-	}
-	if err := n.setQuota(ctx, device, stagingTargetPath, vID, quota); err != nil {
-		return nil, status.Errorf(codes.Internal, "Error while setting xfs limits: %v", err)
+	if req.GetVolumeCapability().GetBlock() != nil {
+		if err := n.stageBlockVolume(ctx, drive, vID); err != nil {
+			return nil, err
+		}
+	} else {
+		path, err := n.stageFilesystemVolume(ctx, vol, drive, stagingTargetPath)
+		if err != nil {
+			return nil, err
+		}
+		vol.Status.HostPath = path
 	}
 
 	conditions := vol.Status.Conditions
@@ -106,7 +98,6 @@ func (n *NodeServer) NodeStageVolume(ctx context.Context, req *csi.NodeStageVolu
 		}
 	}
 
-	vol.Status.HostPath = path
 	vol.Status.StagingPath = stagingTargetPath
 
 	if _, err := vclient.Update(ctx, vol, metav1.UpdateOptions{
@@ -118,6 +109,81 @@ func (n *NodeServer) NodeStageVolume(ctx context.Context, req *csi.NodeStageVolu
 	return &csi.NodeStageVolumeResponse{}, nil
 }
 
+// stageFilesystemVolume creates volume directory on the drive and bind-mounts it to staging target path.
+func (n *NodeServer) stageFilesystemVolume(ctx context.Context, vol *directcsi.DirectCSIVolume, drive *directcsi.DirectCSIDrive, stagingTargetPath string) (string, error) {
+	if err := checkDrive(drive, vol.Name, n.probeMounts); err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+
+	device, err := n.getDevice(drive.Status.MajorNumber, drive.Status.MinorNumber)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "Unable to find device for major/minor %v:%v; %v", drive.Status.MajorNumber, drive.Status.MinorNumber, err)
+	}
+
+	quota := xfs.Quota{
+		HardLimit: uint64(vol.Status.TotalCapacity),
+		SoftLimit: uint64(vol.Status.TotalCapacity),
+	}
+
+	path := filepath.Join(drive.Status.Mountpoint, vol.Name)
+	if vol.Status.ContentSource != nil {
+		if err := n.populateVolume(ctx, vol, device, path, quota); err != nil {
+			return "", status.Errorf(codes.Internal, "unable to populate volume from %v %v; %v", vol.Status.ContentSource.Kind, vol.Status.ContentSource.Name, err)
+		}
+	}
+
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return "", err
+	}
+
+	if err := n.safeBindMount(path, stagingTargetPath, false, false); err != nil {
+		return "", status.Errorf(codes.Internal, "failed stage volume: %v", err)
+	}
+
+	if err := n.setQuota(ctx, device, stagingTargetPath, vol.Name, quota); err != nil {
+		return "", status.Errorf(codes.Internal, "Error while setting xfs limits: %v", err)
+	}
+
+	return path, nil
+}
+
+// stageBlockVolume unmounts the drive dedicated to the raw block volume so that
+// the device is exclusively used by the volume.
+func (n *NodeServer) stageBlockVolume(ctx context.Context, drive *directcsi.DirectCSIDrive, volumeID string) error {
+	if err := checkBlockDrive(drive, volumeID); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	device, err := n.getDevice(drive.Status.MajorNumber, drive.Status.MinorNumber)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Unable to find device for major/minor %v:%v; %v", drive.Status.MajorNumber, drive.Status.MinorNumber, err)
+	}
+
+	if err := n.unmountDevice(device); err != nil {
+		return status.Errorf(codes.Internal, "unable to unmount drive %v; %v", drive.Name, err)
+	}
+
+	if drive.Status.Mountpoint == "" {
+		return nil
+	}
+
+	drive.Status.Mountpoint = ""
+	utils.UpdateCondition(
+		drive.Status.Conditions,
+		string(directcsi.DirectCSIDriveConditionMounted),
+		metav1.ConditionFalse,
+		string(directcsi.DirectCSIDriveReasonAdded),
+		string(directcsi.DirectCSIDriveMessageNotMounted),
+	)
+	if _, err := n.directcsiClient.DirectV1beta3().DirectCSIDrives().Update(
+		ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+	); err != nil {
+		return status.Errorf(codes.Internal, "unable to update drive %v; %v", drive.Name, err)
+	}
+
+	return nil
+}
+
 // NodeUnstageVolume is node unstage volume request handler.
 func (n *NodeServer) NodeUnstageVolume(ctx context.Context, req *csi.NodeUnstageVolumeRequest) (*csi.NodeUnstageVolumeResponse, error) {
 	klog.V(3).InfoS("NodeUnstageVolumeRequest",
@@ -171,3 +237,74 @@ func (n *NodeServer) NodeUnstageVolume(ctx context.Context, req *csi.NodeUnstage
 
 	return &csi.NodeUnstageVolumeResponse{}, nil
 }
+
+// populateVolume copies the content source of the volume into path if path does not exist.
+func (n *NodeServer) populateVolume(ctx context.Context, vol *directcsi.DirectCSIVolume, device, path string, quota xfs.Quota) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	var sourcePath string
+	emptySource := false
+	switch vol.Status.ContentSource.Kind {
+	case directcsi.VolumeContentSourceKindSnapshot:
+		snapshot, err := n.directcsiClient.DirectV1beta3().DirectCSISnapshots().Get(
+			ctx, vol.Status.ContentSource.Name, metav1.GetOptions{TypeMeta: utils.DirectCSISnapshotTypeMeta()},
+		)
+		if err != nil {
+			return err
+		}
+		if !snapshot.Status.ReadyToUse {
+			return fmt.Errorf("snapshot %v is not ready to use", snapshot.Name)
+		}
+		sourcePath = snapshot.Status.HostPath
+	case directcsi.VolumeContentSourceKindVolume:
+		volume, err := n.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(
+			ctx, vol.Status.ContentSource.Name, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()},
+		)
+		if err != nil {
+			return err
+		}
+		if volume.Status.Drive != vol.Status.Drive {
+			return fmt.Errorf("volume %v is not on drive %v", volume.Name, vol.Status.Drive)
+		}
+		sourcePath = filepath.Join(filepath.Dir(path), volume.Name)
+
+		// The source volume directory does not exist until it is staged.
+		if volume.Status.HostPath == "" {
+			if volume.Status.ContentSource != nil {
+				return fmt.Errorf("volume %v is not populated from its content source yet", volume.Name)
+			}
+			emptySource = true
+		}
+	default:
+		return fmt.Errorf("unknown content source kind %v", vol.Status.ContentSource.Kind)
+	}
+
+	// Copy into a temporary directory and rename it on completion so that
+	// a partially copied volume is never staged.
+	tmpPath := path + ".tmp"
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tmpPath, 0755); err != nil {
+		return err
+	}
+
+	// Set the quota before copying so that the copied files are accounted
+	// in the project of this volume.
+	if err := n.setQuota(ctx, device, tmpPath, vol.Name, quota); err != nil {
+		return err
+	}
+
+	if !emptySource {
+		klog.V(3).InfoS("Populating volume", "volume", vol.Name, "source", sourcePath)
+		if err := n.copyDir(sourcePath, tmpPath, true); err != nil {
+			return err
+		}
+	}
+
+	return os.Rename(tmpPath, path)
+}
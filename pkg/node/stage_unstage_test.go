@@ -23,8 +23,11 @@ import (
 	"testing"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/minio/directpv/pkg/fs/xfs"
 	"github.com/minio/directpv/pkg/sys"
 	"github.com/minio/directpv/pkg/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
@@ -105,6 +108,34 @@ func TestNodeStageVolume(t *testing.T) {
 	}
 }
 
+func TestNodeStageVolumeMoving(t *testing.T) {
+	req := &csi.NodeStageVolumeRequest{
+		VolumeId:          "volume-id-1",
+		StagingTargetPath: "volume-id-1-staging-target-path",
+		VolumeCapability: &csi.VolumeCapability{
+			AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+			AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+		},
+	}
+	volume := &directcsi.DirectCSIVolume{
+		TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: req.VolumeId},
+		Status: directcsi.DirectCSIVolumeStatus{
+			NodeName:      testNodeName,
+			Drive:         "drive-1",
+			TargetDrive:   "drive-2",
+			TotalCapacity: 100 * MB,
+		},
+	}
+
+	nodeServer := createFakeNodeServer()
+	nodeServer.directcsiClient = fakedirect.NewSimpleClientset(volume)
+	_, err := nodeServer.NodeStageVolume(context.TODO(), req)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected: %v, got: %v", codes.FailedPrecondition, err)
+	}
+}
+
 func TestStageUnstageVolume(t *testing.T) {
 	testDriveName := "test_drive"
 	testVolumeName50MB := "test_volume_50MB"
@@ -247,3 +278,182 @@ func TestStageUnstageVolume(t *testing.T) {
 		t.Errorf("unexpected status.conditions after unstaging = %v", volObj.Status.Conditions)
 	}
 }
+
+func TestPopulateVolume(t *testing.T) {
+	mountpoint := t.TempDir()
+
+	newVolume := func(name string, contentSource *directcsi.VolumeContentSource) *directcsi.DirectCSIVolume {
+		return &directcsi.DirectCSIVolume{
+			TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: directcsi.DirectCSIVolumeStatus{
+				NodeName:      testNodeName,
+				Drive:         "drive-1",
+				TotalCapacity: mb20,
+				ContentSource: contentSource,
+			},
+		}
+	}
+
+	sourceVolume := newVolume("source-volume", nil)
+	sourceVolume.Status.HostPath = filepath.Join(mountpoint, "source-volume")
+	unstagedVolume := newVolume("unstaged-volume", nil)
+	unpopulatedVolume := newVolume("unpopulated-volume", &directcsi.VolumeContentSource{Kind: directcsi.VolumeContentSourceKindVolume, Name: "source-volume"})
+	otherVolume := newVolume("other-volume", nil)
+	otherVolume.Status.Drive = "drive-2"
+	snapshot := &directcsi.DirectCSISnapshot{
+		TypeMeta:   utils.DirectCSISnapshotTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "snapshot-1"},
+		Status: directcsi.DirectCSISnapshotStatus{
+			SourceVolume: "source-volume",
+			Drive:        "drive-1",
+			HostPath:     filepath.Join(mountpoint, ".snapshots", "snapshot-1"),
+			ReadyToUse:   true,
+		},
+	}
+
+	testCases := []struct {
+		contentSource  *directcsi.VolumeContentSource
+		copyErr        error
+		expectedSource string
+		expectErr      bool
+	}{
+		{&directcsi.VolumeContentSource{Kind: directcsi.VolumeContentSourceKindVolume, Name: "source-volume"}, nil, filepath.Join(mountpoint, "source-volume"), false},
+		{&directcsi.VolumeContentSource{Kind: directcsi.VolumeContentSourceKindSnapshot, Name: "snapshot-1"}, nil, snapshot.Status.HostPath, false},
+		{&directcsi.VolumeContentSource{Kind: directcsi.VolumeContentSourceKindVolume, Name: "unstaged-volume"}, nil, "", false},
+		{&directcsi.VolumeContentSource{Kind: directcsi.VolumeContentSourceKindVolume, Name: "unpopulated-volume"}, nil, "", true},
+		{&directcsi.VolumeContentSource{Kind: directcsi.VolumeContentSourceKindVolume, Name: "source-volume"}, os.ErrNotExist, "", true},
+		{&directcsi.VolumeContentSource{Kind: directcsi.VolumeContentSourceKindSnapshot, Name: "snapshot-1"}, os.ErrNotExist, "", true},
+		{&directcsi.VolumeContentSource{Kind: directcsi.VolumeContentSourceKindVolume, Name: "other-volume"}, nil, "", true},
+		{&directcsi.VolumeContentSource{Kind: directcsi.VolumeContentSourceKindVolume, Name: "unknown-volume"}, nil, "", true},
+		{&directcsi.VolumeContentSource{Kind: "unknown", Name: "source-volume"}, nil, "", true},
+	}
+
+	for i, testCase := range testCases {
+		volume := newVolume("volume-1", testCase.contentSource)
+		path := filepath.Join(mountpoint, volume.Name)
+
+		var source string
+		nodeServer := createFakeNodeServer()
+		nodeServer.directcsiClient = fakedirect.NewSimpleClientset(sourceVolume, unstagedVolume, unpopulatedVolume, otherVolume, snapshot)
+		nodeServer.copyDir = func(src, dst string, fallback bool) error {
+			source = src
+			return testCase.copyErr
+		}
+
+		err := nodeServer.populateVolume(context.TODO(), volume, "", path, xfs.Quota{})
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Fatalf("case %v: expected no volume directory; %v", i+1, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if source != testCase.expectedSource {
+			t.Fatalf("case %v: expected source: %v, got: %v", i+1, testCase.expectedSource, source)
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("case %v: volume directory not found; %v", i+1, err)
+		}
+		if err := os.RemoveAll(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestStagePublishBlockVolume(t *testing.T) {
+	drive := &directcsi.DirectCSIDrive{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "drive-1",
+			Finalizers: []string{
+				directcsi.DirectCSIDriveFinalizerDataProtection,
+				directcsi.DirectCSIDriveFinalizerPrefix + "volume-1",
+			},
+		},
+		Status: directcsi.DirectCSIDriveStatus{
+			NodeName:      testNodeName,
+			DriveStatus:   directcsi.DriveStatusInUse,
+			Mountpoint:    "/var/lib/direct-csi/mnt/drive-1",
+			TotalCapacity: mb100,
+			BlockVolume:   "volume-1",
+		},
+	}
+	volume := &directcsi.DirectCSIVolume{
+		TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "volume-1"},
+		Status: directcsi.DirectCSIVolumeStatus{
+			NodeName:      testNodeName,
+			Drive:         "drive-1",
+			TotalCapacity: mb100,
+		},
+	}
+	capability := &csi.VolumeCapability{
+		AccessType: &csi.VolumeCapability_Block{Block: &csi.VolumeCapability_BlockVolume{}},
+		AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+	}
+
+	ctx := context.TODO()
+	var unmountedDevice, mountSource string
+	nodeServer := createFakeNodeServer()
+	nodeServer.directcsiClient = fakedirect.NewSimpleClientset(drive, volume)
+	nodeServer.getDevice = func(major, minor uint32) (string, error) { return "/dev/sdb", nil }
+	nodeServer.unmountDevice = func(device string) error {
+		unmountedDevice = device
+		return nil
+	}
+	nodeServer.safeBindMount = func(source, target string, recursive, readOnly bool) error {
+		mountSource = source
+		return nil
+	}
+
+	_, err := nodeServer.NodeStageVolume(ctx, &csi.NodeStageVolumeRequest{
+		VolumeId:          "volume-1",
+		StagingTargetPath: "/path/to/staging",
+		VolumeCapability:  capability,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if unmountedDevice != "/dev/sdb" {
+		t.Fatalf("expected unmounted device: /dev/sdb, got: %v", unmountedDevice)
+	}
+
+	drive, err = nodeServer.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(ctx, "drive-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if drive.Status.Mountpoint != "" {
+		t.Fatalf("drive mountpoint is not cleared; %v", drive.Status.Mountpoint)
+	}
+
+	volume, err = nodeServer.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(ctx, "volume-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if volume.Status.StagingPath != "/path/to/staging" || volume.Status.HostPath != "" {
+		t.Fatalf("unexpected volume status %+v", volume.Status)
+	}
+
+	targetPath := filepath.Join(t.TempDir(), "volumeDevices", "volume-1")
+	_, err = nodeServer.NodePublishVolume(ctx, &csi.NodePublishVolumeRequest{
+		VolumeId:          "volume-1",
+		StagingTargetPath: "/path/to/staging",
+		TargetPath:        targetPath,
+		VolumeCapability:  capability,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if mountSource != "/dev/sdb" {
+		t.Fatalf("expected mount source: /dev/sdb, got: %v", mountSource)
+	}
+	if info, err := os.Stat(targetPath); err != nil || !info.Mode().IsRegular() {
+		t.Fatalf("target path is not a regular file; %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/listener"
+	"github.com/minio/directpv/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// getTopology returns topology segments of this node.
+func (ns *NodeServer) getTopology() map[string]string {
+	ns.topologyMutex.RLock()
+	defer ns.topologyMutex.RUnlock()
+
+	topology := map[string]string{
+		string(utils.TopologyDriverIdentity): ns.Identity,
+		string(utils.TopologyDriverRack):     ns.Rack,
+		string(utils.TopologyDriverZone):     ns.Zone,
+		string(utils.TopologyDriverRegion):   ns.Region,
+		string(utils.TopologyDriverNode):     ns.NodeID,
+	}
+	for key, value := range ns.customTopology {
+		topology[key] = value
+	}
+	return topology
+}
+
+// setTopology sets rack, zone, region and custom topology of this node and returns whether any of them changed.
+func (ns *NodeServer) setTopology(rack, zone, region string, customTopology map[string]string) bool {
+	ns.topologyMutex.Lock()
+	defer ns.topologyMutex.Unlock()
+
+	if ns.Rack == rack && ns.Zone == zone && ns.Region == region && reflect.DeepEqual(ns.customTopology, customTopology) {
+		return false
+	}
+
+	klog.V(3).InfoS("Node topology changed", "rack", rack, "zone", zone, "region", region, "custom", customTopology)
+	ns.Rack, ns.Zone, ns.Region, ns.customTopology = rack, zone, region, customTopology
+	return true
+}
+
+// syncDriveTopology sets topology of this node to all its drives.
+func (ns *NodeServer) syncDriveTopology(ctx context.Context) error {
+	topology := ns.getTopology()
+	driveInterface := ns.directcsiClient.DirectV1beta3().DirectCSIDrives()
+	driveList, err := driveInterface.List(ctx, metav1.ListOptions{
+		TypeMeta:      utils.DirectCSIDriveTypeMeta(),
+		LabelSelector: fmt.Sprintf("%s=%s", utils.NodeLabelKey, utils.NewLabelValue(ns.NodeID)),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range driveList.Items {
+		if reflect.DeepEqual(item.Status.Topology, topology) {
+			continue
+		}
+
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			drive, err := driveInterface.Get(ctx, item.Name, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+			if err != nil {
+				return err
+			}
+			drive.Status.Topology = topology
+			_, err = driveInterface.Update(ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		klog.V(3).InfoS("Updated drive topology", "drive", item.Name, "topology", topology)
+	}
+
+	return nil
+}
+
+// topologyEventHandler derives topology of the node from its labels. Flag values
+// are used for the labels not set on the node.
+type topologyEventHandler struct {
+	nodeServer   *NodeServer
+	rackLabel    string
+	topologyKeys []string
+	rack         string
+	zone         string
+	region       string
+}
+
+func newTopologyEventHandler(nodeServer *NodeServer, rackLabel string, topologyKeys []string) *topologyEventHandler {
+	return &topologyEventHandler{
+		nodeServer:   nodeServer,
+		rackLabel:    rackLabel,
+		topologyKeys: topologyKeys,
+		rack:         nodeServer.Rack,
+		zone:         nodeServer.Zone,
+		region:       nodeServer.Region,
+	}
+}
+
+// getCustomTopology returns custom topology segments from node labels; "default"
+// is used for the labels not set on the node.
+func (handler *topologyEventHandler) getCustomTopology(labels map[string]string) map[string]string {
+	if len(handler.topologyKeys) == 0 {
+		return nil
+	}
+
+	topology := map[string]string{}
+	for _, labelKey := range handler.topologyKeys {
+		value := labels[labelKey]
+		if value == "" {
+			value = "default"
+		}
+		topology[string(utils.NewTopologyKey(labelKey))] = value
+	}
+	return topology
+}
+
+// getNodeTopology returns rack, zone and region from node labels.
+func (handler *topologyEventHandler) getNodeTopology(labels map[string]string) (rack, zone, region string) {
+	getValue := func(key, defaultValue string) string {
+		if value := labels[key]; key != "" && value != "" {
+			return value
+		}
+		return defaultValue
+	}
+
+	return getValue(handler.rackLabel, handler.rack),
+		getValue(corev1.LabelTopologyZone, handler.zone),
+		getValue(corev1.LabelTopologyRegion, handler.region)
+}
+
+// setTopology sets topology from node labels and returns whether it changed.
+func (handler *topologyEventHandler) setTopology(node *corev1.Node) bool {
+	rack, zone, region := handler.getNodeTopology(node.GetLabels())
+	return handler.nodeServer.setTopology(rack, zone, region, handler.getCustomTopology(node.GetLabels()))
+}
+
+// update syncs topology of the node to the drives. As kubelet reads the topology
+// of the node by NodeGetInfo only while the plugin registers, changed topology is
+// reported to be picked up by registering the plugin again.
+func (handler *topologyEventHandler) update(ctx context.Context, node *corev1.Node) error {
+	if handler.setTopology(node) {
+		klog.InfoS("Node topology changed; plugin must be registered again for kubelet to use it", "node", node.Name)
+		client.Eventf(node, corev1.EventTypeWarning, "TopologyChanged",
+			"topology of node %v changed; restart the node-driver-registrar of DirectPV on the node to register the plugin again", node.Name)
+	}
+	return handler.nodeServer.syncDriveTopology(ctx)
+}
+
+func (handler *topologyEventHandler) ListerWatcher() cache.ListerWatcher {
+	optionsModifier := func(options *metav1.ListOptions) {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", handler.nodeServer.NodeID).String()
+	}
+
+	return cache.NewFilteredListWatchFromClient(
+		client.GetKubeClient().CoreV1().RESTClient(),
+		"nodes",
+		"",
+		optionsModifier,
+	)
+}
+
+func (handler *topologyEventHandler) KubeClient() kubernetes.Interface {
+	return client.GetKubeClient()
+}
+
+func (handler *topologyEventHandler) Name() string {
+	return "topology"
+}
+
+func (handler *topologyEventHandler) ObjectType() runtime.Object {
+	return &corev1.Node{}
+}
+
+func (handler *topologyEventHandler) Handle(ctx context.Context, args listener.EventArgs) error {
+	switch args.Event {
+	case listener.AddEvent, listener.UpdateEvent:
+		return handler.update(ctx, args.Object.(*corev1.Node))
+	}
+	return nil
+}
+
+// readTopology reads topology from the node labels.
+func readTopology(ctx context.Context, handler *topologyEventHandler) {
+	node, err := client.GetKubeClient().CoreV1().Nodes().Get(ctx, handler.nodeServer.NodeID, metav1.GetOptions{})
+	if err != nil {
+		klog.ErrorS(err, "unable to get node; using topology from flags", "node", handler.nodeServer.NodeID)
+		return
+	}
+	handler.setTopology(node)
+}
+
+// GetTopology returns topology segments of the node derived from its labels; flag
+// values are used for the labels not set on the node.
+func GetTopology(ctx context.Context, identity, nodeID, rack, zone, region, rackLabel string, topologyKeys []string) map[string]string {
+	nodeServer := &NodeServer{
+		NodeID:   nodeID,
+		Identity: identity,
+		Rack:     rack,
+		Zone:     zone,
+		Region:   region,
+	}
+	readTopology(ctx, newTopologyEventHandler(nodeServer, rackLabel, topologyKeys))
+	return nodeServer.getTopology()
+}
+
+// initTopology reads topology from the node labels and starts a controller to follow their changes.
+func initTopology(ctx context.Context, nodeServer *NodeServer, rackLabel string, topologyKeys []string) {
+	handler := newTopologyEventHandler(nodeServer, rackLabel, topologyKeys)
+	readTopology(ctx, handler)
+
+	go func() {
+		hostname, err := os.Hostname()
+		if err != nil {
+			klog.Error(err)
+			return
+		}
+
+		listener := listener.NewListener(handler, "topology-controller", hostname, 1)
+		if err := listener.Run(ctx); err != nil {
+			klog.Error(err)
+		}
+	}()
+}
@@ -0,0 +1,155 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/client"
+	fakedirect "github.com/minio/directpv/pkg/clientset/fake"
+	"github.com/minio/directpv/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetNodeTopology(t *testing.T) {
+	testCases := []struct {
+		rackLabel      string
+		labels         map[string]string
+		expectedRack   string
+		expectedZone   string
+		expectedRegion string
+	}{
+		{"", nil, "default", "default", "default"},
+		{
+			"",
+			map[string]string{corev1.LabelTopologyZone: "zone-a", corev1.LabelTopologyRegion: "region-1", "rack": "rack-1"},
+			"default", "zone-a", "region-1",
+		},
+		{
+			"rack",
+			map[string]string{corev1.LabelTopologyZone: "zone-a", "rack": "rack-1"},
+			"rack-1", "zone-a", "default",
+		},
+		{"rack", map[string]string{"rack": ""}, "default", "default", "default"},
+	}
+
+	ns := createFakeNodeServer()
+	for i, testCase := range testCases {
+		handler := &topologyEventHandler{nodeServer: ns, rackLabel: testCase.rackLabel, rack: "default", zone: "default", region: "default"}
+		rack, zone, region := handler.getNodeTopology(testCase.labels)
+		if rack != testCase.expectedRack || zone != testCase.expectedZone || region != testCase.expectedRegion {
+			t.Fatalf("case %v: expected: %v/%v/%v, got: %v/%v/%v", i+1, testCase.expectedRack, testCase.expectedZone, testCase.expectedRegion, rack, zone, region)
+		}
+	}
+}
+
+func TestTopologyUpdate(t *testing.T) {
+	newDrive := func(name, nodeID string) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{string(utils.NodeLabelKey): nodeID},
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName: nodeID,
+				Topology: map[string]string{string(utils.TopologyDriverZone): "default"},
+			},
+		}
+	}
+
+	client.FakeInit()
+	ns := createFakeNodeServer()
+	ns.directcsiClient = fakedirect.NewSimpleClientset(newDrive("drive-1", testNodeName), newDrive("drive-2", "other-node"))
+	handler := newTopologyEventHandler(ns, "example.com/rack", []string{"example.com/enclosure", "power-domain"})
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testNodeName,
+			Labels: map[string]string{
+				corev1.LabelTopologyZone:   "zone-a",
+				corev1.LabelTopologyRegion: "region-1",
+				"example.com/rack":         "rack-1",
+				"example.com/enclosure":    "enclosure-1",
+			},
+		},
+	}
+
+	// Topology read at startup is synced to the drives.
+	if !handler.setTopology(node) {
+		t.Fatalf("expected topology to be changed")
+	}
+	ctx := context.TODO()
+	if err := handler.update(ctx, node); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedTopology := map[string]string{
+		string(utils.TopologyDriverIdentity): "test-identity",
+		string(utils.TopologyDriverRack):     "rack-1",
+		string(utils.TopologyDriverZone):     "zone-a",
+		string(utils.TopologyDriverRegion):   "region-1",
+		string(utils.TopologyDriverNode):     testNodeName,
+		"direct.csi.min.io/enclosure":        "enclosure-1",
+		"direct.csi.min.io/power-domain":     "default",
+	}
+	response, err := ns.NodeGetInfo(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(response.GetAccessibleTopology().GetSegments(), expectedTopology) {
+		t.Fatalf("node topology: expected: %v, got: %v", expectedTopology, response.GetAccessibleTopology().GetSegments())
+	}
+
+	driveInterface := ns.directcsiClient.DirectV1beta3().DirectCSIDrives()
+	drive, err := driveInterface.Get(ctx, "drive-1", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(drive.Status.Topology, expectedTopology) {
+		t.Fatalf("drive topology: expected: %v, got: %v", expectedTopology, drive.Status.Topology)
+	}
+
+	drive, err = driveInterface.Get(ctx, "drive-2", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if drive.Status.Topology[string(utils.TopologyDriverZone)] != "default" {
+		t.Fatalf("drive of other node is updated; %v", drive.Status.Topology)
+	}
+
+	// Removing the labels falls back to the initial topology which is applied in place.
+	node.Labels = nil
+	if err := handler.update(ctx, node); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if topology := ns.getTopology(); topology[string(utils.TopologyDriverZone)] != "test-zone" || topology[string(utils.TopologyDriverRack)] != "test-rack" {
+		t.Fatalf("unexpected topology %v", topology)
+	}
+	drive, err = driveInterface.Get(ctx, "drive-1", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(drive.Status.Topology, ns.getTopology()) {
+		t.Fatalf("drive topology: expected: %v, got: %v", ns.getTopology(), drive.Status.Topology)
+	}
+}
@@ -39,12 +39,17 @@ import (
 )
 
 func mountDrive(ctx context.Context, drive *directcsi.DirectCSIDrive) {
+	// Drive dedicated to a raw block volume must not be mounted.
+	if drive.Status.BlockVolume != "" {
+		return
+	}
+
 	target := filepath.Join(sys.MountRoot, drive.Status.FilesystemUUID)
 	var flags []string
 	if drive.Spec.RequestedFormat != nil {
 		flags = drive.Spec.RequestedFormat.MountOptions
 	}
-	err := sys.MountXFSDevice(drive.Status.Path, target, flags)
+	err := sys.MountDevice(drive.Status.Path, target, drive.Status.Filesystem, flags)
 	if err == nil {
 		return
 	}
@@ -73,12 +78,22 @@ func mountDrive(ctx context.Context, drive *directcsi.DirectCSIDrive) {
 type ueventHandler struct {
 	listener              *uevent.Listener
 	nodeID                string
-	topology              map[string]string
+	getTopology           func() map[string]string
 	dynamicDriveDiscovery bool
 	loopbackOnly          bool
+	autoAccessTier        bool
 	syncMu                sync.Mutex
 }
 
+// newDrive creates new drive object of the device.
+func (handler *ueventHandler) newDrive(device *sys.Device) *directcsi.DirectCSIDrive {
+	status := client.NewDirectCSIDriveStatus(device, handler.nodeID, handler.getTopology())
+	if handler.autoAccessTier {
+		status.AccessTier = client.GetMediaAccessTier(status)
+	}
+	return client.NewDirectCSIDrive(uuid.New().String(), status)
+}
+
 func (handler *ueventHandler) syncDrive(
 	ctx context.Context,
 	devices map[string]*sys.Device,
@@ -212,10 +227,7 @@ func (handler *ueventHandler) syncDrives(ctx context.Context) {
 			continue
 		}
 
-		drive := client.NewDirectCSIDrive(
-			uuid.New().String(),
-			client.NewDirectCSIDriveStatus(device, handler.nodeID, handler.topology),
-		)
+		drive := handler.newDrive(device)
 
 		err := retry.RetryOnConflict(
 			retry.DefaultRetry,
@@ -317,10 +329,7 @@ func (handler *ueventHandler) processEvent(ctx context.Context, device *sys.Devi
 		return
 	}
 
-	drive := client.NewDirectCSIDrive(
-		uuid.New().String(),
-		client.NewDirectCSIDriveStatus(device, handler.nodeID, handler.topology),
-	)
+	drive := handler.newDrive(device)
 	if err := client.CreateDrive(ctx, drive); err != nil {
 		klog.ErrorS(err, "unable to create drive", "Status.Path", drive.Status.Path)
 	}
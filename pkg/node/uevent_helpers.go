@@ -319,5 +319,25 @@ func updateDriveProperties(drive *directcsi.DirectCSIDrive, device *sys.Device)
 		updated = true
 	}
 
+	if drive.Status.Rotational != device.Rotational {
+		drive.Status.Rotational = device.Rotational
+		updated = true
+	}
+
+	if drive.Status.Transport != device.Transport {
+		drive.Status.Transport = device.Transport
+		updated = true
+	}
+
+	if drive.Status.Scheduler != device.Scheduler {
+		drive.Status.Scheduler = device.Scheduler
+		updated = true
+	}
+
+	if drive.Status.NrRequests != int64(device.NrRequests) {
+		drive.Status.NrRequests = int64(device.NrRequests)
+		updated = true
+	}
+
 	return updated, nameChanged
 }
@@ -0,0 +1,143 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"fmt"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// highWatermarkPercent is the percentage of physical capacity of an overcommitted
+// drive, used by its volumes, above which the drive is marked to reach high watermark.
+const highWatermarkPercent = 90
+
+// setHighWatermarkCondition sets high watermark condition of the drive and returns whether
+// the condition status is changed.
+func setHighWatermarkCondition(drive *directcsi.DirectCSIDrive, reached bool, message string) bool {
+	condition := metav1.Condition{
+		Type:    string(directcsi.DirectCSIDriveConditionHighWatermark),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(directcsi.DirectCSIDriveReasonBelowHighWatermark),
+		Message: message,
+	}
+	if reached {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = string(directcsi.DirectCSIDriveReasonHighWatermarkReached)
+	}
+
+	existing := meta.FindStatusCondition(drive.Status.Conditions, condition.Type)
+	if existing != nil && existing.Status == condition.Status {
+		return false
+	}
+
+	meta.SetStatusCondition(&drive.Status.Conditions, condition)
+	return true
+}
+
+// updateHighWatermark updates high watermark condition of the drive and emits high
+// watermark event if the drive reached high watermark.
+func (ns *NodeServer) updateHighWatermark(ctx context.Context, drive *directcsi.DirectCSIDrive, reached bool, message string) error {
+	// Skip updating if the condition is not changed as per the drive in hand.
+	if !setHighWatermarkCondition(drive.DeepCopy(), reached, message) {
+		return nil
+	}
+
+	driveInterface := ns.directcsiClient.DirectV1beta3().DirectCSIDrives()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		drive, err := driveInterface.Get(ctx, drive.Name, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			return err
+		}
+		if !setHighWatermarkCondition(drive, reached, message) {
+			return nil
+		}
+		if drive, err = driveInterface.Update(ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()}); err != nil {
+			return err
+		}
+
+		if reached {
+			klog.V(3).InfoS("overcommitted drive reached high watermark", "drive", drive.Name, "message", message)
+			client.Eventf(drive, corev1.EventTypeWarning, "DriveHighWatermark", "overcommitted drive %v reached high watermark; %v", drive.Name, message)
+		}
+		return nil
+	})
+}
+
+// trackUsage records used capacity of the volume and sets high watermark condition of
+// the overcommitted drive if real usage of the drive approaches its physical capacity.
+func (ns *NodeServer) trackUsage(ctx context.Context, vol *directcsi.DirectCSIVolume, drive *directcsi.DirectCSIDrive, used int64) error {
+	volumeInterface := ns.directcsiClient.DirectV1beta3().DirectCSIVolumes()
+
+	usageChanged := vol.Status.UsedCapacity != used
+	if usageChanged {
+		vol.Status.UsedCapacity = used
+		vol.Status.AvailableCapacity = vol.Status.TotalCapacity - used
+		if _, err := volumeInterface.Update(ctx, vol, metav1.UpdateOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()}); err != nil {
+			return err
+		}
+	}
+
+	condition := meta.FindStatusCondition(drive.Status.Conditions, string(directcsi.DirectCSIDriveConditionHighWatermark))
+
+	// Volumes of the drive not overcommitted always fit in its physical capacity by their quotas.
+	if drive.Status.AllocatedCapacity <= drive.Status.TotalCapacity {
+		if condition == nil {
+			return nil
+		}
+		return ns.updateHighWatermark(ctx, drive, false, "drive is not overcommitted")
+	}
+
+	// Used capacity of the drive changes only if usage of any of its volumes changes,
+	// which is seen by the stats of that volume.
+	if !usageChanged && condition != nil {
+		return nil
+	}
+
+	volumes, err := volumeInterface.List(ctx, metav1.ListOptions{
+		TypeMeta:      utils.DirectCSIVolumeTypeMeta(),
+		LabelSelector: fmt.Sprintf("%s=%s", utils.DriveLabelKey, utils.NewLabelValue(drive.Name)),
+	})
+	if err != nil {
+		return err
+	}
+
+	var driveUsed int64
+	for _, volume := range volumes.Items {
+		if volume.Name == vol.Name {
+			driveUsed += used
+		} else {
+			driveUsed += volume.Status.UsedCapacity
+		}
+	}
+
+	return ns.updateHighWatermark(
+		ctx,
+		drive,
+		driveUsed*100 >= drive.Status.TotalCapacity*highWatermarkPercent,
+		fmt.Sprintf("volumes use %v bytes of physical capacity %v bytes", driveUsed, drive.Status.TotalCapacity),
+	)
+}
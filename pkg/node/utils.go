@@ -17,9 +17,12 @@
 package node
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
+	"syscall"
 
+	"github.com/container-storage-interface/spec/lib/go/csi"
 	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
 	"github.com/minio/directpv/pkg/matcher"
 	"github.com/minio/directpv/pkg/sys"
@@ -56,6 +59,18 @@ func checkDrive(drive *directcsi.DirectCSIDrive, volumeID string, probeMounts fu
 	return fmt.Errorf("drive %v is not mounted at mount point %v", drive.Name, mountPoint)
 }
 
+func checkBlockDrive(drive *directcsi.DirectCSIDrive, volumeID string) error {
+	if drive.Status.DriveStatus != directcsi.DriveStatusInUse {
+		return fmt.Errorf("drive %v is not in InUse state", drive.Name)
+	}
+
+	if drive.Status.BlockVolume != volumeID {
+		return fmt.Errorf("drive %v is not dedicated to block volume %v", drive.Name, volumeID)
+	}
+
+	return nil
+}
+
 func checkStagingTargetPath(stagingPath string, probeMounts func() (map[string][]sys.MountInfo, error)) error {
 	mounts, err := probeMounts()
 	if err != nil {
@@ -72,3 +87,38 @@ func checkStagingTargetPath(stagingPath string, probeMounts func() (map[string][
 
 	return fmt.Errorf("stagingPath %v is not mounted", stagingPath)
 }
+
+// checkVolume checks whether drive and staging mounts of the volume are present
+// and the staging path is accessible and writable. Staging path is probed instead
+// of the publish target path as the latter is read-only for read-only publishes.
+func (ns *NodeServer) checkVolume(vol *directcsi.DirectCSIVolume, drive *directcsi.DirectCSIDrive) error {
+	if err := checkDrive(drive, vol.Name, ns.probeMounts); err != nil {
+		return err
+	}
+
+	if vol.Status.StagingPath == "" {
+		return fmt.Errorf("volume %v is not staged", vol.Name)
+	}
+	if err := checkStagingTargetPath(vol.Status.StagingPath, ns.probeMounts); err != nil {
+		return err
+	}
+
+	readOnly, err := ns.isReadOnlyFS(vol.Status.StagingPath)
+	switch {
+	case errors.Is(err, syscall.EIO):
+		return fmt.Errorf("filesystem on drive %v is shut down; %w", drive.Name, err)
+	case err != nil:
+		return fmt.Errorf("unable to access staging path %v; %w", vol.Status.StagingPath, err)
+	case readOnly:
+		return fmt.Errorf("staging path %v is read-only", vol.Status.StagingPath)
+	}
+
+	return nil
+}
+
+func newVolumeCondition(err error) *csi.VolumeCondition {
+	if err != nil {
+		return &csi.VolumeCondition{Abnormal: true, Message: err.Error()}
+	}
+	return &csi.VolumeCondition{Abnormal: false, Message: ""}
+}
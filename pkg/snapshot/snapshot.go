@@ -0,0 +1,279 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/fs/xfs"
+	"github.com/minio/directpv/pkg/listener"
+	"github.com/minio/directpv/pkg/sys"
+	"github.com/minio/directpv/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/klog/v2"
+)
+
+// snapshotDir is the directory under drive mountpoint holding snapshots.
+const snapshotDir = ".snapshots"
+
+func excludeFinalizer(finalizers []string, finalizer string) (result []string, found bool) {
+	for _, f := range finalizers {
+		if f != finalizer {
+			result = append(result, f)
+		} else {
+			found = true
+		}
+	}
+	return
+}
+
+func getDevice(major, minor uint32) (string, error) {
+	name, err := sys.GetDeviceName(major, minor)
+	if err != nil {
+		return "", err
+	}
+	return "/dev/" + name, nil
+}
+
+// GetHostPath returns the path of the snapshot directory on given drive mountpoint.
+func GetHostPath(mountpoint, snapshotName string) string {
+	return filepath.Join(mountpoint, snapshotDir, snapshotName)
+}
+
+type snapshotEventHandler struct {
+	nodeID    string
+	getDevice func(major, minor uint32) (string, error)
+	setQuota  func(ctx context.Context, device, path, volumeID string, quota xfs.Quota) error
+	copyDir   func(src, dst string, fallback bool) error
+}
+
+func newSnapshotEventHandler(nodeID string) *snapshotEventHandler {
+	return &snapshotEventHandler{
+		nodeID:    nodeID,
+		getDevice: getDevice,
+		setQuota:  xfs.SetQuota,
+		copyDir:   sys.CopyDir,
+	}
+}
+
+func (handler *snapshotEventHandler) ListerWatcher() cache.ListerWatcher {
+	labelSelector := ""
+	if handler.nodeID != "" {
+		labelSelector = fmt.Sprintf("%s=%s", utils.NodeLabelKey, utils.NewLabelValue(handler.nodeID))
+	}
+
+	optionsModifier := func(options *metav1.ListOptions) {
+		options.LabelSelector = labelSelector
+	}
+
+	return cache.NewFilteredListWatchFromClient(
+		client.GetLatestDirectCSIRESTClient(),
+		"DirectCSISnapshots",
+		"",
+		optionsModifier,
+	)
+}
+
+func (handler *snapshotEventHandler) KubeClient() kubernetes.Interface {
+	return client.GetKubeClient()
+}
+
+func (handler *snapshotEventHandler) Name() string {
+	return "snapshot"
+}
+
+func (handler *snapshotEventHandler) ObjectType() runtime.Object {
+	return &directcsi.DirectCSISnapshot{}
+}
+
+func (handler *snapshotEventHandler) create(ctx context.Context, snapshot *directcsi.DirectCSISnapshot) error {
+	if snapshot.Status.ReadyToUse {
+		return nil
+	}
+
+	volume, err := client.GetLatestDirectCSIVolumeInterface().Get(
+		ctx, snapshot.Status.SourceVolume, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()},
+	)
+	if err != nil {
+		return err
+	}
+
+	drive, err := client.GetLatestDirectCSIDriveInterface().Get(
+		ctx, snapshot.Status.Drive, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+	)
+	if err != nil {
+		return err
+	}
+
+	hostPath := GetHostPath(drive.Status.Mountpoint, snapshot.Name)
+	if _, err := os.Stat(hostPath); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		device, err := handler.getDevice(drive.Status.MajorNumber, drive.Status.MinorNumber)
+		if err != nil {
+			return err
+		}
+
+		// Take the copy in a temporary directory and rename it on completion
+		// so that a partial copy is never treated as a snapshot.
+		tmpPath := hostPath + ".tmp"
+		if err := os.RemoveAll(tmpPath); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(tmpPath, 0755); err != nil {
+			return err
+		}
+
+		// Set the quota before copying so that the copied files are
+		// accounted in the project of this snapshot.
+		quota := xfs.Quota{
+			HardLimit: uint64(snapshot.Status.Size),
+			SoftLimit: uint64(snapshot.Status.Size),
+		}
+		if err := handler.setQuota(ctx, device, tmpPath, snapshot.Name, quota); err != nil {
+			return err
+		}
+
+		sourcePath := volume.Status.HostPath
+		if sourcePath == "" {
+			sourcePath = filepath.Join(drive.Status.Mountpoint, volume.Name)
+		}
+
+		// Files are reflink-copied where the filesystem supports it, else plain copied.
+		// The volume is not frozen while copying, hence the snapshot is crash-consistent
+		// only; applications needing consistent data must quiesce before snapshotting.
+		klog.V(3).InfoS("Creating snapshot", "snapshot", snapshot.Name, "volume", volume.Name, "source", sourcePath)
+		if err := handler.copyDir(sourcePath, tmpPath, true); err != nil {
+			// The volume directory does not exist until it is staged.
+			if !errors.Is(err, os.ErrNotExist) {
+				client.Eventf(snapshot, corev1.EventTypeWarning, "SnapshotCreationFailed", "unable to snapshot volume %v; %v", volume.Name, err)
+				return err
+			}
+		}
+
+		if err := os.Rename(tmpPath, hostPath); err != nil {
+			return err
+		}
+	}
+
+	snapshot.Status.HostPath = hostPath
+	snapshot.Status.ReadyToUse = true
+	if _, err = client.GetLatestDirectCSISnapshotInterface().Update(
+		ctx, snapshot, metav1.UpdateOptions{TypeMeta: utils.DirectCSISnapshotTypeMeta()},
+	); err != nil {
+		return err
+	}
+
+	client.Eventf(snapshot, corev1.EventTypeNormal, "SnapshotCreated", "snapshot %v of volume %v is ready", snapshot.Name, volume.Name)
+	return nil
+}
+
+func (handler *snapshotEventHandler) releaseSnapshot(ctx context.Context, drive *directcsi.DirectCSIDrive, snapshot *directcsi.DirectCSISnapshot) error {
+	finalizers, found := excludeFinalizer(
+		drive.GetFinalizers(), directcsi.DirectCSIDriveFinalizerSnapshotPrefix+snapshot.Name,
+	)
+	if !found {
+		return nil
+	}
+
+	if len(finalizers) == 1 {
+		if finalizers[0] == directcsi.DirectCSIDriveFinalizerDataProtection {
+			drive.Status.DriveStatus = directcsi.DriveStatusReady
+		}
+	}
+
+	drive.SetFinalizers(finalizers)
+	drive.Status.FreeCapacity += snapshot.Status.Size
+	drive.Status.AllocatedCapacity = drive.Status.TotalCapacity - drive.Status.FreeCapacity
+
+	_, err := client.GetLatestDirectCSIDriveInterface().Update(
+		ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+	)
+	return err
+}
+
+func (handler *snapshotEventHandler) delete(ctx context.Context, snapshot *directcsi.DirectCSISnapshot) error {
+	finalizers, found := excludeFinalizer(
+		snapshot.GetFinalizers(), directcsi.DirectCSISnapshotFinalizerPurgeProtection,
+	)
+	if !found {
+		return nil
+	}
+
+	drive, err := client.GetLatestDirectCSIDriveInterface().Get(
+		ctx, snapshot.Status.Drive, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Remove associated directory of the snapshot including partial copy if any.
+	hostPath := GetHostPath(drive.Status.Mountpoint, snapshot.Name)
+	if err := os.RemoveAll(hostPath + ".tmp"); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(hostPath); err != nil {
+		return err
+	}
+
+	// Release snapshot from associated drive.
+	if err := handler.releaseSnapshot(ctx, drive, snapshot); err != nil {
+		return err
+	}
+
+	snapshot.SetFinalizers(finalizers)
+	_, err = client.GetLatestDirectCSISnapshotInterface().Update(
+		ctx, snapshot, metav1.UpdateOptions{TypeMeta: utils.DirectCSISnapshotTypeMeta()},
+	)
+	return err
+}
+
+func (handler *snapshotEventHandler) Handle(ctx context.Context, args listener.EventArgs) error {
+	switch args.Event {
+	case listener.AddEvent, listener.UpdateEvent:
+		return handler.create(ctx, args.Object.(*directcsi.DirectCSISnapshot))
+	case listener.DeleteEvent:
+		return handler.delete(ctx, args.Object.(*directcsi.DirectCSISnapshot))
+	}
+
+	return nil
+}
+
+// StartController starts snapshot controller.
+func StartController(ctx context.Context, nodeID string) error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	listener := listener.NewListener(newSnapshotEventHandler(nodeID), "snapshot-controller", hostname, 40)
+	return listener.Run(ctx)
+}
@@ -0,0 +1,180 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/fs/xfs"
+	"github.com/minio/directpv/pkg/listener"
+	"github.com/minio/directpv/pkg/sys"
+	"github.com/minio/directpv/pkg/utils"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	clientsetfake "github.com/minio/directpv/pkg/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	MB = 1 << 20
+
+	mb20  = 20 * MB
+	mb100 = 100 * MB
+
+	testNodeName     = "test-node"
+	testDriveName    = "test-drive"
+	testVolumeName   = "test-volume"
+	testSnapshotName = "test-snapshot"
+)
+
+func init() {
+	client.FakeInit()
+}
+
+func createFakeSnapshotEventListener(objects ...runtime.Object) *snapshotEventHandler {
+	fakeDirectCSIClient := clientsetfake.NewSimpleClientset(objects...).DirectV1beta3()
+	client.SetLatestDirectCSIDriveInterface(fakeDirectCSIClient.DirectCSIDrives())
+	client.SetLatestDirectCSIVolumeInterface(fakeDirectCSIClient.DirectCSIVolumes())
+	client.SetLatestDirectCSISnapshotInterface(fakeDirectCSIClient.DirectCSISnapshots())
+	return &snapshotEventHandler{
+		nodeID: testNodeName,
+		getDevice: func(major, minor uint32) (string, error) {
+			return "", nil
+		},
+		setQuota: func(ctx context.Context, device, path, volumeID string, quota xfs.Quota) error {
+			return nil
+		},
+		copyDir: func(src, dst string, fallback bool) error {
+			// Snapshot must be taken on filesystems without reflink support too.
+			if !fallback {
+				return sys.ErrReflinkUnsupported
+			}
+			return os.WriteFile(filepath.Join(dst, "data"), []byte(src), 0644)
+		},
+	}
+}
+
+func TestSnapshotEventHandlerHandle(t *testing.T) {
+	mountpoint := t.TempDir()
+	testObjects := []runtime.Object{
+		&directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testDriveName,
+				Finalizers: []string{
+					directcsi.DirectCSIDriveFinalizerDataProtection,
+					directcsi.DirectCSIDriveFinalizerSnapshotPrefix + testSnapshotName,
+				},
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:          testNodeName,
+				DriveStatus:       directcsi.DriveStatusInUse,
+				Mountpoint:        mountpoint,
+				FreeCapacity:      mb100 - mb20,
+				AllocatedCapacity: mb20,
+				TotalCapacity:     mb100,
+			},
+		},
+		&directcsi.DirectCSIVolume{
+			TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: testVolumeName},
+			Status: directcsi.DirectCSIVolumeStatus{
+				NodeName:      testNodeName,
+				HostPath:      filepath.Join(mountpoint, testVolumeName),
+				Drive:         testDriveName,
+				TotalCapacity: mb20,
+			},
+		},
+		&directcsi.DirectCSISnapshot{
+			TypeMeta: utils.DirectCSISnapshotTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       testSnapshotName,
+				Finalizers: []string{directcsi.DirectCSISnapshotFinalizerPurgeProtection},
+			},
+			Status: directcsi.DirectCSISnapshotStatus{
+				SourceVolume: testVolumeName,
+				Drive:        testDriveName,
+				NodeName:     testNodeName,
+				Size:         mb20,
+			},
+		},
+	}
+
+	sl := createFakeSnapshotEventListener(testObjects...)
+	ctx := context.TODO()
+
+	snapshot, err := client.GetLatestDirectCSISnapshotInterface().Get(ctx, testSnapshotName, metav1.GetOptions{TypeMeta: utils.DirectCSISnapshotTypeMeta()})
+	if err != nil {
+		t.Fatalf("Error while getting the snapshot object: %+v", err)
+	}
+	if err := sl.Handle(ctx, listener.EventArgs{Event: listener.AddEvent, Object: snapshot}); err != nil {
+		t.Fatalf("Error while invoking the snapshot add listener: %+v", err)
+	}
+
+	snapshot, err = client.GetLatestDirectCSISnapshotInterface().Get(ctx, testSnapshotName, metav1.GetOptions{TypeMeta: utils.DirectCSISnapshotTypeMeta()})
+	if err != nil {
+		t.Fatalf("Error while getting the snapshot object: %+v", err)
+	}
+	hostPath := GetHostPath(mountpoint, testSnapshotName)
+	if !snapshot.Status.ReadyToUse {
+		t.Fatalf("Snapshot is not ready to use")
+	}
+	if snapshot.Status.HostPath != hostPath {
+		t.Fatalf("Unexpected host path set. Expected: %s, Got: %s", hostPath, snapshot.Status.HostPath)
+	}
+	if _, err := os.Stat(filepath.Join(hostPath, "data")); err != nil {
+		t.Fatalf("Snapshot data not found: %+v", err)
+	}
+	if _, err := os.Stat(hostPath + ".tmp"); !os.IsNotExist(err) {
+		t.Fatalf("Temporary snapshot directory not removed: %+v", err)
+	}
+
+	now := metav1.Now()
+	snapshot.DeletionTimestamp = &now
+	if err := sl.Handle(ctx, listener.EventArgs{Event: listener.DeleteEvent, Object: snapshot}); err != nil {
+		t.Fatalf("Error while invoking the snapshot delete listener: %+v", err)
+	}
+	if len(snapshot.GetFinalizers()) != 0 {
+		t.Errorf("Snapshot finalizers are not empty: %v", snapshot.GetFinalizers())
+	}
+	if _, err := os.Stat(hostPath); !os.IsNotExist(err) {
+		t.Fatalf("Snapshot directory not removed: %+v", err)
+	}
+
+	driveObj, err := client.GetLatestDirectCSIDriveInterface().Get(ctx, testDriveName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		t.Fatalf("Error while getting the drive object: %+v", err)
+	}
+	driveFinalizers := driveObj.GetFinalizers()
+	if len(driveFinalizers) != 1 || driveFinalizers[0] != directcsi.DirectCSIDriveFinalizerDataProtection {
+		t.Fatalf("Unexpected drive finalizers set after clean-up: %+v", driveFinalizers)
+	}
+	if driveObj.Status.DriveStatus != directcsi.DriveStatusReady {
+		t.Errorf("Unexpected drive status set. Expected: %s, Got: %s", string(directcsi.DriveStatusReady), string(driveObj.Status.DriveStatus))
+	}
+	if driveObj.Status.FreeCapacity != mb100 {
+		t.Errorf("Unexpected free capacity set. Expected: %d, Got: %d", mb100, driveObj.Status.FreeCapacity)
+	}
+	if driveObj.Status.AllocatedCapacity != 0 {
+		t.Errorf("Unexpected allocated capacity set. Expected: 0, Got: %d", driveObj.Status.AllocatedCapacity)
+	}
+}
@@ -35,3 +35,20 @@ const (
 	// MinSupportedDeviceSize is minimum supported size for default XFS filesystem.
 	MinSupportedDeviceSize = 16 * 1024 * 1024 // 16 MiB
 )
+
+const (
+	// TransportNVMe denotes NVMe transport.
+	TransportNVMe = "nvme"
+
+	// TransportSATA denotes SATA transport.
+	TransportSATA = "sata"
+
+	// TransportSAS denotes SAS transport.
+	TransportSAS = "sas"
+
+	// TransportUSB denotes USB transport.
+	TransportUSB = "usb"
+
+	// TransportVirtio denotes virtio transport.
+	TransportVirtio = "virtio"
+)
@@ -0,0 +1,29 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import "errors"
+
+// ErrReflinkUnsupported denotes reflink is not supported by the underlying filesystem.
+var ErrReflinkUnsupported = errors.New("reflink not supported")
+
+// CopyDir copies the directory tree of src into dst preserving ownership and permissions.
+// Regular files are reflink-copied; if fallback is set, files are plain copied when
+// reflink is not supported by the filesystem, else ErrReflinkUnsupported is returned.
+func CopyDir(src, dst string, fallback bool) error {
+	return copyDir(src, dst, fallback)
+}
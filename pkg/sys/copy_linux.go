@@ -0,0 +1,102 @@
+//go:build linux
+
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+func reflinkUnsupported(err error) bool {
+	return errors.Is(err, unix.EOPNOTSUPP) ||
+		errors.Is(err, unix.EXDEV) ||
+		errors.Is(err, unix.EINVAL) ||
+		errors.Is(err, unix.ENOTTY)
+}
+
+func copyFile(src, dst string, mode os.FileMode, fallback bool) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	dstFile, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode.Perm())
+	if err != nil {
+		return err
+	}
+	defer dstFile.Close()
+
+	if err = unix.IoctlFileClone(int(dstFile.Fd()), int(srcFile.Fd())); err != nil {
+		if !reflinkUnsupported(err) {
+			return &os.PathError{Op: "reflink", Path: src, Err: err}
+		}
+		if !fallback {
+			return ErrReflinkUnsupported
+		}
+		if _, err = io.Copy(dstFile, srcFile); err != nil {
+			return err
+		}
+	}
+
+	return dstFile.Close()
+}
+
+func copyDir(src, dst string, fallback bool) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, relPath)
+
+		switch mode := info.Mode(); {
+		case mode.IsDir():
+			if err = os.MkdirAll(target, mode.Perm()); err != nil {
+				return err
+			}
+			err = os.Chmod(target, mode.Perm())
+		case mode.IsRegular():
+			err = copyFile(path, target, mode, fallback)
+		case mode&os.ModeSymlink != 0:
+			var link string
+			if link, err = os.Readlink(path); err == nil {
+				err = os.Symlink(link, target)
+			}
+		default:
+			stat := info.Sys().(*syscall.Stat_t)
+			err = unix.Mknod(target, stat.Mode, int(stat.Rdev))
+		}
+		if err != nil {
+			return err
+		}
+
+		stat := info.Sys().(*syscall.Stat_t)
+		return os.Lchown(target, int(stat.Uid), int(stat.Gid))
+	})
+}
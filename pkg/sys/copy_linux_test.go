@@ -0,0 +1,63 @@
+//go:build linux
+
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCopyDir(t *testing.T) {
+	src := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(src, "dir1", "dir2"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "dir1", "dir2", "file1"), []byte("hello"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("dir2/file1", filepath.Join(src, "dir1", "link1")); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(t.TempDir(), "copy")
+	if err := CopyDir(src, dst, true); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dst, "dir1", "link1"))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if string(data) != "hello" {
+		t.Fatalf("expected: hello, got: %v", string(data))
+	}
+
+	info, err := os.Stat(filepath.Join(dst, "dir1", "dir2"))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if info.Mode().Perm() != 0750 {
+		t.Fatalf("expected mode: 0750, got: %v", info.Mode().Perm())
+	}
+
+	if err := CopyDir(filepath.Join(src, "nonexistent"), dst, true); !os.IsNotExist(err) {
+		t.Fatalf("expected not exist error, got: %v", err)
+	}
+}
@@ -247,6 +247,81 @@ func getVirtual(name string) (bool, error) {
 	return strings.HasPrefix(absPath, "/sys/devices/virtual/block/"), nil
 }
 
+// parseTransport returns the transport of the device by its resolved /sys/class/block path.
+func parseTransport(sysPath string) string {
+	switch {
+	case strings.Contains(sysPath, "/usb"):
+		return TransportUSB
+	case strings.Contains(sysPath, "/nvme"):
+		return TransportNVMe
+	case strings.Contains(sysPath, "/virtio"):
+		return TransportVirtio
+	case strings.Contains(sysPath, "/end_device-"):
+		return TransportSAS
+	case strings.Contains(sysPath, "/ata"):
+		return TransportSATA
+	}
+	return ""
+}
+
+func getTransport(name string) (string, error) {
+	absPath, err := filepath.EvalSymlinks("/sys/class/block/" + name)
+	if err != nil {
+		return "", err
+	}
+	return parseTransport(absPath), nil
+}
+
+// getQueueDir returns the queue directory of the device; partitions use the queue of their parent.
+func getQueueDir(name string, partition int) string {
+	if partition > 0 {
+		return "/sys/class/block/" + name + "/../queue"
+	}
+	return "/sys/class/block/" + name + "/queue"
+}
+
+func getRotational(name string, partition int) (bool, error) {
+	s, err := readFirstLine(getQueueDir(name, partition)+"/rotational", false)
+	return s != "" && s != "0", err
+}
+
+// parseScheduler returns the active scheduler enclosed by square brackets.
+func parseScheduler(s string) string {
+	for _, token := range strings.Fields(s) {
+		if strings.HasPrefix(token, "[") && strings.HasSuffix(token, "]") {
+			return strings.TrimSuffix(strings.TrimPrefix(token, "["), "]")
+		}
+	}
+	return s
+}
+
+func getScheduler(name string, partition int) (string, error) {
+	s, err := readFirstLine(getQueueDir(name, partition)+"/scheduler", false)
+	return parseScheduler(s), err
+}
+
+func getNrRequests(name string, partition int) (int, error) {
+	s, err := readFirstLine(getQueueDir(name, partition)+"/nr_requests", false)
+	if err != nil || s == "" {
+		return 0, err
+	}
+	return strconv.Atoi(s)
+}
+
+func updateMediaInfo(device *Device) (err error) {
+	if device.Transport, err = getTransport(device.Name); err != nil {
+		return err
+	}
+	if device.Rotational, err = getRotational(device.Name, device.Partition); err != nil {
+		return err
+	}
+	if device.Scheduler, err = getScheduler(device.Name, device.Partition); err != nil {
+		return err
+	}
+	device.NrRequests, err = getNrRequests(device.Name, device.Partition)
+	return err
+}
+
 func getPartitions(name string) ([]string, error) {
 	names, err := readdirnames("/sys/block/"+name, false)
 	if err != nil {
@@ -316,6 +391,9 @@ func probeDevice(name string) (device *Device, err error) {
 	if device.Virtual, err = getVirtual(name); err != nil {
 		return nil, err
 	}
+	if err = updateMediaInfo(device); err != nil {
+		return nil, err
+	}
 	return device, nil
 }
 
@@ -508,6 +586,9 @@ func probeDevicesFromUdev() (devices map[string]*Device, err error) {
 		if device.ReadOnly, err = getReadOnly(name); err != nil {
 			return nil, err
 		}
+		if err = updateMediaInfo(device); err != nil {
+			return nil, err
+		}
 
 		devices[name] = device
 	}
@@ -788,6 +869,10 @@ func createDevice(event map[string]string) (device *Device, err error) {
 		return nil, err
 	}
 
+	if err = updateMediaInfo(device); err != nil {
+		return nil, err
+	}
+
 	if device.Partition <= 0 {
 		names, err := getPartitions(name)
 		if err != nil {
@@ -703,3 +703,42 @@ Can write RAM:		1	1
 		}
 	}
 }
+
+func TestParseTransport(t *testing.T) {
+	testCases := []struct {
+		sysPath        string
+		expectedResult string
+	}{
+		{"/sys/devices/pci0000:00/0000:00:1d.0/0000:3d:00.0/nvme/nvme0/nvme0n1", TransportNVMe},
+		{"/sys/devices/virtual/nvme-fabrics/ctl/nvme1/nvme1n1", TransportNVMe},
+		{"/sys/devices/pci0000:00/0000:00:17.0/ata1/host0/target0:0:0/0:0:0:0/block/sda", TransportSATA},
+		{"/sys/devices/pci0000:00/0000:00:01.0/0000:01:00.0/host0/port-0:0/end_device-0:0/target0:0:0/0:0:0:0/block/sdb", TransportSAS},
+		{"/sys/devices/pci0000:00/0000:00:14.0/usb2/2-4/2-4:1.0/host6/target6:0:0/6:0:0:0/block/sdc", TransportUSB},
+		{"/sys/devices/pci0000:00/0000:00:04.0/virtio1/block/vda", TransportVirtio},
+		{"/sys/devices/virtual/block/loop0", ""},
+	}
+
+	for i, testCase := range testCases {
+		if result := parseTransport(testCase.sysPath); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestParseScheduler(t *testing.T) {
+	testCases := []struct {
+		value          string
+		expectedResult string
+	}{
+		{"", ""},
+		{"none", "none"},
+		{"[none] mq-deadline", "none"},
+		{"mq-deadline kyber [bfq] none", "bfq"},
+	}
+
+	for i, testCase := range testCases {
+		if result := parseScheduler(testCase.value); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
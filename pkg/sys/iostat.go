@@ -0,0 +1,74 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IOStats denotes kernel I/O counters of a block device read from /sys/class/block/<name>/stat.
+type IOStats struct {
+	ReadIOs      uint64 // Number of read I/Os processed
+	ReadMerges   uint64 // Number of read I/Os merged with in-queue I/O
+	ReadSectors  uint64 // Number of sectors read
+	ReadTicks    uint64 // Total wait time for read requests in milliseconds
+	WriteIOs     uint64 // Number of write I/Os processed
+	WriteMerges  uint64 // Number of write I/Os merged with in-queue I/O
+	WriteSectors uint64 // Number of sectors written
+	WriteTicks   uint64 // Total wait time for write requests in milliseconds
+	InFlight     uint64 // Number of I/Os currently in flight
+	IOTicks      uint64 // Total time this block device has been active in milliseconds
+	TimeInQueue  uint64 // Total wait time for all requests in milliseconds
+}
+
+// parseIOStats parses content of block device stat file.
+func parseIOStats(s string) (*IOStats, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 11 {
+		return nil, fmt.Errorf("invalid stat %q; expected at least 11 fields, got %v", s, len(fields))
+	}
+
+	values := make([]uint64, 11)
+	for i := range values {
+		value, err := strconv.ParseUint(fields[i], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stat field %v; %w", i+1, err)
+		}
+		values[i] = value
+	}
+
+	return &IOStats{
+		ReadIOs:      values[0],
+		ReadMerges:   values[1],
+		ReadSectors:  values[2],
+		ReadTicks:    values[3],
+		WriteIOs:     values[4],
+		WriteMerges:  values[5],
+		WriteSectors: values[6],
+		WriteTicks:   values[7],
+		InFlight:     values[8],
+		IOTicks:      values[9],
+		TimeInQueue:  values[10],
+	}, nil
+}
+
+// GetIOStats returns kernel I/O counters of given device name.
+func GetIOStats(name string) (*IOStats, error) {
+	return getIOStats(name)
+}
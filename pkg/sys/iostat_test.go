@@ -0,0 +1,60 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseIOStats(t *testing.T) {
+	testCases := []struct {
+		s               string
+		expectedIOStats *IOStats
+		expectErr       bool
+	}{
+		{
+			"    4721      286   366626     2176     1822     1327   128944     3384        0     4436     5560",
+			&IOStats{4721, 286, 366626, 2176, 1822, 1327, 128944, 3384, 0, 4436, 5560},
+			false,
+		},
+		{
+			"  197811    59393 16387366    96541   326717   270012 18779920   479826        2   393060   620497        0        0        0        0    10512    44129",
+			&IOStats{197811, 59393, 16387366, 96541, 326717, 270012, 18779920, 479826, 2, 393060, 620497},
+			false,
+		},
+		{"4721 286 366626 2176", nil, true},
+		{"4721 286 366626 2176 1822 1327 128944 3384 0 4436 -1", nil, true},
+		{"", nil, true},
+	}
+
+	for i, testCase := range testCases {
+		ioStats, err := parseIOStats(testCase.s)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(ioStats, testCase.expectedIOStats) {
+			t.Fatalf("case %v: IO stats: expected: %+v, got: %+v", i+1, testCase.expectedIOStats, ioStats)
+		}
+	}
+}
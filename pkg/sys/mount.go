@@ -47,17 +47,27 @@ func SafeUnmount(target string, force, detach, expire bool) error {
 	return safeUnmount(target, force, detach, expire)
 }
 
+// IsReadOnlyFS returns whether filesystem of path is read-only. Error is returned
+// if the filesystem is inaccessible, e.g. EIO after XFS shutdown.
+func IsReadOnlyFS(path string) (bool, error) {
+	return isReadOnlyFS(path)
+}
+
 // UnmountDevice unmounts all mounts of device.
 func UnmountDevice(device string) error {
 	return unmountDevice(device)
 }
 
-// MountXFSDevice mounts device having XFS filesystem into target.
-func MountXFSDevice(device, target string, flags []string) error {
+// MountDevice mounts device having XFS or ext4 filesystem into target with project quota enabled.
+func MountDevice(device, target, fsType string, flags []string) error {
 	if err := os.MkdirAll(target, 0777); err != nil {
 		return err
 	}
 
-	klog.V(3).InfoS("mounting device", "device", device, "target", target)
-	return SafeMount(device, target, "xfs", flags, "prjquota")
+	if fsType == "" {
+		fsType = "xfs"
+	}
+
+	klog.V(3).InfoS("mounting device", "device", device, "target", target, "fsType", fsType)
+	return SafeMount(device, target, fsType, flags, "prjquota")
 }
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"syscall"
 
+	"golang.org/x/sys/unix"
 	"k8s.io/klog/v2"
 )
 
@@ -118,11 +119,25 @@ func safeBindMount(source, target, fsType string, recursive, readOnly bool, supe
 	if recursive {
 		flags |= mountFlagMap["recursive"]
 	}
-	if readOnly {
-		flags |= mountFlagMap["ro"]
-	}
 	klog.V(5).InfoS("bind mounting directory", "source", source, "target", target, "fsType", fsType, "recursive", recursive, "readOnly", readOnly, "superBlockFlags", superBlockFlags)
-	return syscall.Mount(source, target, fsType, flags, superBlockFlags)
+	if err := syscall.Mount(source, target, fsType, flags, superBlockFlags); err != nil {
+		return err
+	}
+
+	if !readOnly {
+		return nil
+	}
+
+	// Read-only flag is ignored on creating bind mount; it is applied by remount.
+	flags = mountFlagMap["remount"] | mountFlagMap["bind"] | mountFlagMap["ro"]
+	if err := syscall.Mount("", target, "", flags, ""); err != nil {
+		if uerr := unmount(target, true, true, false); uerr != nil {
+			klog.ErrorS(uerr, "unable to unmount", "target", target)
+		}
+		return err
+	}
+
+	return nil
 }
 
 func safeUnmount(target string, force, detach, expire bool) error {
@@ -158,3 +173,11 @@ func unmountDevice(device string) error {
 
 	return nil
 }
+
+func isReadOnlyFS(path string) (bool, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return false, err
+	}
+	return stat.Flags&unix.ST_RDONLY != 0, nil
+}
@@ -0,0 +1,61 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestSafeBindMountReadOnly(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("mounting requires root privileges")
+	}
+
+	source, target := t.TempDir(), t.TempDir()
+	if err := safeBindMount(source, target, "", false, true, ""); err != nil {
+		if errors.Is(err, syscall.EPERM) {
+			t.Skipf("mounting is not permitted; %v", err)
+		}
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer func() {
+		if err := unmount(target, true, true, false); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	err := os.WriteFile(filepath.Join(target, "file"), []byte("data"), 0640)
+	if !errors.Is(err, syscall.EROFS) {
+		t.Fatalf("expected: %v, got: %v", syscall.EROFS, err)
+	}
+
+	readOnly, err := isReadOnlyFS(target)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !readOnly {
+		t.Fatalf("expected read-only mount")
+	}
+
+	if err := os.WriteFile(filepath.Join(source, "file"), []byte("data"), 0640); err != nil {
+		t.Fatalf("source must be writable; %v", err)
+	}
+}
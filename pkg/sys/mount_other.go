@@ -46,3 +46,7 @@ func safeUnmount(target string, force, detach, expire bool) error {
 func unmountDevice(device string) error {
 	return fmt.Errorf("unsupported operating system %v", runtime.GOOS)
 }
+
+func isReadOnlyFS(path string) (bool, error) {
+	return false, fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
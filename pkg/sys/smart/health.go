@@ -0,0 +1,144 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package smart
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	nvmeSMARTLogLen = 512
+	ataSMARTDataLen = 512
+
+	ataAttributeCount = 30
+	ataAttributeLen   = 12
+
+	ataAttrReallocatedSectors    = 5
+	ataAttrWearLevelingCount     = 177
+	ataAttrReportedUncorrect     = 187
+	ataAttrTemperatureCelsius    = 194
+	ataAttrOfflineUncorrectable  = 198
+	ataAttrMediaWearoutIndicator = 233
+
+	kelvinOffset = 273
+)
+
+var errInvalidChecksum = errors.New("invalid S.M.A.R.T. data checksum")
+
+// Health denotes S.M.A.R.T. health information of a device.
+type Health struct {
+	CriticalWarning    uint8  // NVMe critical warning bits; always zero for ATA devices
+	Temperature        int64  // Temperature in Celsius
+	PercentageUsed     int64  // Estimate of device life used in percentage
+	MediaErrors        uint64 // Unrecovered media and data integrity errors
+	ReallocatedSectors uint64 // Reallocated sectors; always zero for NVMe devices
+}
+
+type nvmeSMARTLog struct {
+	CritWarning      uint8     // Critical Warning
+	Temperature      uint16    // Composite Temperature in Kelvin
+	AvailSpare       uint8     // Available Spare
+	SpareThresh      uint8     // Available Spare Threshold
+	PercentUsed      uint8     // Percentage Used
+	Rsvd6            [26]byte  // ...
+	DataUnitsRead    [16]byte  // Data Units Read
+	DataUnitsWritten [16]byte  // Data Units Written
+	HostReads        [16]byte  // Host Read Commands
+	HostWrites       [16]byte  // Host Write Commands
+	CtrlBusyTime     [16]byte  // Controller Busy Time
+	PowerCycles      [16]byte  // Power Cycles
+	PowerOnHours     [16]byte  // Power On Hours
+	UnsafeShutdowns  [16]byte  // Unsafe Shutdowns
+	MediaErrors      [16]byte  // Media and Data Integrity Errors
+	NumErrLogEntries [16]byte  // Number of Error Information Log Entries
+	Rsvd192          [320]byte // ...
+} // 512 bytes
+
+// toUint64 converts 128-bit little-endian counter to uint64 by saturating overflowed values.
+func toUint64(counter [16]byte) uint64 {
+	if binary.LittleEndian.Uint64(counter[8:]) != 0 {
+		return math.MaxUint64
+	}
+	return binary.LittleEndian.Uint64(counter[:8])
+}
+
+// parseNVMeSMARTLog parses NVMe SMART/Health Information log page.
+func parseNVMeSMARTLog(buf []byte) (*Health, error) {
+	if len(buf) < nvmeSMARTLogLen {
+		return nil, fmt.Errorf("short NVMe SMART log; expected: %v, got: %v", nvmeSMARTLogLen, len(buf))
+	}
+
+	var log nvmeSMARTLog
+	if err := binary.Read(bytes.NewBuffer(buf[:nvmeSMARTLogLen]), binary.LittleEndian, &log); err != nil {
+		return nil, err
+	}
+
+	health := &Health{
+		CriticalWarning: log.CritWarning,
+		PercentageUsed:  int64(log.PercentUsed),
+		MediaErrors:     toUint64(log.MediaErrors),
+	}
+	if log.Temperature != 0 {
+		health.Temperature = int64(log.Temperature) - kelvinOffset
+	}
+	return health, nil
+}
+
+// parseATASMARTData parses ATA SMART READ DATA response.
+func parseATASMARTData(buf []byte) (*Health, error) {
+	if len(buf) < ataSMARTDataLen {
+		return nil, fmt.Errorf("short ATA SMART data; expected: %v, got: %v", ataSMARTDataLen, len(buf))
+	}
+
+	var checksum uint8
+	for _, b := range buf[:ataSMARTDataLen] {
+		checksum += b
+	}
+	if checksum != 0 {
+		return nil, errInvalidChecksum
+	}
+
+	health := &Health{}
+	for i := 0; i < ataAttributeCount; i++ {
+		// Attribute entry is ID (1 byte), flags (2 bytes), value (1 byte), worst (1 byte),
+		// raw value (6 bytes) and reserved (1 byte) after 2 bytes of revision number.
+		attr := buf[2+i*ataAttributeLen : 2+(i+1)*ataAttributeLen]
+		value := attr[3]
+		raw := uint64(attr[5]) | uint64(attr[6])<<8 | uint64(attr[7])<<16 |
+			uint64(attr[8])<<24 | uint64(attr[9])<<32 | uint64(attr[10])<<40
+
+		switch attr[0] {
+		case ataAttrReallocatedSectors:
+			health.ReallocatedSectors = raw
+		case ataAttrReportedUncorrect, ataAttrOfflineUncorrectable:
+			health.MediaErrors += raw
+		case ataAttrTemperatureCelsius:
+			// Only lowest byte of raw value is current temperature.
+			health.Temperature = int64(attr[5])
+		case ataAttrWearLevelingCount, ataAttrMediaWearoutIndicator:
+			// Normalized value counts down from 100 to 0 as the media wears out.
+			if value <= 100 {
+				health.PercentageUsed = int64(100 - value)
+			}
+		}
+	}
+	return health, nil
+}
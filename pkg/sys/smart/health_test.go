@@ -0,0 +1,132 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package smart
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func newNVMeSMARTLog(critWarning uint8, kelvin uint16, percentUsed uint8, mediaErrors []byte) []byte {
+	buf := make([]byte, nvmeSMARTLogLen)
+	buf[0] = critWarning
+	buf[1] = byte(kelvin)
+	buf[2] = byte(kelvin >> 8)
+	buf[5] = percentUsed
+	copy(buf[160:176], mediaErrors)
+	return buf
+}
+
+func newATASMARTData(attrs map[uint8][2]uint64) []byte {
+	buf := make([]byte, ataSMARTDataLen)
+	buf[0] = 0x10
+	i := 0
+	for id, attr := range attrs {
+		entry := buf[2+i*ataAttributeLen:]
+		entry[0] = id
+		entry[3] = byte(attr[0])
+		for j := 0; j < 6; j++ {
+			entry[5+j] = byte(attr[1] >> (8 * j))
+		}
+		i++
+	}
+	var sum uint8
+	for _, b := range buf[:ataSMARTDataLen-1] {
+		sum += b
+	}
+	buf[ataSMARTDataLen-1] = -sum
+	return buf
+}
+
+func TestParseNVMeSMARTLog(t *testing.T) {
+	testCases := []struct {
+		buf            []byte
+		expectedHealth *Health
+		expectErr      bool
+	}{
+		{make([]byte, nvmeSMARTLogLen), &Health{}, false},
+		{newNVMeSMARTLog(0, 310, 3, []byte{0x2a}), &Health{Temperature: 37, PercentageUsed: 3, MediaErrors: 42}, false},
+		{newNVMeSMARTLog(0x04, 350, 120, []byte{0x00, 0x01}), &Health{CriticalWarning: 0x04, Temperature: 77, PercentageUsed: 120, MediaErrors: 256}, false},
+		{newNVMeSMARTLog(0, 300, 0, []byte{0, 0, 0, 0, 0, 0, 0, 0, 1}), &Health{Temperature: 27, MediaErrors: math.MaxUint64}, false},
+		{make([]byte, 64), nil, true},
+	}
+
+	for i, testCase := range testCases {
+		health, err := parseNVMeSMARTLog(testCase.buf)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(health, testCase.expectedHealth) {
+			t.Fatalf("case %v: health: expected: %+v, got: %+v", i+1, testCase.expectedHealth, health)
+		}
+	}
+}
+
+func TestParseATASMARTData(t *testing.T) {
+	corrupted := newATASMARTData(nil)
+	corrupted[100] = 0xff
+
+	testCases := []struct {
+		buf            []byte
+		expectedHealth *Health
+		expectErr      bool
+	}{
+		{make([]byte, ataSMARTDataLen), &Health{}, false},
+		{
+			newATASMARTData(map[uint8][2]uint64{
+				ataAttrReallocatedSectors:   {100, 8},
+				ataAttrReportedUncorrect:    {100, 2},
+				ataAttrOfflineUncorrectable: {100, 3},
+				ataAttrTemperatureCelsius:   {64, 0x0012002a0036},
+			}),
+			&Health{Temperature: 54, MediaErrors: 5, ReallocatedSectors: 8},
+			false,
+		},
+		{
+			newATASMARTData(map[uint8][2]uint64{
+				ataAttrWearLevelingCount: {93, 1234},
+			}),
+			&Health{PercentageUsed: 7},
+			false,
+		},
+		{corrupted, nil, true},
+		{make([]byte, 32), nil, true},
+	}
+
+	for i, testCase := range testCases {
+		health, err := parseATASMARTData(testCase.buf)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(health, testCase.expectedHealth) {
+			t.Fatalf("case %v: health: expected: %+v, got: %+v", i+1, testCase.expectedHealth, health)
+		}
+	}
+}
@@ -27,7 +27,11 @@ import (
 )
 
 const (
-	nvmeAdminIdentify = 0x06
+	nvmeAdminGetLogPage = 0x02
+	nvmeAdminIdentify   = 0x06
+
+	nvmeLogSMARTHealth = 0x02
+	nvmeNSIDAll        = 0xffffffff
 )
 
 var (
@@ -159,3 +163,27 @@ func (d *nvmeDevice) SerialNumber() (string, error) {
 
 	return string(controller.SerialNumber[:]), nil
 }
+
+func (d *nvmeDevice) Health() (*Health, error) {
+	fd, err := d.open()
+	if err != nil {
+		return nil, err
+	}
+	defer d.close(fd)
+
+	buf := make([]byte, nvmeSMARTLogLen)
+
+	cmd := nvmePassthruCommand{
+		opcode:  nvmeAdminGetLogPage,
+		nsid:    nvmeNSIDAll, // Controller wide log page
+		addr:    uint64(uintptr(unsafe.Pointer(&buf[0]))),
+		dataLen: uint32(len(buf)),
+		cdw10:   nvmeLogSMARTHealth | uint32(len(buf)/4-1)<<16, // Log page ID and number of dwords
+	}
+
+	if err := sysIOCTL(uintptr(fd), nvmeIoctlAdminCmd, uintptr(unsafe.Pointer(&cmd))); err != nil {
+		return nil, err
+	}
+
+	return parseNVMeSMARTLog(buf)
+}
@@ -38,6 +38,9 @@ const (
 
 var (
 	serialInquiry = []byte{0x12, 0x01, 0x80, 0x00, 0x60, 0x00}
+
+	// ATA PASS-THROUGH (16) of SMART READ DATA command with PIO data-in protocol
+	ataSMARTReadData = []byte{0x85, 0x08, 0x0e, 0x00, 0xd0, 0x00, 0x01, 0x00, 0x00, 0x00, 0x4f, 0x00, 0xc2, 0x00, 0xb0, 0x00}
 )
 
 type scsiDevice struct {
@@ -165,3 +168,17 @@ func (d *scsiDevice) SerialNumber() (string, error) {
 	}
 	return string(inquiryR[4:]), nil
 }
+
+func (d *scsiDevice) Health() (*Health, error) {
+	if err := d.open(); err != nil {
+		return nil, err
+	}
+	defer d.close()
+
+	respBuf := make([]byte, ataSMARTDataLen)
+	if err := d.sendCDB(ataSMARTReadData, &respBuf); err != nil {
+		return nil, err
+	}
+
+	return parseATASMARTData(respBuf)
+}
@@ -21,3 +21,9 @@ func GetSerialNumber(devicePath string) (string, error) {
 	sd := getSmartDevice(devicePath)
 	return sd.SerialNumber()
 }
+
+// GetHealth fetches device health information from S.M.A.R.T.
+func GetHealth(devicePath string) (*Health, error) {
+	sd := getSmartDevice(devicePath)
+	return sd.Health()
+}
@@ -23,12 +23,16 @@ var LoopRegexp = regexp.MustCompile("^loop[0-9]*")
 // Device is a block device information.
 type Device struct {
 	// Populated from /sys
-	Name      string
-	Major     int
-	Minor     int
-	Removable bool
-	ReadOnly  bool
-	Virtual   bool
+	Name       string
+	Major      int
+	Minor      int
+	Removable  bool
+	ReadOnly   bool
+	Virtual    bool
+	Rotational bool
+	Transport  string
+	Scheduler  string
+	NrRequests int
 
 	// Populated from /run/udev/data/b<Major>:<Minor>
 	Size      uint64
@@ -48,3 +48,11 @@ func DirectCSIVolumeTypeMeta() metav1.TypeMeta {
 		Kind:       "DirectCSIVolume",
 	}
 }
+
+// DirectCSISnapshotTypeMeta gets new direct-csi snapshot meta.
+func DirectCSISnapshotTypeMeta() metav1.TypeMeta {
+	return metav1.TypeMeta{
+		APIVersion: string(DirectCSIVersionLabelKey),
+		Kind:       "DirectCSISnapshot",
+	}
+}
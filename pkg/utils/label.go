@@ -55,16 +55,22 @@ func normalizeLabelValue(value string) string {
 type LabelKey string
 
 const (
-	PodNameLabelKey          LabelKey = directcsi.Group + "/pod.name"
-	PodNSLabelKey            LabelKey = directcsi.Group + "/pod.namespace"
-	NodeLabelKey             LabelKey = directcsi.Group + "/node"
-	DriveLabelKey            LabelKey = directcsi.Group + "/drive"
-	PathLabelKey             LabelKey = directcsi.Group + "/path"
-	AccessTierLabelKey       LabelKey = directcsi.Group + "/access-tier"
-	VersionLabelKey          LabelKey = directcsi.Group + "/version"
-	CreatedByLabelKey        LabelKey = directcsi.Group + "/created-by"
-	DrivePathLabelKey        LabelKey = directcsi.Group + "/drive-path"
-	DirectCSIVersionLabelKey LabelKey = directcsi.Group + "/" + directcsi.Version
+	PodNameLabelKey           LabelKey = directcsi.Group + "/pod.name"
+	PodNSLabelKey             LabelKey = directcsi.Group + "/pod.namespace"
+	PVCNameLabelKey           LabelKey = directcsi.Group + "/pvc.name"
+	PVCNamespaceLabelKey      LabelKey = directcsi.Group + "/pvc.namespace"
+	NodeLabelKey              LabelKey = directcsi.Group + "/node"
+	DriveLabelKey             LabelKey = directcsi.Group + "/drive"
+	VolumeLabelKey            LabelKey = directcsi.Group + "/volume"
+	PathLabelKey              LabelKey = directcsi.Group + "/path"
+	AccessTierLabelKey        LabelKey = directcsi.Group + "/access-tier"
+	VersionLabelKey           LabelKey = directcsi.Group + "/version"
+	CreatedByLabelKey         LabelKey = directcsi.Group + "/created-by"
+	DrivePathLabelKey         LabelKey = directcsi.Group + "/drive-path"
+	OvercommitRatioLabelKey   LabelKey = directcsi.Group + "/overcommit-ratio"
+	EphemeralLabelKey         LabelKey = directcsi.Group + "/ephemeral"
+	DataReclaimPolicyLabelKey LabelKey = directcsi.Group + "/data-reclaim-policy"
+	DirectCSIVersionLabelKey  LabelKey = directcsi.Group + "/" + directcsi.Version
 
 	TopologyDriverIdentity LabelKey = directcsi.Group + "/identity"
 	TopologyDriverNode     LabelKey = directcsi.Group + "/node"
@@ -73,6 +79,9 @@ const (
 	TopologyDriverRegion   LabelKey = directcsi.Group + "/region"
 )
 
+// DriveUserLabelPrefix is the prefix of user-defined drive label keys.
+const DriveUserLabelPrefix = "user." + directcsi.Group + "/"
+
 type LabelValue string
 
 func NewLabelValue(value string) LabelValue {
@@ -102,3 +111,38 @@ func ToLabelSelector(labels map[LabelKey][]LabelValue) string {
 	}
 	return strings.Join(selectors, ",")
 }
+
+// NewTopologyKey returns the topology segment key of the custom topology node label key.
+func NewTopologyKey(labelKey string) LabelKey {
+	name := labelKey
+	if i := strings.LastIndex(labelKey, "/"); i >= 0 {
+		name = labelKey[i+1:]
+	}
+	return LabelKey(directcsi.Group + "/" + name)
+}
+
+// ValidateTopologyKeys validates node label keys of custom topology.
+func ValidateTopologyKeys(labelKeys []string) error {
+	topologyKeys := map[LabelKey]string{
+		TopologyDriverIdentity: "",
+		TopologyDriverNode:     "",
+		TopologyDriverRack:     "",
+		TopologyDriverZone:     "",
+		TopologyDriverRegion:   "",
+	}
+	for _, labelKey := range labelKeys {
+		if errs := validation.IsQualifiedName(labelKey); len(errs) != 0 {
+			return fmt.Errorf("invalid topology key %v; %v", labelKey, strings.Join(errs, "; "))
+		}
+
+		topologyKey := NewTopologyKey(labelKey)
+		if existingKey, found := topologyKeys[topologyKey]; found {
+			if existingKey == "" {
+				return fmt.Errorf("topology key %v conflicts with built-in topology key %v", labelKey, topologyKey)
+			}
+			return fmt.Errorf("topology key %v conflicts with topology key %v", labelKey, existingKey)
+		}
+		topologyKeys[topologyKey] = labelKey
+	}
+	return nil
+}
@@ -107,3 +107,31 @@ func TestNewSafeFile(t *testing.T) {
 	}
 
 }
+
+func TestValidateTopologyKeys(t *testing.T) {
+	testCases := []struct {
+		labelKeys []string
+		expectErr bool
+	}{
+		{nil, false},
+		{[]string{"enclosure", "example.com/power-domain"}, false},
+		{[]string{"enclosure", "example.com/enclosure"}, true},
+		{[]string{"topology.kubernetes.io/zone"}, true},
+		{[]string{"node"}, true},
+		{[]string{"invalid key"}, true},
+	}
+
+	for i, testCase := range testCases {
+		err := ValidateTopologyKeys(testCase.labelKeys)
+		if testCase.expectErr && err == nil {
+			t.Fatalf("case %v: expected error, but succeeded", i+1)
+		}
+		if !testCase.expectErr && err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+	}
+
+	if key := NewTopologyKey("example.com/power-domain"); key != "direct.csi.min.io/power-domain" {
+		t.Fatalf("expected: direct.csi.min.io/power-domain, got: %v", key)
+	}
+}
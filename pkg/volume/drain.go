@@ -0,0 +1,68 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package volume
+
+import (
+	"fmt"
+	"strings"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetVolumeNames returns the names of the volumes placed on the drive.
+func GetVolumeNames(drive *directcsi.DirectCSIDrive) (names []string) {
+	for _, finalizer := range drive.GetFinalizers() {
+		if strings.HasPrefix(finalizer, directcsi.DirectCSIDriveFinalizerPrefix) {
+			names = append(names, strings.TrimPrefix(finalizer, directcsi.DirectCSIDriveFinalizerPrefix))
+		}
+	}
+	return names
+}
+
+// UpdateDrainedCondition sets the drained condition of the drive by the volumes remaining on it.
+func UpdateDrainedCondition(drive *directcsi.DirectCSIDrive) {
+	condition := metav1.Condition{
+		Type:    string(directcsi.DirectCSIDriveConditionDrained),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(directcsi.DirectCSIDriveReasonDrained),
+		Message: "no volumes remaining",
+	}
+	if count := len(GetVolumeNames(drive)); count > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(directcsi.DirectCSIDriveReasonDraining)
+		condition.Message = fmt.Sprintf("%v volumes remaining", count)
+	}
+	meta.SetStatusCondition(&drive.Status.Conditions, condition)
+}
+
+// SelectTargetDrive returns the drive having the most free capacity among given drives
+// the volume can be moved to; nil is returned if there is no such drive.
+func SelectTargetDrive(volume *directcsi.DirectCSIVolume, source *directcsi.DirectCSIDrive, drives []directcsi.DirectCSIDrive) *directcsi.DirectCSIDrive {
+	var target *directcsi.DirectCSIDrive
+	for i := range drives {
+		if CheckMove(volume, source, &drives[i]) != nil {
+			continue
+		}
+		if target == nil || drives[i].Status.FreeCapacity > target.Status.FreeCapacity {
+			target = &drives[i]
+		}
+	}
+	return target
+}
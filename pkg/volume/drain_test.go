@@ -0,0 +1,111 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package volume
+
+import (
+	"testing"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUpdateDrainedCondition(t *testing.T) {
+	testCases := []struct {
+		finalizers     []string
+		expectedStatus metav1.ConditionStatus
+		expectedReason directcsi.DirectCSIDriveReason
+	}{
+		{[]string{directcsi.DirectCSIDriveFinalizerDataProtection}, metav1.ConditionTrue, directcsi.DirectCSIDriveReasonDrained},
+		{[]string{directcsi.DirectCSIDriveFinalizerDataProtection, directcsi.DirectCSIDriveFinalizerPrefix + "volume-1"}, metav1.ConditionFalse, directcsi.DirectCSIDriveReasonDraining},
+	}
+
+	for i, testCase := range testCases {
+		drive := &directcsi.DirectCSIDrive{ObjectMeta: metav1.ObjectMeta{Name: "drive-1", Finalizers: testCase.finalizers}}
+		UpdateDrainedCondition(drive)
+		condition := meta.FindStatusCondition(drive.Status.Conditions, string(directcsi.DirectCSIDriveConditionDrained))
+		if condition == nil {
+			t.Fatalf("case %v: drained condition not found", i+1)
+		}
+		if condition.Status != testCase.expectedStatus || condition.Reason != string(testCase.expectedReason) {
+			t.Fatalf("case %v: expected: %v/%v, got: %v/%v", i+1, testCase.expectedStatus, testCase.expectedReason, condition.Status, condition.Reason)
+		}
+	}
+}
+
+func TestSelectTargetDrive(t *testing.T) {
+	newDrive := func(name string, free int64, cordoned bool) directcsi.DirectCSIDrive {
+		drive := directcsi.DirectCSIDrive{
+			TypeMeta:   utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:      testNodeName,
+				DriveStatus:   directcsi.DriveStatusInUse,
+				FreeCapacity:  free,
+				TotalCapacity: mb100,
+			},
+		}
+		if cordoned {
+			drive.Spec.DriveTaint = map[string]string{directcsi.DriveTaintCordoned: ""}
+		}
+		return drive
+	}
+
+	source := newDrive("drive-1", mb20, true)
+	volume := &directcsi.DirectCSIVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "volume-1"},
+		Status: directcsi.DirectCSIVolumeStatus{
+			NodeName:      testNodeName,
+			Drive:         source.Name,
+			TotalCapacity: mb20,
+		},
+	}
+
+	unhealthy := newDrive("drive-2", mb100, false)
+	unhealthy.Status.Conditions = []metav1.Condition{
+		{Type: string(directcsi.DirectCSIDriveConditionHealthy), Status: metav1.ConditionFalse},
+	}
+	tainted := newDrive("drive-2", mb100, false)
+	tainted.Spec.DriveTaint = map[string]string{"tenant": "acme:NoSchedule"}
+	preferTainted := newDrive("drive-2", mb100, false)
+	preferTainted.Spec.DriveTaint = map[string]string{"tenant": "acme:PreferNoSchedule"}
+
+	testCases := []struct {
+		drives         []directcsi.DirectCSIDrive
+		expectedResult string
+	}{
+		{[]directcsi.DirectCSIDrive{source}, ""},
+		{[]directcsi.DirectCSIDrive{source, newDrive("drive-2", mb100, true)}, ""},
+		{[]directcsi.DirectCSIDrive{source, newDrive("drive-2", mb20-1, false)}, ""},
+		{[]directcsi.DirectCSIDrive{source, newDrive("drive-2", mb20, false), newDrive("drive-3", mb100, false)}, "drive-3"},
+		{[]directcsi.DirectCSIDrive{source, newDrive("drive-2", mb100, true), newDrive("drive-3", mb20, false)}, "drive-3"},
+		{[]directcsi.DirectCSIDrive{source, unhealthy, newDrive("drive-3", mb20, false)}, "drive-3"},
+		{[]directcsi.DirectCSIDrive{source, tainted, newDrive("drive-3", mb20, false)}, "drive-3"},
+		{[]directcsi.DirectCSIDrive{source, preferTainted, newDrive("drive-3", mb20, false)}, "drive-2"},
+	}
+
+	for i, testCase := range testCases {
+		result := ""
+		if target := SelectTargetDrive(volume, &source, testCase.drives); target != nil {
+			result = target.Name
+		}
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package volume
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/fs/xfs"
+	"github.com/minio/directpv/pkg/matcher"
+	"github.com/minio/directpv/pkg/sys"
+	"github.com/minio/directpv/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+func getDevice(major, minor uint32) (string, error) {
+	name, err := sys.GetDeviceName(major, minor)
+	if err != nil {
+		return "", err
+	}
+	return "/dev/" + name, nil
+}
+
+func checkInUse(volume *directcsi.DirectCSIVolume) error {
+	if utils.IsConditionStatus(volume.Status.Conditions, string(directcsi.DirectCSIVolumeConditionStaged), metav1.ConditionTrue) ||
+		utils.IsConditionStatus(volume.Status.Conditions, string(directcsi.DirectCSIVolumeConditionPublished), metav1.ConditionTrue) {
+		return fmt.Errorf("volume %v is in use", volume.Name)
+	}
+	return nil
+}
+
+// CheckMove validates whether the volume can be moved to the target drive.
+func CheckMove(volume *directcsi.DirectCSIVolume, source, target *directcsi.DirectCSIDrive) error {
+	if err := checkInUse(volume); err != nil {
+		return err
+	}
+
+	if source.Status.BlockVolume == volume.Name {
+		return fmt.Errorf("raw block volume %v cannot be moved", volume.Name)
+	}
+
+	if target.Name == source.Name {
+		return fmt.Errorf("volume %v is already on drive %v", volume.Name, target.Name)
+	}
+
+	if target.Status.NodeName != volume.Status.NodeName {
+		return fmt.Errorf("drive %v is not on node %v of volume %v", target.Name, volume.Status.NodeName, volume.Name)
+	}
+
+	switch target.Status.DriveStatus {
+	case directcsi.DriveStatusReady, directcsi.DriveStatusInUse:
+	default:
+		return fmt.Errorf("drive %v is not in Ready or InUse state", target.Name)
+	}
+
+	if target.IsCordoned() {
+		return fmt.Errorf("drive %v is cordoned", target.Name)
+	}
+
+	if target.IsUnhealthy() {
+		return fmt.Errorf("drive %v is unhealthy", target.Name)
+	}
+
+	// Moved volume has no tolerations; invalid drive taints are treated as NoSchedule taints.
+	for key, taintValue := range target.Spec.DriveTaint {
+		if _, effect, err := directcsi.ParseDriveTaintValue(taintValue); err != nil || effect == directcsi.DriveTaintEffectNoSchedule {
+			return fmt.Errorf("drive %v has NoSchedule taint %v", target.Name, key)
+		}
+	}
+
+	if target.Status.BlockVolume != "" {
+		return fmt.Errorf("drive %v is dedicated to raw block volume %v", target.Name, target.Status.BlockVolume)
+	}
+
+	if target.Status.Filesystem != source.Status.Filesystem {
+		return fmt.Errorf("drive %v has filesystem %v different from filesystem %v of drive %v", target.Name, target.Status.Filesystem, source.Status.Filesystem, source.Name)
+	}
+
+	if target.Status.FreeCapacity < volume.Status.TotalCapacity {
+		return fmt.Errorf("drive %v does not have enough free capacity; requested %v, available %v", target.Name, volume.Status.TotalCapacity, target.Status.FreeCapacity)
+	}
+
+	return nil
+}
+
+// reserveDrive reserves the capacity of the volume on the target drive. The volume is
+// validated on its latest state as the event object may be stale; staging is refused
+// while the move request is set, hence the volume stays unused for the whole move.
+func reserveDrive(ctx context.Context, volumeName string, source *directcsi.DirectCSIDrive, targetName string) (target *directcsi.DirectCSIDrive, err error) {
+	volumeInterface := client.GetLatestDirectCSIVolumeInterface()
+	driveInterface := client.GetLatestDirectCSIDriveInterface()
+	finalizer := directcsi.DirectCSIDriveFinalizerPrefix + volumeName
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		volume, err := volumeInterface.Get(ctx, volumeName, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			return err
+		}
+
+		if volume.Status.TargetDrive != targetName {
+			return fmt.Errorf("move request of volume %v is changed to drive %v", volume.Name, volume.Status.TargetDrive)
+		}
+
+		target, err = driveInterface.Get(ctx, targetName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			return err
+		}
+
+		// Drive is already reserved by a previous interrupted move.
+		if matcher.StringIn(target.Finalizers, finalizer) {
+			return checkInUse(volume)
+		}
+
+		if err = CheckMove(volume, source, target); err != nil {
+			return err
+		}
+
+		target.Status.FreeCapacity -= volume.Status.TotalCapacity
+		target.Status.AllocatedCapacity += volume.Status.TotalCapacity
+		target.Status.DriveStatus = directcsi.DriveStatusInUse
+		target.SetFinalizers(append(target.GetFinalizers(), finalizer))
+		target, err = driveInterface.Update(ctx, target, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		return err
+	})
+	return target, err
+}
+
+// copyVolume copies the volume directory from source drive to target drive and applies
+// the volume quota on it.
+func (handler *volumeEventHandler) copyVolume(ctx context.Context, volume *directcsi.DirectCSIVolume, source, target *directcsi.DirectCSIDrive) (string, error) {
+	srcPath := filepath.Join(source.Status.Mountpoint, volume.Name)
+	dstPath := filepath.Join(target.Status.Mountpoint, volume.Name)
+
+	srcExists := true
+	if _, err := os.Stat(srcPath); err != nil {
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		// Volume populated from a content source needs its source on the same drive.
+		if volume.Status.ContentSource != nil {
+			return "", fmt.Errorf("volume %v is not populated from its content source yet", volume.Name)
+		}
+		srcExists = false
+	}
+
+	if err := os.RemoveAll(dstPath); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dstPath, 0755); err != nil {
+		return "", err
+	}
+
+	device, err := handler.getDevice(target.Status.MajorNumber, target.Status.MinorNumber)
+	if err != nil {
+		return "", fmt.Errorf("unable to find device for major/minor %v:%v; %w", target.Status.MajorNumber, target.Status.MinorNumber, err)
+	}
+
+	// Set the quota before copying so that the copied files are accounted
+	// in the project of this volume.
+	quota := xfs.Quota{
+		HardLimit: uint64(volume.Status.TotalCapacity),
+		SoftLimit: uint64(volume.Status.TotalCapacity),
+	}
+	if err := handler.setQuota(ctx, device, dstPath, volume.Name, quota); err != nil {
+		return "", err
+	}
+
+	if srcExists {
+		klog.V(3).InfoS("Copying volume", "volume", volume.Name, "source", srcPath, "target", dstPath)
+		if err := handler.copyDir(srcPath, dstPath, true); err != nil {
+			return "", err
+		}
+	}
+
+	return dstPath, nil
+}
+
+// updateVolume points the volume to the target drive and clears the move request.
+func updateVolume(ctx context.Context, volumeName string, target *directcsi.DirectCSIDrive, hostPath string) error {
+	volumeInterface := client.GetLatestDirectCSIVolumeInterface()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		volume, err := volumeInterface.Get(ctx, volumeName, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			return err
+		}
+
+		if volume.Status.TargetDrive != target.Name {
+			return fmt.Errorf("move request of volume %v is changed to drive %v", volume.Name, volume.Status.TargetDrive)
+		}
+
+		volume.Status.Drive = target.Name
+		if volume.Status.HostPath != "" {
+			volume.Status.HostPath = hostPath
+		}
+		volume.Status.TargetDrive = ""
+		utils.UpdateLabels(volume, map[utils.LabelKey]utils.LabelValue{
+			utils.DrivePathLabelKey: utils.NewLabelValue(utils.SanitizeDrivePath(target.Status.Path)),
+			utils.DriveLabelKey:     utils.NewLabelValue(target.Name),
+		})
+		_, err = volumeInterface.Update(ctx, volume, metav1.UpdateOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		return err
+	})
+}
+
+// clearTargetDrive clears the move request of the volume.
+func clearTargetDrive(ctx context.Context, volumeName string) error {
+	volumeInterface := client.GetLatestDirectCSIVolumeInterface()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		volume, err := volumeInterface.Get(ctx, volumeName, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			return err
+		}
+		if volume.Status.TargetDrive == "" {
+			return nil
+		}
+		volume.Status.TargetDrive = ""
+		_, err = volumeInterface.Update(ctx, volume, metav1.UpdateOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		return err
+	})
+}
+
+// moveVolume moves the volume to the target drive; on failure, changes done on the
+// target drive are rolled back and the volume stays on its source drive.
+func (handler *volumeEventHandler) moveVolume(ctx context.Context, volume *directcsi.DirectCSIVolume) (err error) {
+	source, err := client.GetLatestDirectCSIDriveInterface().Get(
+		ctx, volume.Status.Drive, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+	)
+	if err != nil {
+		return err
+	}
+
+	target, err := reserveDrive(ctx, volume.Name, source, volume.Status.TargetDrive)
+	if err != nil {
+		// Release the reservation left by a previous interrupted move.
+		if target != nil && matcher.StringIn(target.Finalizers, directcsi.DirectCSIDriveFinalizerPrefix+volume.Name) {
+			if rerr := handler.releaseVolume(ctx, target.Name, volume.Name, volume.Status.TotalCapacity); rerr != nil {
+				klog.ErrorS(rerr, "unable to release drive", "drive", target.Name, "volume", volume.Name)
+			}
+		}
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if rerr := handler.releaseVolume(ctx, target.Name, volume.Name, volume.Status.TotalCapacity); rerr != nil {
+				klog.ErrorS(rerr, "unable to release drive", "drive", target.Name, "volume", volume.Name)
+			}
+		}
+	}()
+
+	hostPath, err := handler.copyVolume(ctx, volume, source, target)
+	defer func() {
+		if err != nil {
+			if rerr := os.RemoveAll(filepath.Join(target.Status.Mountpoint, volume.Name)); rerr != nil {
+				klog.ErrorS(rerr, "unable to remove volume directory", "drive", target.Name, "volume", volume.Name)
+			}
+		}
+	}()
+	if err != nil {
+		return err
+	}
+
+	if err = updateVolume(ctx, volume.Name, target, hostPath); err != nil {
+		return err
+	}
+
+	// Volume is moved; failures in cleaning up the source drive are only reported.
+	srcPath := filepath.Join(source.Status.Mountpoint, volume.Name)
+	if _, rerr := os.Stat(srcPath); rerr == nil {
+		if rerr := handler.clearQuota(ctx, volume, srcPath); rerr != nil {
+			klog.ErrorS(rerr, "unable to clear quota", "drive", source.Name, "volume", volume.Name)
+		}
+	}
+	if rerr := handler.releaseVolume(ctx, source.Name, volume.Name, volume.Status.TotalCapacity); rerr != nil {
+		klog.ErrorS(rerr, "unable to release drive", "drive", source.Name, "volume", volume.Name)
+	}
+	if rerr := os.RemoveAll(srcPath); rerr != nil {
+		klog.ErrorS(rerr, "unable to remove volume directory", "drive", source.Name, "volume", volume.Name)
+	}
+
+	return nil
+}
+
+// move handles the request to move the volume to its target drive.
+func (handler *volumeEventHandler) move(ctx context.Context, volume *directcsi.DirectCSIVolume) error {
+	targetDrive := volume.Status.TargetDrive
+	klog.V(3).InfoS("Moving volume", "volume", volume.Name, "source", volume.Status.Drive, "target", targetDrive)
+
+	if err := handler.moveVolume(ctx, volume); err != nil {
+		klog.ErrorS(err, "unable to move volume", "volume", volume.Name, "target", targetDrive)
+		client.Eventf(volume, corev1.EventTypeWarning, "VolumeMoveFailed", "unable to move volume %v to drive %v; %v", volume.Name, targetDrive, err)
+		return clearTargetDrive(ctx, volume.Name)
+	}
+
+	client.Eventf(volume, corev1.EventTypeNormal, "VolumeMoveSucceeded", "volume %v moved from drive %v to drive %v", volume.Name, volume.Status.Drive, targetDrive)
+	return nil
+}
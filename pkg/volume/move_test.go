@@ -0,0 +1,180 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package volume
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/fs/xfs"
+	"github.com/minio/directpv/pkg/listener"
+	"github.com/minio/directpv/pkg/matcher"
+	"github.com/minio/directpv/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMoveVolume(t *testing.T) {
+	client.FakeInit()
+
+	newDrive := func(name, mountpoint, filesystem string, finalizers ...string) *directcsi.DirectCSIDrive {
+		allocated := int64(len(finalizers)) * mb20
+		return &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Finalizers: append([]string{directcsi.DirectCSIDriveFinalizerDataProtection}, finalizers...),
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:          testNodeName,
+				Mountpoint:        mountpoint,
+				Filesystem:        filesystem,
+				DriveStatus:       directcsi.DriveStatusReady,
+				FreeCapacity:      mb100 - allocated,
+				AllocatedCapacity: allocated,
+				TotalCapacity:     mb100,
+			},
+		}
+	}
+	newVolume := func(staged bool) *directcsi.DirectCSIVolume {
+		return &directcsi.DirectCSIVolume{
+			TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "volume-1",
+				Labels: map[string]string{string(utils.DriveLabelKey): "drive-1"},
+			},
+			Status: directcsi.DirectCSIVolumeStatus{
+				NodeName:      testNodeName,
+				Drive:         "drive-1",
+				TargetDrive:   "drive-2",
+				TotalCapacity: mb20,
+				Conditions: []metav1.Condition{
+					{Type: string(directcsi.DirectCSIVolumeConditionStaged), Status: utils.BoolToCondition(staged)},
+					{Type: string(directcsi.DirectCSIVolumeConditionPublished), Status: metav1.ConditionFalse},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		staged       bool
+		staleEvent   bool
+		reserved     bool
+		setQuotaErr  error
+		targetFS     string
+		expectedMove bool
+	}{
+		{false, false, false, nil, "xfs", true},
+		{false, false, false, errors.New("quota error"), "xfs", false},
+		{true, false, false, nil, "xfs", false},
+		{true, true, false, nil, "xfs", false},
+		{false, false, true, nil, "xfs", true},
+		{true, true, true, nil, "xfs", false},
+		{false, false, false, nil, "ext4", false},
+	}
+
+	for i, testCase := range testCases {
+		source, target := t.TempDir(), t.TempDir()
+		if err := os.MkdirAll(filepath.Join(source, "volume-1"), 0755); err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if err := os.WriteFile(filepath.Join(source, "volume-1", "data"), []byte("data"), 0644); err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+
+		volume := newVolume(testCase.staged)
+		volume.Status.HostPath = filepath.Join(source, "volume-1")
+		// Event object may be stale while the volume got staged.
+		event := volume
+		if testCase.staleEvent {
+			event = newVolume(false)
+			event.Status.HostPath = volume.Status.HostPath
+		}
+		var targetFinalizers []string
+		if testCase.reserved {
+			targetFinalizers = append(targetFinalizers, directcsi.DirectCSIDriveFinalizerPrefix+"volume-1")
+		}
+		handler := createFakeVolumeEventListener(
+			newDrive("drive-1", source, "xfs", directcsi.DirectCSIDriveFinalizerPrefix+"volume-1"),
+			newDrive("drive-2", target, testCase.targetFS, targetFinalizers...),
+			volume,
+		)
+		var clearedPath string
+		handler.setQuota = func(ctx context.Context, device, path, volumeID string, quota xfs.Quota) error {
+			if quota == (xfs.Quota{}) {
+				clearedPath = path
+			}
+			return testCase.setQuotaErr
+		}
+
+		ctx := context.TODO()
+		if err := handler.Handle(ctx, listener.EventArgs{Event: listener.UpdateEvent, Object: event}); err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+
+		result, err := client.GetLatestDirectCSIVolumeInterface().Get(ctx, "volume-1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if result.Status.TargetDrive != "" {
+			t.Fatalf("case %v: expected empty target drive, got: %v", i+1, result.Status.TargetDrive)
+		}
+
+		moved, remained := "drive-2", "drive-1"
+		movedPath, remainedPath := target, source
+		if !testCase.expectedMove {
+			moved, remained = remained, moved
+			movedPath, remainedPath = remainedPath, movedPath
+		}
+
+		if result.Status.Drive != moved || result.Labels[string(utils.DriveLabelKey)] != moved {
+			t.Fatalf("case %v: expected drive: %v, got: %v", i+1, moved, result.Status.Drive)
+		}
+		if result.Status.HostPath != filepath.Join(movedPath, "volume-1") {
+			t.Fatalf("case %v: expected host path: %v, got: %v", i+1, filepath.Join(movedPath, "volume-1"), result.Status.HostPath)
+		}
+		if _, err := os.Stat(filepath.Join(movedPath, "volume-1", "data")); err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if _, err := os.Stat(filepath.Join(remainedPath, "volume-1")); !os.IsNotExist(err) {
+			t.Fatalf("case %v: expected volume directory removed from %v; %v", i+1, remained, err)
+		}
+		if testCase.expectedMove && clearedPath != filepath.Join(source, "volume-1") {
+			t.Fatalf("case %v: expected quota cleared on %v, got: %v", i+1, filepath.Join(source, "volume-1"), clearedPath)
+		}
+
+		drive, err := client.GetLatestDirectCSIDriveInterface().Get(ctx, moved, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if !matcher.StringIn(drive.Finalizers, directcsi.DirectCSIDriveFinalizerPrefix+"volume-1") || drive.Status.FreeCapacity != mb100-mb20 {
+			t.Fatalf("case %v: drive %v is not reserved for volume; %+v", i+1, moved, drive)
+		}
+
+		drive, err = client.GetLatestDirectCSIDriveInterface().Get(ctx, remained, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if len(drive.Finalizers) != 1 || drive.Status.FreeCapacity != mb100 || drive.Status.DriveStatus != directcsi.DriveStatusReady {
+			t.Fatalf("case %v: drive %v is not released; %+v", i+1, remained, drive)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package volume
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/utils"
+
+	"k8s.io/klog/v2"
+)
+
+// DataReclaimPolicyParameter is the storage class parameter to choose what is done with the data of deleted volumes.
+const DataReclaimPolicyParameter = "direct-csi-min-io/data-reclaim-policy"
+
+// DataReclaimPolicy denotes what is done with the data of deleted volume.
+type DataReclaimPolicy string
+
+const (
+	// DataReclaimPolicyDelete moves the data to the trash of the drive to be purged in background.
+	DataReclaimPolicyDelete DataReclaimPolicy = "delete"
+
+	// DataReclaimPolicyRetain leaves the data on the drive as is.
+	DataReclaimPolicyRetain DataReclaimPolicy = "retain"
+
+	// DataReclaimPolicyArchive moves the data to the archive of the drive.
+	DataReclaimPolicyArchive DataReclaimPolicy = "archive"
+)
+
+const (
+	trashDir   = ".trash"
+	archiveDir = ".archive"
+
+	purgeInterval = 1 * time.Minute
+)
+
+// purgeRate is the number of files and directories removed per second from the trash of a drive.
+var purgeRate = 1000
+
+// ToDataReclaimPolicy converts value to data reclaim policy.
+func ToDataReclaimPolicy(value string) (DataReclaimPolicy, error) {
+	policy := DataReclaimPolicy(strings.ToLower(value))
+	switch policy {
+	case DataReclaimPolicyDelete, DataReclaimPolicyRetain, DataReclaimPolicyArchive:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown data reclaim policy %v", value)
+}
+
+// getDataReclaimPolicy returns data reclaim policy of the volume; defaults to delete.
+func getDataReclaimPolicy(volume *directcsi.DirectCSIVolume) DataReclaimPolicy {
+	value, found := volume.GetLabels()[string(utils.DataReclaimPolicyLabelKey)]
+	if !found {
+		return DataReclaimPolicyDelete
+	}
+
+	policy, err := ToDataReclaimPolicy(value)
+	if err != nil {
+		klog.ErrorS(err, "using delete data reclaim policy", "volume", volume.Name)
+		return DataReclaimPolicyDelete
+	}
+	return policy
+}
+
+// reclaimData moves the directory of the volume as per its data reclaim policy and
+// returns the path of the data left on the drive. Empty path is returned if the
+// volume has no data on the drive.
+func reclaimData(volume *directcsi.DirectCSIVolume) (string, error) {
+	if volume.Status.HostPath == "" {
+		return "", nil
+	}
+
+	var dir string
+	switch getDataReclaimPolicy(volume) {
+	case DataReclaimPolicyRetain:
+		dir = ""
+	case DataReclaimPolicyArchive:
+		dir = archiveDir
+	default:
+		dir = trashDir
+	}
+
+	if dir == "" {
+		if _, err := os.Lstat(volume.Status.HostPath); err != nil {
+			if os.IsNotExist(err) {
+				return "", nil
+			}
+			return "", err
+		}
+		return volume.Status.HostPath, nil
+	}
+
+	// Volume UID is appended to the name so that a recreated volume of the same name never collides.
+	dir = filepath.Join(filepath.Dir(volume.Status.HostPath), dir)
+	path := filepath.Join(dir, volume.Name+"-"+string(volume.UID))
+
+	if _, err := os.Lstat(volume.Status.HostPath); err != nil {
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+
+		// Directory of the volume is already moved by previous attempt, or never existed.
+		if _, err := os.Lstat(path); err != nil {
+			if os.IsNotExist(err) {
+				return "", nil
+			}
+			return "", err
+		}
+		return path, nil
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	if err := os.Rename(volume.Status.HostPath, path); err != nil {
+		return "", err
+	}
+
+	klog.V(3).InfoS("Reclaimed volume data", "volume", volume.Name, "path", path)
+	return path, nil
+}
+
+// removeAll removes path and its children; wait is called before each removal.
+// Unlike os.RemoveAll, removed files stay removed on interruption so that a later
+// call resumes the removal.
+func removeAll(path string, wait func() error) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := removeAll(filepath.Join(path, entry.Name()), wait); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := wait(); err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// purgeTrash removes all entries in the trash directory.
+func purgeTrash(trashPath string, wait func() error) error {
+	entries, err := os.ReadDir(trashPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(trashPath, entry.Name())
+		if err := removeAll(path, wait); err != nil {
+			return err
+		}
+		klog.V(3).InfoS("Purged volume data", "path", path)
+	}
+
+	return nil
+}
+
+func purgeDrives(ctx context.Context, nodeID string, wait func() error) {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	resultCh, err := client.ListDrives(
+		ctx,
+		[]utils.LabelValue{utils.NewLabelValue(nodeID)},
+		nil,
+		nil,
+		client.MaxThreadCount,
+	)
+	if err != nil {
+		klog.Error(err)
+		return
+	}
+
+	for result := range resultCh {
+		if result.Err != nil {
+			klog.Error(result.Err)
+			return
+		}
+
+		if result.Drive.Status.Mountpoint == "" {
+			continue
+		}
+
+		if err := purgeTrash(filepath.Join(result.Drive.Status.Mountpoint, trashDir), wait); err != nil {
+			klog.ErrorS(err, "unable to purge trash", "drive", result.Drive.Name)
+		}
+	}
+}
+
+// StartPurger purges the trash of the drives of this node at regular interval with
+// removals limited to purgeRate per second. As the trash is on the drive, purging
+// continues after restart.
+func StartPurger(ctx context.Context, nodeID string) {
+	throttle := time.NewTicker(time.Second / time.Duration(purgeRate))
+	defer throttle.Stop()
+	wait := func() error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-throttle.C:
+			return nil
+		}
+	}
+
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+	for {
+		purgeDrives(ctx, nodeID, wait)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
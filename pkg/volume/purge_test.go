@@ -0,0 +1,174 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package volume
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/directpv/pkg/utils"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestToDataReclaimPolicy(t *testing.T) {
+	testCases := []struct {
+		value          string
+		expectedPolicy DataReclaimPolicy
+		expectErr      bool
+	}{
+		{"delete", DataReclaimPolicyDelete, false},
+		{"Retain", DataReclaimPolicyRetain, false},
+		{"ARCHIVE", DataReclaimPolicyArchive, false},
+		{"", "", true},
+		{"purge", "", true},
+	}
+
+	for i, testCase := range testCases {
+		policy, err := ToDataReclaimPolicy(testCase.value)
+		if testCase.expectErr != (err != nil) {
+			t.Fatalf("case %v: expected error: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if policy != testCase.expectedPolicy {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedPolicy, policy)
+		}
+	}
+}
+
+func TestReclaimData(t *testing.T) {
+	newVolume := func(hostPath, policy string) *directcsi.DirectCSIVolume {
+		volume := &directcsi.DirectCSIVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "volume-1", UID: "uid-1"},
+			Status:     directcsi.DirectCSIVolumeStatus{HostPath: hostPath},
+		}
+		if policy != "" {
+			volume.Labels = map[string]string{string(utils.DataReclaimPolicyLabelKey): policy}
+		}
+		return volume
+	}
+
+	testCases := []struct {
+		policy       string
+		createDir    bool
+		expectedPath string
+	}{
+		{"", true, filepath.Join(trashDir, "volume-1-uid-1")},
+		{"delete", true, filepath.Join(trashDir, "volume-1-uid-1")},
+		{"unknown", true, filepath.Join(trashDir, "volume-1-uid-1")},
+		{"archive", true, filepath.Join(archiveDir, "volume-1-uid-1")},
+		{"retain", true, "volume-1"},
+		{"delete", false, ""},
+		{"retain", false, ""},
+	}
+
+	for i, testCase := range testCases {
+		driveDir := t.TempDir()
+		hostPath := filepath.Join(driveDir, "volume-1")
+		if testCase.createDir {
+			if err := os.MkdirAll(filepath.Join(hostPath, "data"), 0755); err != nil {
+				t.Fatalf("case %v: %v", i+1, err)
+			}
+		}
+
+		path, err := reclaimData(newVolume(hostPath, testCase.policy))
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+
+		expectedPath := ""
+		if testCase.expectedPath != "" {
+			expectedPath = filepath.Join(driveDir, testCase.expectedPath)
+		}
+		if path != expectedPath {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, expectedPath, path)
+		}
+
+		if path != "" {
+			if _, err := os.Stat(filepath.Join(path, "data")); err != nil {
+				t.Fatalf("case %v: data not found in %v; %v", i+1, path, err)
+			}
+		}
+	}
+
+	// Directory moved by previous attempt is reported for quota to be cleared.
+	driveDir := t.TempDir()
+	movedPath := filepath.Join(driveDir, archiveDir, "volume-1-uid-1")
+	if err := os.MkdirAll(movedPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if path, err := reclaimData(newVolume(filepath.Join(driveDir, "volume-1"), "archive")); path != movedPath || err != nil {
+		t.Fatalf("expected: %v, got: %v, %v", movedPath, path, err)
+	}
+
+	if path, err := reclaimData(newVolume("", "")); path != "" || err != nil {
+		t.Fatalf("expected empty path and no error, got: %v, %v", path, err)
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	trashPath := filepath.Join(t.TempDir(), trashDir)
+	for _, dir := range []string{"volume-1-uid-1/a/b", "volume-2-uid-2"} {
+		if err := os.MkdirAll(filepath.Join(trashPath, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(trashPath, "volume-1-uid-1", "a", "file"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("/", filepath.Join(trashPath, "volume-2-uid-2", "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Interrupted purge leaves remaining entries to be removed by next purge.
+	count := 0
+	errInterrupted := errors.New("interrupted")
+	wait := func() error {
+		if count++; count > 2 {
+			return errInterrupted
+		}
+		return nil
+	}
+	if err := purgeTrash(trashPath, wait); err != errInterrupted {
+		t.Fatalf("expected: %v, got: %v", errInterrupted, err)
+	}
+	if _, err := os.Stat(filepath.Join(trashPath, "volume-1-uid-1", "a", "file")); !os.IsNotExist(err) {
+		t.Fatalf("expected file to be removed; %v", err)
+	}
+
+	count = 0
+	wait = func() error { return nil }
+	if err := purgeTrash(trashPath, wait); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	entries, err := os.ReadDir(trashPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected empty trash, got: %v", entries)
+	}
+	if _, err := os.Stat("/"); err != nil {
+		t.Fatalf("symlink target must not be removed; %v", err)
+	}
+
+	if err := purgeTrash(filepath.Join(t.TempDir(), trashDir), wait); err != nil {
+		t.Fatalf("unexpected error on missing trash: %v", err)
+	}
+}
@@ -23,7 +23,9 @@ import (
 
 	directcsi "github.com/minio/directpv/pkg/apis/direct.csi.min.io/v1beta3"
 	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/fs/xfs"
 	"github.com/minio/directpv/pkg/listener"
+	"github.com/minio/directpv/pkg/sys"
 	"github.com/minio/directpv/pkg/utils"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -47,11 +49,19 @@ func excludeFinalizer(finalizers []string, finalizer string) (result []string, f
 }
 
 type volumeEventHandler struct {
-	nodeID string
+	nodeID    string
+	getDevice func(major, minor uint32) (string, error)
+	setQuota  func(ctx context.Context, device, path, volumeID string, quota xfs.Quota) error
+	copyDir   func(src, dst string, fallback bool) error
 }
 
 func newVolumeEventHandler(nodeID string) *volumeEventHandler {
-	return &volumeEventHandler{nodeID: nodeID}
+	return &volumeEventHandler{
+		nodeID:    nodeID,
+		getDevice: getDevice,
+		setQuota:  xfs.SetQuota,
+		copyDir:   sys.CopyDir,
+	}
 }
 
 func (handler *volumeEventHandler) ListerWatcher() cache.ListerWatcher {
@@ -108,6 +118,27 @@ func (handler *volumeEventHandler) releaseVolume(ctx context.Context, driveName,
 		drive.Status.FreeCapacity += capacity
 		drive.Status.AllocatedCapacity = drive.Status.TotalCapacity - drive.Status.FreeCapacity
 
+		if drive.IsCordoned() {
+			UpdateDrainedCondition(drive)
+		}
+
+		if drive.Status.BlockVolume == volumeName {
+			drive.Status.BlockVolume = ""
+
+			// Drive unmounted by staging was handed over to the raw block volume, hence its
+			// filesystem is overwritten; request the drive controller to format it with the
+			// same filesystem and mount it again. Drive never staged still has its filesystem
+			// mounted and is ready to use as is.
+			if drive.Status.Mountpoint == "" {
+				drive.Status.DriveStatus = directcsi.DriveStatusAvailable
+				drive.Spec.RequestedFormat = &directcsi.RequestedFormat{
+					Force:        true,
+					Filesystem:   drive.Status.Filesystem,
+					MountOptions: drive.Status.MountOptions,
+				}
+			}
+		}
+
 		_, err = driveInterface.Update(
 			ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
 		)
@@ -116,6 +147,23 @@ func (handler *volumeEventHandler) releaseVolume(ctx context.Context, driveName,
 	return err
 }
 
+func (handler *volumeEventHandler) clearQuota(ctx context.Context, volume *directcsi.DirectCSIVolume, path string) error {
+	drive, err := client.GetLatestDirectCSIDriveInterface().Get(
+		ctx, volume.Status.Drive, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+	)
+	if err != nil {
+		return err
+	}
+
+	device, err := handler.getDevice(drive.Status.MajorNumber, drive.Status.MinorNumber)
+	if err != nil {
+		klog.ErrorS(err, "unable to find device to clear quota", "volume", volume.Name, "drive", drive.Name)
+		return nil
+	}
+
+	return handler.setQuota(ctx, device, path, volume.Name, xfs.Quota{})
+}
+
 func (handler *volumeEventHandler) delete(ctx context.Context, volume *directcsi.DirectCSIVolume) error {
 	finalizers, _ := excludeFinalizer(
 		volume.GetFinalizers(), string(directcsi.DirectCSIVolumeFinalizerPurgeProtection),
@@ -124,18 +172,29 @@ func (handler *volumeEventHandler) delete(ctx context.Context, volume *directcsi
 		return fmt.Errorf("waiting for the volume to be released before cleaning up")
 	}
 
-	// Remove associated directory of the volume.
-	if err := os.RemoveAll(volume.Status.HostPath); err != nil {
+	// Move associated directory of the volume as per data reclaim policy; the
+	// trash is purged in background by the purger.
+	path, err := reclaimData(volume)
+	if err != nil {
 		return err
 	}
 
+	// Clear the quota so that the space used by the data is no more accounted to the
+	// volume; capacity of the volume is released thereafter. The data left on the
+	// drive is handled by the data reclaim policy.
+	if path != "" {
+		if err := handler.clearQuota(ctx, volume, path); err != nil {
+			return err
+		}
+	}
+
 	// Release volume from associated drive.
 	if err := handler.releaseVolume(ctx, volume.Status.Drive, volume.Name, volume.Status.TotalCapacity); err != nil {
 		return err
 	}
 
 	volume.SetFinalizers(finalizers)
-	_, err := client.GetLatestDirectCSIVolumeInterface().Update(
+	_, err = client.GetLatestDirectCSIVolumeInterface().Update(
 		ctx, volume, metav1.UpdateOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()},
 	)
 
@@ -143,7 +202,12 @@ func (handler *volumeEventHandler) delete(ctx context.Context, volume *directcsi
 }
 
 func (handler *volumeEventHandler) Handle(ctx context.Context, args listener.EventArgs) error {
-	if args.Event == listener.DeleteEvent {
+	switch args.Event {
+	case listener.AddEvent, listener.UpdateEvent:
+		if volume := args.Object.(*directcsi.DirectCSIVolume); volume.Status.TargetDrive != "" {
+			return handler.move(ctx, volume)
+		}
+	case listener.DeleteEvent:
 		return handler.delete(ctx, args.Object.(*directcsi.DirectCSIVolume))
 	}
 
@@ -18,10 +18,14 @@ package volume
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/minio/directpv/pkg/client"
+	"github.com/minio/directpv/pkg/fs/xfs"
 	"github.com/minio/directpv/pkg/listener"
+	"github.com/minio/directpv/pkg/sys"
 	"github.com/minio/directpv/pkg/utils"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -46,7 +50,14 @@ func createFakeVolumeEventListener(objects ...runtime.Object) *volumeEventHandle
 	fakeDirectCSIClient := clientsetfake.NewSimpleClientset(objects...).DirectV1beta3()
 	client.SetLatestDirectCSIDriveInterface(fakeDirectCSIClient.DirectCSIDrives())
 	client.SetLatestDirectCSIVolumeInterface(fakeDirectCSIClient.DirectCSIVolumes())
-	return &volumeEventHandler{nodeID: testNodeName}
+	return &volumeEventHandler{
+		nodeID:    testNodeName,
+		getDevice: func(major, minor uint32) (string, error) { return "", nil },
+		setQuota: func(ctx context.Context, device, path, volumeID string, quota xfs.Quota) error {
+			return nil
+		},
+		copyDir: sys.CopyDir,
+	}
 }
 
 func TestVolumeEventHandlerHandle(t *testing.T) {
@@ -256,3 +267,185 @@ func TestAbnormalDeleteEventHandle(t *testing.T) {
 		}
 	}
 }
+
+func TestBlockVolumeDeleteEventHandle(t *testing.T) {
+	testCases := []struct {
+		mountpoint     string
+		expectedStatus directcsi.DriveStatus
+		expectFormat   bool
+	}{
+		// Drive unmounted by staging is formatted with its filesystem.
+		{"", directcsi.DriveStatusAvailable, true},
+		// Drive never staged has its filesystem mounted.
+		{"/var/lib/direct-csi/mnt/test-drive", directcsi.DriveStatusReady, false},
+	}
+
+	for i, testCase := range testCases {
+		testObjects := []runtime.Object{
+			&directcsi.DirectCSIDrive{
+				TypeMeta: utils.DirectCSIDriveTypeMeta(),
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-drive",
+					Finalizers: []string{
+						directcsi.DirectCSIDriveFinalizerDataProtection,
+						directcsi.DirectCSIDriveFinalizerPrefix + "test-volume",
+					},
+				},
+				Status: directcsi.DirectCSIDriveStatus{
+					NodeName:          testNodeName,
+					DriveStatus:       directcsi.DriveStatusInUse,
+					AllocatedCapacity: mb100,
+					TotalCapacity:     mb100,
+					BlockVolume:       "test-volume",
+					Filesystem:        "ext4",
+					Mountpoint:        testCase.mountpoint,
+				},
+			},
+			&directcsi.DirectCSIVolume{
+				TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-volume",
+					Finalizers: []string{directcsi.DirectCSIVolumeFinalizerPurgeProtection},
+				},
+				Status: directcsi.DirectCSIVolumeStatus{
+					NodeName:      testNodeName,
+					Drive:         "test-drive",
+					TotalCapacity: mb100,
+				},
+			},
+		}
+
+		vl := createFakeVolumeEventListener(testObjects...)
+		ctx := context.TODO()
+		volume, err := client.GetLatestDirectCSIVolumeInterface().Get(ctx, "test-volume", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			t.Fatalf("case %v: error while getting the volume object: %+v", i+1, err)
+		}
+
+		now := metav1.Now()
+		volume.DeletionTimestamp = &now
+		if err := vl.Handle(ctx, listener.EventArgs{Event: listener.DeleteEvent, Object: volume}); err != nil {
+			t.Fatalf("case %v: error while invoking the volume delete listener: %+v", i+1, err)
+		}
+
+		drive, err := client.GetLatestDirectCSIDriveInterface().Get(ctx, "test-drive", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			t.Fatalf("case %v: error while getting the drive object: %+v", i+1, err)
+		}
+		if drive.Status.BlockVolume != "" {
+			t.Fatalf("case %v: block volume is not cleared. Got: %s", i+1, drive.Status.BlockVolume)
+		}
+		if drive.Status.DriveStatus != testCase.expectedStatus {
+			t.Fatalf("case %v: unexpected drive status set. Expected: %s, Got: %s", i+1, testCase.expectedStatus, drive.Status.DriveStatus)
+		}
+		if len(drive.GetFinalizers()) != 1 || drive.GetFinalizers()[0] != directcsi.DirectCSIDriveFinalizerDataProtection {
+			t.Fatalf("case %v: unexpected drive finalizers: %v", i+1, drive.GetFinalizers())
+		}
+		if drive.Status.FreeCapacity != mb100 || drive.Status.AllocatedCapacity != 0 {
+			t.Fatalf("case %v: capacity not released; free capacity: %v, allocated capacity: %v", i+1, drive.Status.FreeCapacity, drive.Status.AllocatedCapacity)
+		}
+
+		switch {
+		case !testCase.expectFormat && drive.Spec.RequestedFormat != nil:
+			t.Fatalf("case %v: unexpected format requested: %+v", i+1, drive.Spec.RequestedFormat)
+		case testCase.expectFormat && (drive.Spec.RequestedFormat == nil || !drive.Spec.RequestedFormat.Force || drive.Spec.RequestedFormat.Filesystem != "ext4"):
+			t.Fatalf("case %v: forced format with ext4 filesystem is not requested: %+v", i+1, drive.Spec.RequestedFormat)
+		}
+	}
+}
+
+func TestDeleteEventHandleWithData(t *testing.T) {
+	testCases := []struct {
+		policy       DataReclaimPolicy
+		expectedPath string
+	}{
+		{DataReclaimPolicyDelete, filepath.Join(trashDir, "test-volume-test-uid")},
+		{DataReclaimPolicyRetain, "test-volume"},
+		{DataReclaimPolicyArchive, filepath.Join(archiveDir, "test-volume-test-uid")},
+	}
+
+	for i, testCase := range testCases {
+		driveDir := t.TempDir()
+		hostPath := filepath.Join(driveDir, "test-volume")
+		if err := os.MkdirAll(filepath.Join(hostPath, "data"), 0755); err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+
+		testObjects := []runtime.Object{
+			&directcsi.DirectCSIDrive{
+				TypeMeta: utils.DirectCSIDriveTypeMeta(),
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-drive",
+					Finalizers: []string{
+						directcsi.DirectCSIDriveFinalizerDataProtection,
+						directcsi.DirectCSIDriveFinalizerPrefix + "test-volume",
+					},
+				},
+				Status: directcsi.DirectCSIDriveStatus{
+					NodeName:          testNodeName,
+					DriveStatus:       directcsi.DriveStatusInUse,
+					FreeCapacity:      mb50,
+					AllocatedCapacity: mb50,
+					TotalCapacity:     mb100,
+				},
+			},
+			&directcsi.DirectCSIVolume{
+				TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-volume",
+					UID:        "test-uid",
+					Labels:     map[string]string{string(utils.DataReclaimPolicyLabelKey): string(testCase.policy)},
+					Finalizers: []string{directcsi.DirectCSIVolumeFinalizerPurgeProtection},
+				},
+				Status: directcsi.DirectCSIVolumeStatus{
+					NodeName:      testNodeName,
+					HostPath:      hostPath,
+					Drive:         "test-drive",
+					TotalCapacity: mb50,
+				},
+			},
+		}
+
+		vl := createFakeVolumeEventListener(testObjects...)
+		var quotaPath string
+		vl.setQuota = func(ctx context.Context, device, path, volumeID string, quota xfs.Quota) error {
+			quotaPath = path
+			return nil
+		}
+
+		ctx := context.TODO()
+		volume, err := client.GetLatestDirectCSIVolumeInterface().Get(ctx, "test-volume", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+		now := metav1.Now()
+		volume.DeletionTimestamp = &now
+
+		if err := vl.Handle(ctx, listener.EventArgs{Event: listener.DeleteEvent, Object: volume}); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+
+		expectedPath := filepath.Join(driveDir, testCase.expectedPath)
+		if quotaPath != expectedPath {
+			t.Fatalf("case %v: expected quota cleared on: %v, got: %v", i+1, expectedPath, quotaPath)
+		}
+		if _, err := os.Stat(filepath.Join(expectedPath, "data")); err != nil {
+			t.Fatalf("case %v: data not found in %v; %v", i+1, expectedPath, err)
+		}
+
+		// Capacity is released once the quota is cleared.
+		drive, err := client.GetLatestDirectCSIDriveInterface().Get(ctx, "test-drive", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+		if drive.Status.FreeCapacity != mb100 || drive.Status.AllocatedCapacity != 0 {
+			t.Fatalf("case %v: capacity not released; free capacity: %v, allocated capacity: %v", i+1, drive.Status.FreeCapacity, drive.Status.AllocatedCapacity)
+		}
+		if len(drive.GetFinalizers()) != 1 {
+			t.Fatalf("case %v: unexpected drive finalizers: %v", i+1, drive.GetFinalizers())
+		}
+		if len(volume.GetFinalizers()) != 0 {
+			t.Fatalf("case %v: volume finalizers are not empty: %v", i+1, volume.GetFinalizers())
+		}
+	}
+}